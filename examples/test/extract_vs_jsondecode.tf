@@ -99,7 +99,7 @@ resource "httpx_request" "downstream" {
     id = httpx_request.large_api_response.outputs["important_id"]
   }
 
-  headers = {
+  request_headers = {
     "X-Request-Id" = httpx_request.large_api_response.outputs["request_id"]
   }
 }
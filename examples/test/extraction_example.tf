@@ -82,6 +82,35 @@ resource "httpx_request" "use_extracted" {
   }
 }
 
+# Example 5: Extract arrays into list outputs
+resource "httpx_request" "extract_lists" {
+  url    = "https://httpbin.org/json"
+  method = "GET"
+
+  expect {
+    status_codes = [200]
+  }
+
+  # Each slide title becomes an element of outputs_lists["slide_titles"]
+  extract {
+    name          = "slide_titles"
+    json_path     = "slideshow.slides"
+    for_each_path = "title"
+  }
+}
+
+# Iterate over an extracted collection
+resource "httpx_request" "per_slide" {
+  for_each = toset(httpx_request.extract_lists.outputs_lists["slide_titles"])
+
+  url    = "https://httpbin.org/get"
+  method = "GET"
+
+  query = {
+    title = each.value
+  }
+}
+
 # Outputs to see extracted values
 output "extracted_json_values" {
   value = {
@@ -30,7 +30,7 @@ resource "httpx_request" "test_post" {
   url    = "https://httpbin.org/post"
   method = "POST"
 
-  headers = {
+  request_headers = {
     "Content-Type" = "application/json"
   }
 
@@ -94,3 +94,38 @@ output "retry_500_status" {
   sensitive = false
   description = "Status code after retries (should be 500)"
 }
+
+# Test retry with decorrelated backoff (spreads out parallel retries)
+resource "httpx_request" "test_retry_decorrelated" {
+  url    = "https://httpbin.org/get"
+  method = "GET"
+
+  retry {
+    attempts     = 5
+    min_delay_ms = 200
+    max_delay_ms = 5000
+    backoff      = "decorrelated"
+  }
+
+  expect {
+    status_codes = [200]
+  }
+}
+
+# Test retry with exponential backoff and full jitter
+resource "httpx_request" "test_retry_full_jitter" {
+  url    = "https://httpbin.org/get"
+  method = "GET"
+
+  retry {
+    attempts     = 5
+    min_delay_ms = 200
+    max_delay_ms = 5000
+    backoff      = "exponential"
+    jitter_mode  = "full"
+  }
+
+  expect {
+    status_codes = [200]
+  }
+}
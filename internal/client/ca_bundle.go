@@ -0,0 +1,80 @@
+package client
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+)
+
+// NewRootCAs builds the pool of CAs trusted for server certificates from ca_cert_pem,
+// ca_bundle_file and ca_bundle_dir. It returns nil, meaning the system roots, when none are set,
+// and with append_system_cas the custom CAs are added on top of the system roots instead of
+// replacing them. Files are read every time a client is built, so renewed trust stores are
+// picked up without restarting the provider.
+func NewRootCAs(cfg *config.ProviderConfig) (*x509.CertPool, error) {
+	hasInline := cfg.CaCertPem != nil && *cfg.CaCertPem != ""
+	if !hasInline && cfg.CaBundleFile == "" && cfg.CaBundleDir == "" {
+		return nil, nil
+	}
+
+	pool := x509.NewCertPool()
+	if cfg.AppendSystemCAs {
+		systemPool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load system CAs for append_system_cas: %w", err)
+		}
+		pool = systemPool
+	}
+	if hasInline && !pool.AppendCertsFromPEM([]byte(*cfg.CaCertPem)) {
+		return nil, fmt.Errorf("failed to parse CA certificate")
+	}
+
+	if cfg.CaBundleFile != "" {
+		data, err := os.ReadFile(cfg.CaBundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca_bundle_file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("ca_bundle_file %s contains no PEM certificates", cfg.CaBundleFile)
+		}
+	}
+
+	if cfg.CaBundleDir != "" {
+		if err := appendCADir(pool, cfg.CaBundleDir); err != nil {
+			return nil, err
+		}
+	}
+	return pool, nil
+}
+
+// appendCADir adds the certificates of every file in dir to pool. Files without PEM certificates
+// are skipped, like the system trust store directories, but the directory must hold at least one.
+func appendCADir(pool *x509.CertPool, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read ca_bundle_dir: %w", err)
+	}
+
+	loaded := 0
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		// Stat follows symlinks, as trust store directories are often made of them
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s from ca_bundle_dir: %w", entry.Name(), err)
+		}
+		if pool.AppendCertsFromPEM(data) {
+			loaded++
+		}
+	}
+	if loaded == 0 {
+		return fmt.Errorf("ca_bundle_dir %s contains no PEM certificates", dir)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+)
+
+func TestCABundles(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	dir := t.TempDir()
+	bundleFile := filepath.Join(dir, "bundle.pem")
+	if err := os.WriteFile(bundleFile, serverCA, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bundleDir := filepath.Join(dir, "certs")
+	if err := os.Mkdir(bundleDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bundleDir, "README"), []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(bundleFile, filepath.Join(bundleDir, "server.crt")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  *config.ProviderConfig
+		trusted bool
+	}{
+		{name: "system roots", config: &config.ProviderConfig{TimeoutMs: 5000}},
+		{name: "bundle file", config: &config.ProviderConfig{TimeoutMs: 5000, CaBundleFile: bundleFile}, trusted: true},
+		{name: "bundle dir", config: &config.ProviderConfig{TimeoutMs: 5000, CaBundleDir: bundleDir}, trusted: true},
+		{
+			name:    "bundle file on top of system roots",
+			config:  &config.ProviderConfig{TimeoutMs: 5000, CaBundleFile: bundleFile, AppendSystemCAs: true},
+			trusted: true,
+		},
+		{name: "system roots only", config: &config.ProviderConfig{TimeoutMs: 5000, AppendSystemCAs: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewHTTPClient(tt.config)
+			if err != nil {
+				t.Fatalf("NewHTTPClient() error = %v", err)
+			}
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err := client.Do(req)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			if (err == nil) != tt.trusted {
+				t.Errorf("Do() error = %v, want trusted = %v", err, tt.trusted)
+			}
+		})
+	}
+}
+
+func TestNewRootCAsErrors(t *testing.T) {
+	dir := t.TempDir()
+	junk := filepath.Join(dir, "junk.pem")
+	if err := os.WriteFile(junk, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, cfg := range map[string]*config.ProviderConfig{
+		"missing file":        {CaBundleFile: filepath.Join(dir, "missing.pem")},
+		"file without certs":  {CaBundleFile: junk},
+		"missing dir":         {CaBundleDir: filepath.Join(dir, "missing")},
+		"dir without certs":   {CaBundleDir: dir},
+		"invalid inline cert": {CaCertPem: stringPtr("not a certificate")},
+	} {
+		if _, err := NewRootCAs(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if pool, err := NewRootCAs(&config.ProviderConfig{}); pool != nil || err != nil {
+		t.Errorf("NewRootCAs() without CAs = %v, %v, want nil, nil", pool, err)
+	}
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/utils"
+)
+
+// Record modes for record_mode
+const (
+	RecordModeRecord = "record"
+	RecordModeReplay = "replay"
+)
+
+// ValidateRecordMode checks a record_mode and cassette_dir combination.
+// An empty mode disables recording and replaying.
+func ValidateRecordMode(mode string, cassetteDir string) error {
+	switch mode {
+	case "":
+		return nil
+	case RecordModeRecord, RecordModeReplay:
+		if cassetteDir == "" {
+			return fmt.Errorf("cassette_dir is required when record_mode is %q", mode)
+		}
+		return nil
+	default:
+		return fmt.Errorf("record_mode must be 'record' or 'replay', got %q", mode)
+	}
+}
+
+// cassetteDroppedHeaders are response headers carrying credentials or session state, never
+// written to a cassette
+var cassetteDroppedHeaders = []string{"Set-Cookie", "Set-Cookie2", "Authorization", "Proxy-Authorization", "Authentication-Info"}
+
+// cassetteEntry is the on-disk form of one recorded exchange
+type cassetteEntry struct {
+	Method     string              `json:"method"`
+	URL        string              `json:"url"`
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body,omitempty"`
+	BodyBase64 []byte              `json:"body_base64,omitempty"`
+}
+
+// cassetteTransport records responses to, or replays them from, a cassette directory.
+// Each exchange is stored as one JSON file named after a hash of the method, URL and request body,
+// so request header values such as credentials never affect matching and are never written to
+// disk. The recorded URL and response headers are redacted like the audit log, and response
+// headers carrying credentials or cookies are dropped.
+type cassetteTransport struct {
+	next      http.RoundTripper
+	mode      string
+	dir       string
+	redaction utils.Redaction
+}
+
+// NewCassetteTransport wraps next with record/replay behavior. In replay mode next is never called.
+func NewCassetteTransport(next http.RoundTripper, mode string, cassetteDir string, redaction utils.Redaction) (http.RoundTripper, error) {
+	if err := ValidateRecordMode(mode, cassetteDir); err != nil {
+		return nil, err
+	}
+	if mode == "" {
+		return next, nil
+	}
+	return &cassetteTransport{next: next, mode: mode, dir: cassetteDir, redaction: redaction}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body for cassette: %w", err)
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	path := filepath.Join(t.dir, cassetteKey(req.Method, req.URL.String(), reqBody)+".json")
+
+	if t.mode == RecordModeReplay {
+		return replayCassette(req, path)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	return recordCassette(req, resp, path, t.redaction)
+}
+
+// cassetteKey identifies an exchange by method, URL and request body
+func cassetteKey(method string, url string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(url))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordCassette saves the response to path and returns an equivalent response
+func recordCassette(req *http.Request, resp *http.Response, path string, redaction utils.Redaction) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body for cassette: %w", err)
+	}
+
+	entry := cassetteEntry{
+		Method:     req.Method,
+		URL:        redaction.Apply(req.URL.String()),
+		StatusCode: resp.StatusCode,
+		Headers:    cassetteHeaders(resp.Header, redaction),
+	}
+	if utf8.Valid(body) {
+		entry.Body = string(body)
+	} else {
+		entry.BodyBase64 = body
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	// Response bodies may still hold secrets, so only the current user can read cassettes
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp, nil
+}
+
+// cassetteHeaders returns the response headers to record: headers carrying credentials or
+// cookies are dropped, redact_headers values are masked and the rest is redacted like the audit log
+func cassetteHeaders(header http.Header, redaction utils.Redaction) http.Header {
+	recorded := make(http.Header, len(header))
+	for name, values := range header {
+		dropped := false
+		for _, drop := range cassetteDroppedHeaders {
+			if http.CanonicalHeaderKey(drop) == http.CanonicalHeaderKey(name) {
+				dropped = true
+				break
+			}
+		}
+		if dropped {
+			continue
+		}
+		for _, value := range values {
+			recorded.Add(name, redaction.Apply(utils.RedactHeaderValue(name, value, redaction.Headers)))
+		}
+	}
+	return recorded
+}
+
+// replayCassette builds a response from the exchange recorded at path
+func replayCassette(req *http.Request, path string) (*http.Response, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is built from the configured cassette directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no recorded response for %s %s in cassette %s", req.Method, req.URL.String(), path)
+		}
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var entry cassetteEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+
+	body := []byte(entry.Body)
+	if entry.BodyBase64 != nil {
+		body = entry.BodyBase64
+	}
+
+	header := http.Header(entry.Headers)
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.StatusCode, http.StatusText(entry.StatusCode)),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+)
+
+func TestValidateRecordMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		dir     string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"record", "cassettes", false},
+		{"replay", "cassettes", false},
+		{"replay", "", true},
+		{"playback", "cassettes", true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateRecordMode(tt.mode, tt.dir)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateRecordMode(%q, %q) error = %v, wantErr %v", tt.mode, tt.dir, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Echo", string(body))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+
+	do := func(mode string, body string) (*http.Response, string, error) {
+		httpClient, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000, RecordMode: mode, CassetteDir: dir})
+		if err != nil {
+			t.Fatalf("NewHTTPClient() error = %v", err)
+		}
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/items", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("NewRequest() error = %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return resp, string(respBody), nil
+	}
+
+	resp, body, err := do(RecordModeRecord, "payload")
+	if err != nil {
+		t.Fatalf("record request error = %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated || body != `{"id":"123"}` {
+		t.Errorf("record response = %d %q", resp.StatusCode, body)
+	}
+
+	entries, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 cassette, got %d", len(entries))
+	}
+	if data, _ := os.ReadFile(entries[0]); strings.Contains(string(data), "secret") {
+		t.Errorf("cassette must not contain request credentials: %s", data)
+	}
+
+	// Replay works without the server
+	server.Close()
+	resp, body, err = do(RecordModeReplay, "payload")
+	if err != nil {
+		t.Fatalf("replay request error = %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated || body != `{"id":"123"}` || resp.Header.Get("X-Echo") != "payload" {
+		t.Errorf("replay response = %d %q %v", resp.StatusCode, body, resp.Header)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 live request, got %d", requests)
+	}
+
+	// A different body was never recorded
+	if _, _, err := do(RecordModeReplay, "other"); err == nil || !strings.Contains(err.Error(), "no recorded response for POST") {
+		t.Errorf("expected missing cassette error, got %v", err)
+	}
+}
+
+func TestCassetteRedaction(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie-secret"})
+		w.Header().Set("X-Session-Token", "header-secret")
+		w.Header().Set("Location", "/items/1?signature=location-secret")
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	httpClient, err := NewHTTPClient(&config.ProviderConfig{
+		TimeoutMs:         5000,
+		RecordMode:        RecordModeRecord,
+		CassetteDir:       dir,
+		RedactHeaders:     []string{"X-Session-Token"},
+		RedactQueryParams: []string{"signature"},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/items?signature=url-secret", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("record request error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	entries, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 cassette, got %d", len(entries))
+	}
+	info, err := os.Stat(entries[0])
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("cassette mode = %v, want 0600", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(entries[0])
+	for _, secret := range []string{"cookie-secret", "Set-Cookie", "header-secret", "location-secret", "url-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette must not contain %q: %s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), "req-1") {
+		t.Errorf("cassette must keep other headers: %s", data)
+	}
+}
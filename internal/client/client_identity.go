@@ -0,0 +1,63 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// LoadClientCertificate returns the client certificate from client_cert_pem and client_key_pem,
+// decrypting the key with client_key_passphrase, or from client_pkcs12_file. It returns nil when
+// no client identity is configured.
+func LoadClientCertificate(cfg *config.ProviderConfig) (*tls.Certificate, error) {
+	if cfg.ClientPKCS12File != "" {
+		return loadPKCS12(cfg.ClientPKCS12File, cfg.ClientPKCS12Password)
+	}
+
+	if cfg.ClientCertPem != nil && cfg.ClientKeyPem != nil {
+		keyPEM, err := decryptKeyPEM([]byte(*cfg.ClientKeyPem), cfg.ClientKeyPassphrase)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair([]byte(*cfg.ClientCertPem), keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+		}
+		return &cert, nil
+	}
+	return nil, nil
+}
+
+// loadPKCS12 reads a .p12/.pfx bundle holding the client certificate, its private key and
+// optionally the intermediate chain, encrypted with either AES (the OpenSSL 3 default) or the
+// legacy 3DES/RC2.
+func loadPKCS12(path string, password string) (*tls.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client_pkcs12_file: %w", err)
+	}
+	key, leaf, chain, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode client_pkcs12_file: %w", err)
+	}
+
+	// X509KeyPair checks that the key belongs to the leaf, which must come first in the chain
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})
+	for _, cert := range chain {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client_pkcs12_file: %w", err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client_pkcs12_file: %w", err)
+	}
+	return &cert, nil
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// startMTLSServer returns a TLS server that requires a client certificate and reports the
+// common name of the one it received
+func startMTLSServer(t *testing.T) (*httptest.Server, *string) {
+	t.Helper()
+	var clientCN string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			clientCN = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server, &clientCN
+}
+
+func TestClientPKCS12File(t *testing.T) {
+	server, clientCN := startMTLSServer(t)
+
+	client, err := NewHTTPClient(&config.ProviderConfig{
+		TimeoutMs:            5000,
+		InsecureSkipVerify:   true,
+		ClientPKCS12File:     "testdata/client.p12",
+		ClientPKCS12Password: "changeit",
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if *clientCN != "httpx-test-client" {
+		t.Errorf("client certificate CN = %q, want httpx-test-client", *clientCN)
+	}
+}
+
+func TestClientPKCS12FileAES(t *testing.T) {
+	server, clientCN := startMTLSServer(t)
+
+	// Bundles exported by OpenSSL 3 without -legacy use AES-256-CBC and PBKDF2
+	certPEM, keyPEM := readTestdata(t, "client_cert.pem"), readTestdata(t, "client_key_pkcs8.pem")
+	pair, err := LoadClientCertificate(&config.ProviderConfig{ClientCertPem: &certPEM, ClientKeyPem: &keyPEM, ClientKeyPassphrase: "changeit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := pkcs12.Modern.Encode(pair.PrivateKey, leaf, nil, "changeit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "client-aes.p12")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewHTTPClient(&config.ProviderConfig{
+		TimeoutMs:            5000,
+		InsecureSkipVerify:   true,
+		ClientPKCS12File:     path,
+		ClientPKCS12Password: "changeit",
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if *clientCN != "httpx-test-client" {
+		t.Errorf("client certificate CN = %q, want httpx-test-client", *clientCN)
+	}
+}
+
+func TestLoadClientCertificate(t *testing.T) {
+	if cert, err := LoadClientCertificate(&config.ProviderConfig{}); cert != nil || err != nil {
+		t.Errorf("LoadClientCertificate() without an identity = %v, %v, want nil, nil", cert, err)
+	}
+	if _, err := LoadClientCertificate(&config.ProviderConfig{ClientPKCS12File: "testdata/client.p12", ClientPKCS12Password: "wrong"}); err == nil {
+		t.Error("expected an error for a wrong password")
+	}
+	if _, err := LoadClientCertificate(&config.ProviderConfig{ClientPKCS12File: "testdata/missing.p12"}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestClientKeyPassphrase(t *testing.T) {
+	server, clientCN := startMTLSServer(t)
+	certPEM := readTestdata(t, "client_cert.pem")
+
+	for _, keyFile := range []string{"client_key_pkcs8.pem", "client_key_legacy.pem"} {
+		t.Run(keyFile, func(t *testing.T) {
+			keyPEM := readTestdata(t, keyFile)
+			cfg := &config.ProviderConfig{
+				TimeoutMs:           5000,
+				InsecureSkipVerify:  true,
+				ClientCertPem:       &certPEM,
+				ClientKeyPem:        &keyPEM,
+				ClientKeyPassphrase: "changeit",
+			}
+			client, err := NewHTTPClient(cfg)
+			if err != nil {
+				t.Fatalf("NewHTTPClient() error = %v", err)
+			}
+			*clientCN = ""
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			_ = resp.Body.Close()
+			if *clientCN != "httpx-test-client" {
+				t.Errorf("client certificate CN = %q, want httpx-test-client", *clientCN)
+			}
+
+			cfg.ClientKeyPassphrase = "wrong"
+			if _, err := LoadClientCertificate(cfg); !errors.Is(err, errIncorrectPassphrase) {
+				t.Errorf("LoadClientCertificate() with a wrong passphrase error = %v", err)
+			}
+			cfg.ClientKeyPassphrase = ""
+			if _, err := LoadClientCertificate(cfg); err == nil || !strings.Contains(err.Error(), "set client_key_passphrase") {
+				t.Errorf("LoadClientCertificate() without a passphrase error = %v", err)
+			}
+		})
+	}
+}
+
+func readTestdata(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
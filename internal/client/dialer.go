@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+)
+
+// defaultDNSPort is used for dns_servers entries without a port
+const defaultDNSPort = "53"
+
+// IP families for ip_family
+const (
+	IPFamilyAny  = "any"
+	IPFamilyIPv4 = "ipv4"
+	IPFamilyIPv6 = "ipv6"
+)
+
+// dialContextFunc is the signature of http.Transport.DialContext
+type dialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// NormalizeDNSServers checks dns_servers entries and returns them as host:port addresses.
+// Entries must be IP addresses, optionally with a port.
+func NormalizeDNSServers(servers []string) ([]string, error) {
+	normalized := make([]string, 0, len(servers))
+	for _, server := range servers {
+		host, port, err := net.SplitHostPort(server)
+		if err != nil {
+			host, port = server, defaultDNSPort
+		}
+		if net.ParseIP(host) == nil {
+			return nil, fmt.Errorf("dns_servers entries must be IP addresses with an optional port, got %q", server)
+		}
+		normalized = append(normalized, net.JoinHostPort(host, port))
+	}
+	return normalized, nil
+}
+
+// ValidateIPFamily checks an ip_family value. An empty value means "any".
+func ValidateIPFamily(family string) error {
+	switch family {
+	case "", IPFamilyAny, IPFamilyIPv4, IPFamilyIPv6:
+		return nil
+	default:
+		return fmt.Errorf("ip_family must be 'any', 'ipv4' or 'ipv6', got %q", family)
+	}
+}
+
+// ValidateLocalAddress checks a local_address value against ip_family. An empty address lets
+// the system pick the source address.
+func ValidateLocalAddress(address string, family string) error {
+	if address == "" {
+		return nil
+	}
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return fmt.Errorf("local_address must be an IP address, got %q", address)
+	}
+	if (family == IPFamilyIPv4 && ip.To4() == nil) || (family == IPFamilyIPv6 && ip.To4() != nil) {
+		return fmt.Errorf("local_address %s is not an %s address", address, family)
+	}
+	return nil
+}
+
+// newDialContext returns the transport dial function for cfg. Host names are resolved through
+// dns_servers when set, each lookup is bounded by dns_timeout_ms, connections are limited to
+// ip_family's addresses and made from local_address.
+func newDialContext(cfg *config.ProviderConfig) dialContextFunc {
+	dial := newResolvingDialContext(cfg)
+	var suffix string
+	switch cfg.IPFamily {
+	case IPFamilyIPv4:
+		suffix = "4"
+	case IPFamilyIPv6:
+		suffix = "6"
+	default:
+		return dial
+	}
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		if network == "tcp" || network == "udp" {
+			network += suffix
+		}
+		return dial(ctx, network, address)
+	}
+}
+
+// newDialer returns the dialer for the connect timeout, source address and TCP keepalive
+// settings of cfg
+func newDialer(cfg *config.ProviderConfig) *net.Dialer {
+	dialer := &net.Dialer{
+		Timeout: time.Duration(cfg.ConnectTimeoutMs) * time.Millisecond,
+	}
+	if cfg.LocalAddress != "" {
+		dialer.LocalAddr = &net.TCPAddr{IP: net.ParseIP(cfg.LocalAddress)}
+	}
+	if cfg.TCPKeepAliveIntervalMs > 0 {
+		// Probe idle connections at this interval so NATs and firewalls keep them open
+		interval := time.Duration(cfg.TCPKeepAliveIntervalMs) * time.Millisecond
+		dialer.KeepAliveConfig = net.KeepAliveConfig{Enable: true, Idle: interval, Interval: interval}
+	}
+	return dialer
+}
+
+// newResolvingDialContext returns the dial function honoring the DNS settings of cfg
+func newResolvingDialContext(cfg *config.ProviderConfig) dialContextFunc {
+	dialer := newDialer(cfg)
+	if len(cfg.DNSServers) == 0 && cfg.DNSTimeoutMs <= 0 {
+		return dialer.DialContext
+	}
+
+	resolver := net.DefaultResolver
+	if len(cfg.DNSServers) > 0 {
+		resolver = newServerResolver(cfg.DNSServers)
+	}
+	d := &resolvingDialer{
+		dialer:     dialer,
+		resolver:   resolver,
+		dnsTimeout: time.Duration(cfg.DNSTimeoutMs) * time.Millisecond,
+	}
+	return d.DialContext
+}
+
+// newServerResolver returns a resolver that sends queries to servers, rotating through them
+// so a server that stops answering is skipped on the resolver's next attempt
+func newServerResolver(servers []string) *net.Resolver {
+	var next atomic.Uint64
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			server := servers[(next.Add(1)-1)%uint64(len(servers))]
+			var d net.Dialer
+			return d.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// resolvingDialer resolves host names itself so the lookup gets its own timeout, separate from
+// connect_timeout_ms
+type resolvingDialer struct {
+	dialer     *net.Dialer
+	resolver   *net.Resolver
+	dnsTimeout time.Duration
+}
+
+// DialContext resolves address and connects to each resolved IP in turn until one succeeds
+func (d *resolvingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || net.ParseIP(host) != nil {
+		return d.dialer.DialContext(ctx, network, address)
+	}
+
+	lookupCtx := ctx
+	if d.dnsTimeout > 0 {
+		var cancel context.CancelFunc
+		lookupCtx, cancel = context.WithTimeout(ctx, d.dnsTimeout)
+		defer cancel()
+	}
+	ips, err := d.resolver.LookupIP(lookupCtx, lookupNetwork(network), host)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	for _, ip := range ips {
+		conn, err := d.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, firstErr
+}
+
+// lookupNetwork returns the LookupIP network matching a dial network, so tcp4 and tcp6 dials
+// only resolve addresses of their family
+func lookupNetwork(network string) string {
+	switch network {
+	case "tcp4", "udp4":
+		return "ip4"
+	case "tcp6", "udp6":
+		return "ip6"
+	default:
+		return "ip"
+	}
+}
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+)
+
+// startDNSServer answers A queries for every name with 127.0.0.1 and AAAA queries with no
+// records. With answer false it reads queries without replying. It returns the server address
+// and a channel receiving each queried name.
+func startDNSServer(t *testing.T, answer bool) (string, <-chan string) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	queries := make(chan string, 16)
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			query := buf[:n]
+
+			// Walk the question name to find the end of the question section
+			var labels []string
+			offset := 12
+			for offset < n && query[offset] != 0 {
+				length := int(query[offset])
+				labels = append(labels, string(query[offset+1:offset+1+length]))
+				offset += 1 + length
+			}
+			offset++
+			qtype := binary.BigEndian.Uint16(query[offset:])
+			question := query[12 : offset+4]
+
+			select {
+			case queries <- strings.Join(labels, "."):
+			default:
+			}
+			if !answer {
+				continue
+			}
+
+			reply := append([]byte{}, query[:2]...)
+			reply = append(reply, 0x81, 0x80, 0, 1, 0, 0, 0, 0, 0, 0)
+			reply = append(reply, question...)
+			if qtype == 1 {
+				reply[7] = 1
+				reply = append(reply, 0xc0, 0x0c, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4, 127, 0, 0, 1)
+			}
+			_, _ = conn.WriteTo(reply, addr)
+		}
+	}()
+	return conn.LocalAddr().String(), queries
+}
+
+func TestDNSServers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	dnsServer, queries := startDNSServer(t, true)
+	client, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000, DNSServers: []string{dnsServer}})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://api.internal.test:"+port+"/", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+	if name := <-queries; name != "api.internal.test" {
+		t.Errorf("queried name = %q, want api.internal.test", name)
+	}
+}
+
+func TestDNSTimeout(t *testing.T) {
+	dnsServer, queries := startDNSServer(t, false)
+	client, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000, DNSServers: []string{dnsServer}, DNSTimeoutMs: 100})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	start := time.Now()
+	req, _ := http.NewRequest(http.MethodGet, "http://api.internal.test/", nil)
+	_, err = client.Do(req)
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		t.Fatalf("Do() error = %v, want a DNS error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("lookup took %s, want it bounded by dns_timeout_ms", elapsed)
+	}
+	if len(queries) == 0 {
+		t.Error("expected the query to reach the configured DNS server")
+	}
+}
+
+func TestNormalizeDNSServers(t *testing.T) {
+	got, err := NormalizeDNSServers([]string{"10.0.0.2", "10.0.0.3:5353", "::1", "[fd00::1]:53"})
+	if err != nil {
+		t.Fatalf("NormalizeDNSServers() error = %v", err)
+	}
+	want := []string{"10.0.0.2:53", "10.0.0.3:5353", "[::1]:53", "[fd00::1]:53"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("NormalizeDNSServers() = %v, want %v", got, want)
+	}
+
+	if _, err := NormalizeDNSServers([]string{"dns.example.com"}); err == nil {
+		t.Error("expected an error for a host name")
+	}
+}
+
+func TestIPFamily(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	// The DNS stub only has an IPv4 address for every name
+	dnsServer, _ := startDNSServer(t, true)
+	do := func(family string) error {
+		client, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000, DNSServers: []string{dnsServer}, IPFamily: family})
+		if err != nil {
+			t.Fatalf("NewHTTPClient() error = %v", err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, "http://api.internal.test:"+port+"/", nil)
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := do(IPFamilyIPv4); err != nil {
+		t.Errorf("ipv4: Do() error = %v", err)
+	}
+	if err := do(IPFamilyAny); err != nil {
+		t.Errorf("any: Do() error = %v", err)
+	}
+	if err := do(IPFamilyIPv6); err == nil {
+		t.Error("ipv6: expected an error without an IPv6 address")
+	}
+
+	if err := ValidateIPFamily("ipv5"); err == nil {
+		t.Error("expected an error for an unknown ip_family")
+	}
+}
+
+func TestLocalAddress(t *testing.T) {
+	probe, err := net.Listen("tcp", "127.0.0.2:0")
+	if err != nil {
+		t.Skipf("127.0.0.2 is not available: %v", err)
+	}
+	_ = probe.Close()
+
+	var remoteAddr string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddr = r.RemoteAddr
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000, LocalAddress: "127.0.0.2"})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if host, _, _ := net.SplitHostPort(remoteAddr); host != "127.0.0.2" {
+		t.Errorf("request came from %s, want 127.0.0.2", remoteAddr)
+	}
+}
+
+func TestValidateLocalAddress(t *testing.T) {
+	if err := ValidateLocalAddress("10.0.0.5", IPFamilyIPv4); err != nil {
+		t.Errorf("ValidateLocalAddress() error = %v", err)
+	}
+	if err := ValidateLocalAddress("", IPFamilyIPv6); err != nil {
+		t.Errorf("ValidateLocalAddress() with no address error = %v", err)
+	}
+	if err := ValidateLocalAddress("eth0", IPFamilyAny); err == nil {
+		t.Error("expected an error for an interface name")
+	}
+	if err := ValidateLocalAddress("10.0.0.5", IPFamilyIPv6); err == nil {
+		t.Error("expected an error for an IPv4 address with ip_family ipv6")
+	}
+}
+
+func TestNewDialerKeepAlive(t *testing.T) {
+	if got := newDialer(&config.ProviderConfig{}).KeepAliveConfig; got.Enable {
+		t.Errorf("KeepAliveConfig = %+v without tcp_keepalive_interval_ms, want Go's default", got)
+	}
+
+	got := newDialer(&config.ProviderConfig{TCPKeepAliveIntervalMs: 5000}).KeepAliveConfig
+	want := net.KeepAliveConfig{Enable: true, Idle: 5 * time.Second, Interval: 5 * time.Second}
+	if got != want {
+		t.Errorf("KeepAliveConfig = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha1" //nolint:gosec // hmacWithSHA1 is the PBKDF2 default PRF, not used for signatures
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+// errIncorrectPassphrase is returned when a key doesn't decrypt with client_key_passphrase
+var errIncorrectPassphrase = errors.New("incorrect client_key_passphrase")
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	pbkdf2PRFs = map[string]func() hash.Hash{
+		"1.2.840.113549.2.7":  sha1.New,
+		"1.2.840.113549.2.9":  sha256.New,
+		"1.2.840.113549.2.10": sha512.New384,
+		"1.2.840.113549.2.11": sha512.New,
+	}
+	// AES-CBC key sizes by OID
+	pbes2Ciphers = map[string]int{
+		"2.16.840.1.101.3.4.1.2":  16,
+		"2.16.840.1.101.3.4.1.22": 24,
+		"2.16.840.1.101.3.4.1.42": 32,
+	}
+)
+
+// encryptedPrivateKeyInfo is a PKCS#8 ENCRYPTED PRIVATE KEY
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt       []byte
+	Iterations int
+	KeyLength  int                      `asn1:"optional"`
+	PRF        pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decryptKeyPEM returns keyPEM with its private key decrypted using passphrase. It handles
+// PKCS#8 "ENCRYPTED PRIVATE KEY" blocks using PBES2 (what OpenSSL 3 writes) and legacy
+// Proc-Type encrypted RSA/EC blocks. Unencrypted keys are returned unchanged.
+func decryptKeyPEM(keyPEM []byte, passphrase string) ([]byte, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return keyPEM, nil
+	}
+
+	//nolint:staticcheck // Legacy PEM encryption is insecure but still what some PKIs issue
+	legacy := x509.IsEncryptedPEMBlock(block)
+	if block.Type != "ENCRYPTED PRIVATE KEY" && !legacy {
+		return keyPEM, nil
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("client_key_pem is encrypted, set client_key_passphrase")
+	}
+
+	if legacy {
+		//nolint:staticcheck // See above
+		der, err := x509.DecryptPEMBlock(block, []byte(passphrase))
+		if err != nil {
+			if errors.Is(err, x509.IncorrectPasswordError) {
+				return nil, errIncorrectPassphrase
+			}
+			return nil, fmt.Errorf("failed to decrypt client_key_pem: %w", err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: der}), nil
+	}
+
+	der, err := decryptPKCS8(block.Bytes, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// decryptPKCS8 decrypts a PBES2 EncryptedPrivateKeyInfo into PKCS#8 DER
+func decryptPKCS8(data []byte, passphrase string) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted client_key_pem: %w", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported client_key_pem encryption %s, only PBES2 is supported", info.Algorithm.Algorithm)
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse PBES2 parameters: %w", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported key derivation %s, only PBKDF2 is supported", params.KeyDerivationFunc.Algorithm)
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("failed to parse PBKDF2 parameters: %w", err)
+	}
+
+	prf := sha1.New
+	if len(kdf.PRF.Algorithm) > 0 {
+		var ok bool
+		if prf, ok = pbkdf2PRFs[kdf.PRF.Algorithm.String()]; !ok {
+			return nil, fmt.Errorf("unsupported PBKDF2 PRF %s", kdf.PRF.Algorithm)
+		}
+	}
+	keySize, ok := pbes2Ciphers[params.EncryptionScheme.Algorithm.String()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported client_key_pem cipher %s, only AES-CBC is supported", params.EncryptionScheme.Algorithm)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil || len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("invalid AES-CBC parameters in client_key_pem")
+	}
+	if len(info.EncryptedData) == 0 || len(info.EncryptedData)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("invalid encrypted client_key_pem length")
+	}
+
+	key, err := pbkdf2.Key(prf, passphrase, kdf.Salt, kdf.Iterations, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive client_key_pem key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	plain := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, info.EncryptedData)
+
+	// A wrong passphrase almost always shows up as bad PKCS#7 padding
+	padding := int(plain[len(plain)-1])
+	if padding == 0 || padding > aes.BlockSize || !bytes.Equal(plain[len(plain)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, errIncorrectPassphrase
+	}
+	der := plain[:len(plain)-padding]
+	if _, err := x509.ParsePKCS8PrivateKey(der); err != nil {
+		return nil, errIncorrectPassphrase
+	}
+	return der, nil
+}
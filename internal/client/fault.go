@@ -0,0 +1,163 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FaultInjection configures faults added to requests for testing retry behavior
+type FaultInjection struct {
+	// FailFirstAttempts fails the first N attempts of every request (matched by method and URL)
+	FailFirstAttempts int
+	// StatusCode is returned by failed attempts. 0 injects a connection error instead.
+	StatusCode int
+	// LatencyMs delays every attempt
+	LatencyMs int64
+	// HostLatency adds latency to attempts against matching hosts, keyed by host pattern.
+	// Patterns match the host with or without port and may use * wildcards, e.g. "*.example.com".
+	HostLatency map[string]LatencyDistribution
+}
+
+// LatencyDistribution describes latency drawn uniformly between MinMs and MaxMs, applied to
+// a Probability fraction of attempts
+type LatencyDistribution struct {
+	MinMs       int64
+	MaxMs       int64
+	Probability float64
+}
+
+// FaultInjector applies a FaultInjection, counting attempts across every client that shares it
+type FaultInjector struct {
+	cfg FaultInjection
+
+	mu       sync.Mutex
+	attempts map[string]int
+	rand     *rand.Rand
+}
+
+// NewFaultInjector creates an injector for cfg
+func NewFaultInjector(cfg FaultInjection) *FaultInjector {
+	return &FaultInjector{
+		cfg:      cfg,
+		attempts: make(map[string]int),
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // Latency sampling is not security sensitive
+	}
+}
+
+// ValidateFaultInjection checks fault injection settings
+func ValidateFaultInjection(cfg FaultInjection) error {
+	if cfg.FailFirstAttempts < 0 {
+		return fmt.Errorf("fail_first_attempts must not be negative, got %d", cfg.FailFirstAttempts)
+	}
+	if cfg.LatencyMs < 0 {
+		return fmt.Errorf("latency_ms must not be negative, got %d", cfg.LatencyMs)
+	}
+	if cfg.StatusCode != 0 && (cfg.StatusCode < 100 || cfg.StatusCode > 599) {
+		return fmt.Errorf("status_code must be between 100 and 599, got %d", cfg.StatusCode)
+	}
+	for pattern, dist := range cfg.HostLatency {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid host_latency pattern %q: %w", pattern, err)
+		}
+		if dist.MinMs < 0 || dist.MaxMs < dist.MinMs {
+			return fmt.Errorf("host_latency %q: need 0 <= min_ms <= max_ms, got %d and %d", pattern, dist.MinMs, dist.MaxMs)
+		}
+		if dist.Probability < 0 || dist.Probability > 1 {
+			return fmt.Errorf("host_latency %q: probability must be between 0 and 1, got %g", pattern, dist.Probability)
+		}
+	}
+	return nil
+}
+
+// latency returns the delay to add to req: the fixed LatencyMs plus a sample from every
+// host_latency distribution matching the request host
+func (f *FaultInjector) latency(req *http.Request) time.Duration {
+	delayMs := f.cfg.LatencyMs
+	host, hostname := strings.ToLower(req.URL.Host), strings.ToLower(req.URL.Hostname())
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for pattern, dist := range f.cfg.HostLatency {
+		pattern = strings.ToLower(pattern)
+		matchHost, _ := path.Match(pattern, host)
+		matchHostname, _ := path.Match(pattern, hostname)
+		if !matchHost && !matchHostname {
+			continue
+		}
+		if f.rand.Float64() >= dist.Probability {
+			continue
+		}
+		delayMs += dist.MinMs
+		if dist.MaxMs > dist.MinMs {
+			delayMs += f.rand.Int63n(dist.MaxMs - dist.MinMs + 1)
+		}
+	}
+	return time.Duration(delayMs) * time.Millisecond
+}
+
+// shouldFail counts an attempt of req and reports whether it is one of the first FailFirstAttempts
+func (f *FaultInjector) shouldFail(req *http.Request) bool {
+	key := req.Method + " " + req.URL.String()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.attempts[key]++
+	return f.attempts[key] <= f.cfg.FailFirstAttempts
+}
+
+// wrap returns a transport that injects faults before delegating to next
+func (f *FaultInjector) wrap(next http.RoundTripper) http.RoundTripper {
+	return &faultTransport{injector: f, next: next}
+}
+
+type faultTransport struct {
+	injector *FaultInjector
+	next     http.RoundTripper
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cfg := t.injector.cfg
+
+	if delay := t.injector.latency(req); delay > 0 {
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+
+	if !t.injector.shouldFail(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	if cfg.StatusCode == 0 {
+		// A dial error is classified as a connection failure by retry_on_errors
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused (injected fault)")}
+	}
+
+	body := fmt.Sprintf("injected fault: %d %s", cfg.StatusCode, http.StatusText(cfg.StatusCode))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cfg.StatusCode, http.StatusText(cfg.StatusCode)),
+		StatusCode:    cfg.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
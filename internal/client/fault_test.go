@@ -0,0 +1,123 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+)
+
+func TestFaultInjector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	do := func(injector *FaultInjector, url string) (*http.Response, error) {
+		// A fresh client per attempt, as ExecuteRequest does
+		httpClient, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000})
+		if err != nil {
+			t.Fatalf("NewHTTPClient() error = %v", err)
+		}
+		httpClient.SetFaultInjector(injector)
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		return httpClient.Do(req)
+	}
+
+	t.Run("status code for the first attempts", func(t *testing.T) {
+		injector := NewFaultInjector(FaultInjection{FailFirstAttempts: 2, StatusCode: http.StatusServiceUnavailable})
+		for attempt, want := range []int{503, 503, 200, 200} {
+			resp, err := do(injector, server.URL+"/a")
+			if err != nil {
+				t.Fatalf("attempt %d: Do() error = %v", attempt+1, err)
+			}
+			_, _ = io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if resp.StatusCode != want {
+				t.Errorf("attempt %d: status = %d, want %d", attempt+1, resp.StatusCode, want)
+			}
+		}
+
+		// Attempts are counted per request
+		resp, err := do(injector, server.URL+"/b")
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("first attempt of another URL: status = %d, want 503", resp.StatusCode)
+		}
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		injector := NewFaultInjector(FaultInjection{FailFirstAttempts: 1})
+		if _, err := do(injector, server.URL); err == nil {
+			t.Error("expected an injected connection error")
+		}
+		resp, err := do(injector, server.URL)
+		if err != nil {
+			t.Fatalf("second attempt: Do() error = %v", err)
+		}
+		_ = resp.Body.Close()
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		injector := NewFaultInjector(FaultInjection{LatencyMs: 30})
+		start := time.Now()
+		resp, err := do(injector, server.URL)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		_ = resp.Body.Close()
+		if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+			t.Errorf("expected at least 30ms of injected latency, took %v", elapsed)
+		}
+	})
+}
+
+func TestFaultInjector_HostLatency(t *testing.T) {
+	injector := NewFaultInjector(FaultInjection{
+		LatencyMs: 5,
+		HostLatency: map[string]LatencyDistribution{
+			"*.slow.example.com": {MinMs: 100, MaxMs: 200, Probability: 1},
+			"never.example.com":  {MinMs: 1000, MaxMs: 1000, Probability: 0},
+		},
+	})
+
+	for i := 0; i < 20; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://API.slow.example.com:8443/items", nil)
+		if d := injector.latency(req); d < 105*time.Millisecond || d > 205*time.Millisecond {
+			t.Fatalf("latency for a slow host = %v, want between 105ms and 205ms", d)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://never.example.com/items", nil)
+	if d := injector.latency(req); d != 5*time.Millisecond {
+		t.Errorf("latency with probability 0 = %v, want the fixed 5ms", d)
+	}
+	req, _ = http.NewRequest(http.MethodGet, "https://other.example.com/items", nil)
+	if d := injector.latency(req); d != 5*time.Millisecond {
+		t.Errorf("latency for an unmatched host = %v, want the fixed 5ms", d)
+	}
+}
+
+func TestValidateFaultInjection(t *testing.T) {
+	if err := ValidateFaultInjection(FaultInjection{FailFirstAttempts: 2, StatusCode: 503, LatencyMs: 100}); err != nil {
+		t.Errorf("ValidateFaultInjection() unexpected error = %v", err)
+	}
+	for _, cfg := range []FaultInjection{
+		{FailFirstAttempts: -1},
+		{LatencyMs: -1},
+		{StatusCode: 42},
+		{HostLatency: map[string]LatencyDistribution{"[": {}}},
+		{HostLatency: map[string]LatencyDistribution{"api.example.com": {MinMs: 10, MaxMs: 5, Probability: 1}}},
+		{HostLatency: map[string]LatencyDistribution{"api.example.com": {Probability: 1.5}}},
+	} {
+		if err := ValidateFaultInjection(cfg); err == nil {
+			t.Errorf("ValidateFaultInjection(%+v) expected error", cfg)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +9,7 @@ import (
 	"time"
 
 	"github.com/davidshato/terraform-provider-httpx/internal/config"
+	"github.com/davidshato/terraform-provider-httpx/internal/utils"
 )
 
 // HTTPClient wraps an http.Client with provider configuration
@@ -17,6 +17,7 @@ type HTTPClient struct {
 	client  *http.Client
 	config  *config.ProviderConfig
 	timeout time.Duration
+	limiter *RequestLimiter
 }
 
 // NewHTTPClient creates a new HTTP client from provider configuration
@@ -29,25 +30,29 @@ func NewHTTPClient(cfg *config.ProviderConfig) (*HTTPClient, error) {
 	}
 
 	// Configure TLS certificates if provided
-	if cfg.CaCertPem != nil && *cfg.CaCertPem != "" {
-		caCertPool := x509.NewCertPool()
-		if !caCertPool.AppendCertsFromPEM([]byte(*cfg.CaCertPem)) {
-			return nil, fmt.Errorf("failed to parse CA certificate")
-		}
-		tlsConfig.RootCAs = caCertPool
+	rootCAs, err := NewRootCAs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.RootCAs = rootCAs
+	if len(cfg.PinnedSPKISha256) > 0 {
+		tlsConfig.VerifyPeerCertificate = verifySPKIPins(cfg.PinnedSPKISha256)
 	}
 
-	if cfg.ClientCertPem != nil && cfg.ClientKeyPem != nil {
-		cert, err := tls.X509KeyPair([]byte(*cfg.ClientCertPem), []byte(*cfg.ClientKeyPem))
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse client certificate: %w", err)
-		}
-		tlsConfig.Certificates = []tls.Certificate{cert}
+	clientCert, err := LoadClientCertificate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
 	}
 
 	// Create transport
 	transport := &http.Transport{
-		TLSClientConfig: tlsConfig,
+		DialContext:           newDialContext(cfg),
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   time.Duration(cfg.TLSHandshakeTimeoutMs) * time.Millisecond,
+		ResponseHeaderTimeout: time.Duration(cfg.ResponseHeaderTimeoutMs) * time.Millisecond,
 	}
 
 	// Configure proxy if provided
@@ -57,11 +62,31 @@ func NewHTTPClient(cfg *config.ProviderConfig) (*HTTPClient, error) {
 			return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
 		}
 		transport.Proxy = http.ProxyURL(proxyURL)
+	} else if cfg.ProxyFromEnvironment {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+	if len(cfg.ProxyConnectHeaders) > 0 {
+		transport.ProxyConnectHeader = make(http.Header, len(cfg.ProxyConnectHeaders))
+		for name, value := range cfg.ProxyConnectHeaders {
+			transport.ProxyConnectHeader.Set(name, value)
+		}
+	}
+
+	// Record or replay responses if configured
+	redaction := utils.Redaction{Headers: cfg.RedactHeaders, QueryParams: cfg.RedactQueryParams, Patterns: cfg.RedactPatterns}
+	roundTripper, err := NewCassetteTransport(transport, cfg.RecordMode, cfg.CassetteDir, redaction)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serve canned responses instead of using the network in mock mode
+	if cfg.MockMode {
+		roundTripper = NewMockTransport(cfg.MockResponses)
 	}
 
 	// Create HTTP client
 	httpClient := &http.Client{
-		Transport: transport,
+		Transport: roundTripper,
 		Timeout:   timeout,
 	}
 
@@ -72,9 +97,72 @@ func NewHTTPClient(cfg *config.ProviderConfig) (*HTTPClient, error) {
 	}, nil
 }
 
+// SetLimiter shares a request limiter with the client. A nil limiter disables limiting.
+func (c *HTTPClient) SetLimiter(limiter *RequestLimiter) {
+	c.limiter = limiter
+}
+
+// SetFaultInjector routes requests through a shared fault injector. A nil injector disables fault injection.
+func (c *HTTPClient) SetFaultInjector(injector *FaultInjector) {
+	if injector == nil {
+		return
+	}
+	c.client.Transport = injector.wrap(c.client.Transport)
+}
+
 // Do executes an HTTP request
 func (c *HTTPClient) Do(req *http.Request) (*http.Response, error) {
-	return c.client.Do(req)
+	return c.doLimited(c.client, req)
+}
+
+// doLimited executes req once the limiter has a free slot, holding the slot until the response body is closed
+func (c *HTTPClient) doLimited(client *http.Client, req *http.Request) (*http.Response, error) {
+	release, err := c.limiter.acquire(req.Context(), req.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// maxRedirects matches the net/http default redirect limit
+const maxRedirects = 10
+
+// RedirectHop describes a redirect response that was followed
+type RedirectHop struct {
+	URL        string
+	StatusCode int
+	Location   string
+}
+
+// DoTrackingRedirects executes an HTTP request and returns every redirect hop that was followed
+func (c *HTTPClient) DoTrackingRedirects(req *http.Request) (*http.Response, []RedirectHop, error) {
+	var hops []RedirectHop
+
+	client := *c.client
+	client.CheckRedirect = func(next *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		hop := RedirectHop{
+			URL:      via[len(via)-1].URL.String(),
+			Location: next.URL.String(),
+		}
+		if next.Response != nil {
+			hop.StatusCode = next.Response.StatusCode
+		}
+		hops = append(hops, hop)
+		return nil
+	}
+
+	resp, err := c.doLimited(&client, req)
+	return resp, hops, err
 }
 
 // GetTimeout returns the configured timeout
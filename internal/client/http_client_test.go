@@ -3,6 +3,10 @@ package client
 import (
 	"bytes"
 	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -146,6 +150,178 @@ func TestGetTimeout(t *testing.T) {
 	}
 }
 
+func TestDoTrackingRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/start", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/middle", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/middle", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/final", http.StatusFound)
+	})
+	mux.HandleFunc("/final", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/start", nil)
+	resp, hops, err := client.DoTrackingRedirects(req)
+	if err != nil {
+		t.Fatalf("DoTrackingRedirects() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	expected := []RedirectHop{
+		{URL: server.URL + "/start", StatusCode: http.StatusMovedPermanently, Location: server.URL + "/middle"},
+		{URL: server.URL + "/middle", StatusCode: http.StatusFound, Location: server.URL + "/final"},
+	}
+	if len(hops) != len(expected) {
+		t.Fatalf("DoTrackingRedirects() hops = %v, want %v", hops, expected)
+	}
+	for i := range expected {
+		if hops[i] != expected[i] {
+			t.Errorf("hop %d = %+v, want %+v", i, hops[i], expected[i])
+		}
+	}
+	if got := resp.Request.URL.String(); got != server.URL+"/final" {
+		t.Errorf("final URL = %s, want %s", got, server.URL+"/final")
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/loop", nil)
+	if _, _, err := client.DoTrackingRedirects(req); err == nil {
+		t.Error("expected redirect loop to fail")
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestResponseHeaderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000, ResponseHeaderTimeoutMs: 50})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); err == nil || !strings.Contains(err.Error(), "timeout awaiting response headers") {
+		t.Errorf("Do() error = %v, want response header timeout", err)
+	}
+
+	client, err = NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+	req, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() without a response header timeout error = %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestProxyFromEnvironment(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	// http.ProxyFromEnvironment reads the environment once per process
+	t.Setenv("HTTP_PROXY", proxy.URL)
+	t.Setenv("NO_PROXY", "direct.example.test")
+
+	client, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000, ProxyFromEnvironment: true})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://api.example.test/status", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if len(proxied) != 1 || proxied[0] != "http://api.example.test/status" {
+		t.Errorf("proxied requests = %v, want the request to go through HTTP_PROXY", proxied)
+	}
+
+	proxied = nil
+	req, _ = http.NewRequest(http.MethodGet, "http://direct.example.test/status", nil)
+	if resp, err := client.Do(req); err == nil {
+		_ = resp.Body.Close()
+	}
+	if len(proxied) != 0 {
+		t.Errorf("proxied requests = %v, want NO_PROXY hosts to be reached directly", proxied)
+	}
+}
+
+func TestProxyConnectHeaders(t *testing.T) {
+	target := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+
+	var connectHeader string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		connectHeader = r.Header.Get("X-Proxy-Team")
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			_ = upstream.Close()
+			return
+		}
+		go func() {
+			_, _ = io.Copy(upstream, conn)
+			_ = upstream.Close()
+		}()
+		_, _ = io.Copy(conn, upstream)
+		_ = conn.Close()
+	}))
+	defer proxy.Close()
+
+	client, err := NewHTTPClient(&config.ProviderConfig{
+		TimeoutMs:           5000,
+		InsecureSkipVerify:  true,
+		ProxyUrl:            stringPtr(proxy.URL),
+		ProxyConnectHeaders: map[string]string{"X-Proxy-Team": "platform"},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, target.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+	if connectHeader != "platform" {
+		t.Errorf("CONNECT X-Proxy-Team = %q, want platform", connectHeader)
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+)
+
+// RequestLimiter bounds the number of requests in flight across every client that shares it,
+// and optionally allows only one request at a time per host
+type RequestLimiter struct {
+	slots   chan struct{}
+	perHost bool
+
+	mu    sync.Mutex
+	hosts map[string]chan struct{}
+}
+
+// NewRequestLimiter creates a limiter allowing at most maxConcurrency requests at once
+// (0 means unlimited). With serializePerHost, requests to the same host run one at a time.
+func NewRequestLimiter(maxConcurrency int, serializePerHost bool) *RequestLimiter {
+	l := &RequestLimiter{perHost: serializePerHost, hosts: make(map[string]chan struct{})}
+	if maxConcurrency > 0 {
+		l.slots = make(chan struct{}, maxConcurrency)
+	}
+	return l
+}
+
+// hostLock returns the single-slot channel guarding host
+func (l *RequestLimiter) hostLock(host string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock, ok := l.hosts[host]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		l.hosts[host] = lock
+	}
+	return lock
+}
+
+// acquire blocks until the request to host may run or ctx is done, returning a function that
+// frees what was acquired. The host lock is taken first so waiting for a busy host never holds
+// a global slot.
+func (l *RequestLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	var held []chan struct{}
+	releaseHeld := func() {
+		for i := len(held) - 1; i >= 0; i-- {
+			<-held[i]
+		}
+	}
+
+	if l.perHost {
+		lock := l.hostLock(strings.ToLower(host))
+		select {
+		case lock <- struct{}{}:
+			held = append(held, lock)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			held = append(held, l.slots)
+		case <-ctx.Done():
+			releaseHeld()
+			return nil, ctx.Err()
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(releaseHeld)
+	}, nil
+}
+
+// releasingBody frees a limiter slot once the response body is closed, so the
+// connection counts as in flight until the body has been consumed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
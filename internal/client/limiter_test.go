@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+)
+
+func TestRequestLimiter(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := NewRequestLimiter(2, false)
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each request gets its own client, as ExecuteRequest does
+			httpClient, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000})
+			if err != nil {
+				t.Errorf("NewHTTPClient() error = %v", err)
+				return
+			}
+			httpClient.SetLimiter(limiter)
+
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, _, err := httpClient.DoTrackingRedirects(req)
+			if err != nil {
+				t.Errorf("DoTrackingRedirects() error = %v", err)
+				return
+			}
+			_, _ = io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+	if len(limiter.slots) != 0 {
+		t.Errorf("expected all slots to be released, %d still held", len(limiter.slots))
+	}
+}
+
+func TestRequestLimiter_ContextCancelled(t *testing.T) {
+	limiter := NewRequestLimiter(1, false)
+	release, err := limiter.acquire(context.Background(), "api.example.com")
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx, "other.example.com"); err == nil {
+		t.Error("expected acquire to fail when the context is done")
+	}
+}
+
+func TestRequestLimiter_SerializePerHost(t *testing.T) {
+	newServer := func(maxInFlight *int) *httptest.Server {
+		var mu sync.Mutex
+		inFlight := 0
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			if inFlight > *maxInFlight {
+				*maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}))
+	}
+
+	var maxA, maxB int
+	serverA, serverB := newServer(&maxA), newServer(&maxB)
+	defer serverA.Close()
+	defer serverB.Close()
+
+	limiter := NewRequestLimiter(0, true)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		url := serverA.URL
+		if i%2 == 1 {
+			url = serverB.URL
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			httpClient, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000})
+			if err != nil {
+				t.Errorf("NewHTTPClient() error = %v", err)
+				return
+			}
+			httpClient.SetLimiter(limiter)
+
+			req, _ := http.NewRequest(http.MethodPost, url, nil)
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				t.Errorf("Do() error = %v", err)
+				return
+			}
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxA != 1 || maxB != 1 {
+		t.Errorf("expected one request at a time per host, got %d and %d", maxA, maxB)
+	}
+}
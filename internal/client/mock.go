@@ -0,0 +1,114 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+)
+
+// mockRoute is a compiled mock_response entry
+type mockRoute struct {
+	key      string
+	method   string
+	pattern  *regexp.Regexp
+	hasQuery bool
+	response config.MockResponse
+}
+
+// mockTransport serves canned responses and never touches the network
+type mockTransport struct {
+	routes []mockRoute
+}
+
+// NewMockTransport builds a RoundTripper from mock_response entries. Keys are URL patterns where
+// "*" matches any characters, optionally prefixed with a method ("POST https://api.example.com/*").
+// Patterns without a query string match the URL with its query removed. When several patterns
+// match, the longest one wins.
+func NewMockTransport(responses map[string]config.MockResponse) http.RoundTripper {
+	routes := make([]mockRoute, 0, len(responses))
+	for key, response := range responses {
+		method := ""
+		urlPattern := strings.TrimSpace(key)
+		if before, after, found := strings.Cut(urlPattern, " "); found {
+			method = strings.ToUpper(before)
+			urlPattern = strings.TrimSpace(after)
+		}
+
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(urlPattern), `\*`, ".*") + "$"
+		routes = append(routes, mockRoute{
+			key:      key,
+			method:   method,
+			pattern:  regexp.MustCompile(expr),
+			hasQuery: strings.Contains(urlPattern, "?"),
+			response: response,
+		})
+	}
+
+	// Most specific patterns first, ties broken alphabetically for determinism
+	sort.Slice(routes, func(i, j int) bool {
+		if len(routes[i].key) != len(routes[j].key) {
+			return len(routes[i].key) > len(routes[j].key)
+		}
+		return routes[i].key < routes[j].key
+	})
+
+	return &mockTransport{routes: routes}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	fullURL := req.URL.String()
+	withoutQuery := *req.URL
+	withoutQuery.RawQuery = ""
+	withoutQuery.Fragment = ""
+	baseURL := withoutQuery.String()
+
+	for _, route := range t.routes {
+		if route.method != "" && route.method != req.Method {
+			continue
+		}
+		target := baseURL
+		if route.hasQuery {
+			target = fullURL
+		}
+		if route.pattern.MatchString(target) {
+			return mockHTTPResponse(req, route.response), nil
+		}
+	}
+
+	return nil, fmt.Errorf("no mock_response matches %s %s", req.Method, fullURL)
+}
+
+// mockHTTPResponse converts a MockResponse into an *http.Response
+func mockHTTPResponse(req *http.Request, response config.MockResponse) *http.Response {
+	statusCode := response.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	header := http.Header{}
+	for name, value := range response.Headers {
+		header.Set(name, value)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(response.Body)),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+)
+
+func TestMockTransport(t *testing.T) {
+	httpClient, err := NewHTTPClient(&config.ProviderConfig{
+		TimeoutMs: 5000,
+		MockMode:  true,
+		MockResponses: map[string]config.MockResponse{
+			"https://api.example.com/*":          {Body: "fallback"},
+			"https://api.example.com/users/*":    {StatusCode: 200, Body: `{"id":"123"}`, Headers: map[string]string{"Content-Type": "application/json"}},
+			"POST https://api.example.com/users": {StatusCode: 201, Body: "created"},
+			"https://api.example.com/search?q=*": {Body: "search"},
+			"https://api.example.com/old":        {StatusCode: 301, Headers: map[string]string{"Location": "https://api.example.com/users/1"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	tests := []struct {
+		method     string
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{http.MethodGet, "https://api.example.com/users/1?verbose=true", 200, `{"id":"123"}`},
+		{http.MethodPost, "https://api.example.com/users", 201, "created"},
+		{http.MethodGet, "https://api.example.com/users", 200, "fallback"},
+		{http.MethodGet, "https://api.example.com/search?q=terraform", 200, "search"},
+		{http.MethodGet, "https://api.example.com/old", 200, `{"id":"123"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, strings.NewReader("{}"))
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus || string(body) != tt.wantBody {
+				t.Errorf("got %d %q, want %d %q", resp.StatusCode, body, tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://other.example.com/", nil)
+	if _, err := httpClient.Do(req); err == nil || !strings.Contains(err.Error(), "no mock_response matches GET https://other.example.com/") {
+		t.Errorf("expected unmatched mock error, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
+)
+
+func TestSPKIPins(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	serverPin := SPKIPin(server.Certificate())
+
+	do := func(pins []string) error {
+		client, err := NewHTTPClient(&config.ProviderConfig{TimeoutMs: 5000, CaCertPem: &serverCA, PinnedSPKISha256: pins})
+		if err != nil {
+			t.Fatalf("NewHTTPClient() error = %v", err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := do([]string{"LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=", serverPin}); err != nil {
+		t.Errorf("Do() with a matching pin error = %v", err)
+	}
+	if err := do([]string{"LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="}); err == nil || !strings.Contains(err.Error(), "pinned_spki_sha256") {
+		t.Errorf("Do() without a matching pin error = %v, want a pin mismatch", err)
+	}
+}
+
+// selfSignedCertificate creates a self-signed certificate valid for 127.0.0.1
+func selfSignedCertificate(t *testing.T, name string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestSPKIPinsIgnoreAppendedCertificates(t *testing.T) {
+	// The server's own certificate is trusted but not pinned, and it appends the pinned one
+	serverCert := selfSignedCertificate(t, "server")
+	pinnedCert := selfSignedCertificate(t, "pinned")
+	presented := serverCert
+	presented.Certificate = append([][]byte{serverCert.Certificate[0]}, pinnedCert.Certificate[0])
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{presented}}
+	server.StartTLS()
+	defer server.Close()
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serverCert.Leaf.Raw}))
+
+	do := func(cfg *config.ProviderConfig) error {
+		client, err := NewHTTPClient(cfg)
+		if err != nil {
+			t.Fatalf("NewHTTPClient() error = %v", err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	pins := []string{SPKIPin(pinnedCert.Leaf)}
+	if err := do(&config.ProviderConfig{TimeoutMs: 5000, CaCertPem: &serverCA, PinnedSPKISha256: pins}); err == nil || !strings.Contains(err.Error(), "pinned_spki_sha256") {
+		t.Errorf("Do() with the pinned certificate appended error = %v, want a pin mismatch", err)
+	}
+	if err := do(&config.ProviderConfig{TimeoutMs: 5000, InsecureSkipVerify: true, PinnedSPKISha256: pins}); err == nil || !strings.Contains(err.Error(), "pinned_spki_sha256") {
+		t.Errorf("Do() with insecure_skip_verify and the pinned certificate appended error = %v, want a pin mismatch", err)
+	}
+	if err := do(&config.ProviderConfig{TimeoutMs: 5000, InsecureSkipVerify: true, PinnedSPKISha256: []string{SPKIPin(serverCert.Leaf)}}); err != nil {
+		t.Errorf("Do() with insecure_skip_verify and a pinned leaf error = %v", err)
+	}
+}
+
+func TestNormalizeSPKIPins(t *testing.T) {
+	got, err := NormalizeSPKIPins([]string{"sha256//47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", " LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ= "})
+	if err != nil {
+		t.Fatalf("NormalizeSPKIPins() error = %v", err)
+	}
+	want := []string{"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("NormalizeSPKIPins() = %v, want %v", got, want)
+	}
+
+	for _, pin := range []string{"not base64!", "c2hvcnQ=", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"} {
+		if _, err := NormalizeSPKIPins([]string{pin}); err == nil {
+			t.Errorf("NormalizeSPKIPins(%q) expected an error", pin)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package config
 
+import "regexp"
+
 // ProviderConfig holds the provider configuration
 type ProviderConfig struct {
 	DefaultHeaders       map[string]string
@@ -12,8 +14,55 @@ type ProviderConfig struct {
 	ClientCertPem        *string
 	ClientKeyPem         *string
 	RedactHeaders        []string
+	RedactQueryParams    []string
+	RedactPatterns       []*regexp.Regexp
 	MaxResponseBodyBytes int64
 	Debug                bool
+	RecordMode           string
+	CassetteDir          string
+	MockMode             bool
+	MockResponses        map[string]MockResponse
+
+	// Per-phase transport timeouts, 0 leaves the phase bounded only by TimeoutMs
+	ConnectTimeoutMs        int64
+	TLSHandshakeTimeoutMs   int64
+	ResponseHeaderTimeoutMs int64
+
+	// Name resolution through specific servers (host:port), and a bound on each lookup
+	DNSServers   []string
+	DNSTimeoutMs int64
+
+	// Address family to connect over: "any" (or empty), "ipv4" or "ipv6"
+	IPFamily string
+	// Source IP to connect from, empty lets the system choose
+	LocalAddress string
+	// Interval between TCP keepalive probes of idle connections, 0 keeps Go's default
+	TCPKeepAliveIntervalMs int64
+	// Use HTTP_PROXY, HTTPS_PROXY and NO_PROXY when ProxyUrl is not set
+	ProxyFromEnvironment bool
+	// Extra headers sent with the CONNECT request that opens a tunnel through the proxy
+	ProxyConnectHeaders map[string]string
+
+	// CA bundles trusted in addition to CaCertPem
+	CaBundleFile string
+	CaBundleDir  string
+	// Trust the custom CAs in addition to the system roots
+	AppendSystemCAs bool
+	// Base64 SHA-256 SPKI digests, one of which the server must present
+	PinnedSPKISha256 []string
+
+	// Client identity from a PKCS#12 bundle, instead of ClientCertPem and ClientKeyPem
+	ClientPKCS12File     string
+	ClientPKCS12Password string
+	// Passphrase of an encrypted ClientKeyPem
+	ClientKeyPassphrase string
+}
+
+// MockResponse is a canned response served in mock mode
+type MockResponse struct {
+	StatusCode int
+	Body       string
+	Headers    map[string]string
 }
 
 // BasicAuthModel represents basic auth credentials
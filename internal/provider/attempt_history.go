@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxAttemptHistory caps the number of attempts kept in attempt_history (most recent are kept)
+const maxAttemptHistory = 20
+
+// AttemptRecord describes a single HTTP attempt made while retrying or polling
+type AttemptRecord struct {
+	Attempt               int64
+	StatusCode            int64
+	DurationMs            int64
+	Error                 string
+	ConditionsEvaluated   bool
+	ConditionsMet         bool
+	UnsatisfiedConditions []string
+	Transcript            string
+	Nonce                 string
+	RequestHeaders        map[string]string
+}
+
+// attemptHistory collects attempt records, keeping at most maxAttemptHistory entries
+type attemptHistory struct {
+	records []AttemptRecord
+}
+
+// add appends a record, dropping the oldest one when the cap is reached
+func (h *attemptHistory) add(record AttemptRecord) {
+	if len(h.records) >= maxAttemptHistory {
+		h.records = append(h.records[:0], h.records[1:]...)
+	}
+	h.records = append(h.records, record)
+}
+
+// last returns the most recent record, or nil if none were recorded
+func (h *attemptHistory) last() *AttemptRecord {
+	if len(h.records) == 0 {
+		return nil
+	}
+	return &h.records[len(h.records)-1]
+}
+
+// attemptHistoryAttrTypes describes the object type of attempt_history elements
+var attemptHistoryAttrTypes = map[string]attr.Type{
+	"attempt":                types.Int64Type,
+	"status_code":            types.Int64Type,
+	"duration_ms":            types.Int64Type,
+	"error":                  types.StringType,
+	"conditions_met":         types.BoolType,
+	"unsatisfied_conditions": types.ListType{ElemType: types.StringType},
+}
+
+// AttemptHistoryValue converts attempt records to the attempt_history list value
+func AttemptHistoryValue(ctx context.Context, records []AttemptRecord) (types.List, diag.Diagnostics) {
+	elemType := types.ObjectType{AttrTypes: attemptHistoryAttrTypes}
+	models := make([]AttemptHistoryModel, 0, len(records))
+
+	for _, record := range records {
+		model := AttemptHistoryModel{
+			Attempt:               types.Int64Value(record.Attempt),
+			DurationMs:            types.Int64Value(record.DurationMs),
+			StatusCode:            types.Int64Null(),
+			Error:                 types.StringNull(),
+			ConditionsMet:         types.BoolNull(),
+			UnsatisfiedConditions: types.ListNull(types.StringType),
+		}
+		if record.StatusCode != 0 {
+			model.StatusCode = types.Int64Value(record.StatusCode)
+		}
+		if record.Error != "" {
+			model.Error = types.StringValue(record.Error)
+		}
+		if record.ConditionsEvaluated {
+			model.ConditionsMet = types.BoolValue(record.ConditionsMet)
+			unsatisfied, diags := types.ListValueFrom(ctx, types.StringType, append([]string{}, record.UnsatisfiedConditions...))
+			if diags.HasError() {
+				return types.ListNull(elemType), diags
+			}
+			model.UnsatisfiedConditions = unsatisfied
+		}
+		models = append(models, model)
+	}
+
+	return types.ListValueFrom(ctx, elemType, models)
+}
+
+// requestFailureDetail builds a diagnostic detail for a failed request, including the attempt history
+func requestFailureDetail(err error, result *ResponseResult) string {
+	if result == nil || len(result.AttemptHistory) == 0 {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s\n\nAttempt history:\n%s", err.Error(), formatAttemptHistory(result.AttemptHistory))
+}
+
+// formatAttemptHistory renders attempt records as one line per attempt
+func formatAttemptHistory(records []AttemptRecord) string {
+	lines := make([]string, 0, len(records))
+	for _, record := range records {
+		line := fmt.Sprintf("  attempt %d: ", record.Attempt)
+		if record.Error != "" {
+			line += fmt.Sprintf("error %q", record.Error)
+		} else {
+			line += fmt.Sprintf("status %d", record.StatusCode)
+		}
+		line += fmt.Sprintf(" in %dms", record.DurationMs)
+		if record.ConditionsEvaluated && !record.ConditionsMet {
+			line += fmt.Sprintf(", unmet: %s", strings.Join(record.UnsatisfiedConditions, "; "))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttemptHistory_Cap(t *testing.T) {
+	history := &attemptHistory{}
+	assert.Nil(t, history.last())
+
+	for i := int64(1); i <= maxAttemptHistory+5; i++ {
+		history.add(AttemptRecord{Attempt: i})
+	}
+
+	assert.Len(t, history.records, maxAttemptHistory)
+	assert.Equal(t, int64(6), history.records[0].Attempt)
+	assert.Equal(t, int64(maxAttemptHistory+5), history.last().Attempt)
+}
+
+func TestAttemptHistoryValue(t *testing.T) {
+	ctx := context.Background()
+	records := []AttemptRecord{
+		{Attempt: 1, DurationMs: 5, Error: "request failed: connection refused"},
+		{Attempt: 2, StatusCode: 200, DurationMs: 12, ConditionsEvaluated: true, UnsatisfiedConditions: []string{"json_path_equals"}},
+		{Attempt: 3, StatusCode: 200, DurationMs: 9, ConditionsEvaluated: true, ConditionsMet: true},
+	}
+
+	value, diags := AttemptHistoryValue(ctx, records)
+	assert.False(t, diags.HasError())
+
+	var models []AttemptHistoryModel
+	diags = value.ElementsAs(ctx, &models, false)
+	assert.False(t, diags.HasError())
+	assert.Len(t, models, 3)
+
+	assert.True(t, models[0].StatusCode.IsNull())
+	assert.Equal(t, "request failed: connection refused", models[0].Error.ValueString())
+	assert.True(t, models[0].ConditionsMet.IsNull())
+
+	assert.Equal(t, int64(200), models[1].StatusCode.ValueInt64())
+	assert.False(t, models[1].ConditionsMet.ValueBool())
+	assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("json_path_equals")}), models[1].UnsatisfiedConditions)
+
+	assert.True(t, models[2].ConditionsMet.ValueBool())
+	assert.Len(t, models[2].UnsatisfiedConditions.Elements(), 0)
+}
+
+func TestRequestFailureDetail(t *testing.T) {
+	err := errors.New("exhausted 2 retry attempts")
+
+	assert.Equal(t, err.Error(), requestFailureDetail(err, nil))
+
+	result := &ResponseResult{
+		AttemptHistory: []AttemptRecord{
+			{Attempt: 1, Error: "dial tcp: connection refused", DurationMs: 3},
+			{Attempt: 2, StatusCode: 202, DurationMs: 40, ConditionsEvaluated: true, UnsatisfiedConditions: []string{"status_codes: got 202"}},
+		},
+	}
+	detail := requestFailureDetail(err, result)
+	assert.Contains(t, detail, "exhausted 2 retry attempts")
+	assert.Contains(t, detail, `attempt 1: error "dial tcp: connection refused" in 3ms`)
+	assert.Contains(t, detail, "attempt 2: status 202 in 40ms, unmet: status_codes: got 202")
+}
+
+func TestExecuteRequestWithRetry_AttemptHistory(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"ready": %t}`, calls >= 3)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	config := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576}
+	retryConfig := &RetryConfig{Attempts: 5, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed"}
+	retryUntilConfig := &RetryUntilConfig{JsonPathEquals: map[string]string{"ready": "true"}}
+
+	result, err := ExecuteRequestWithRetry(context.Background(), req, config, retryConfig, retryUntilConfig, nil)
+	assert.NoError(t, err)
+	assert.Len(t, result.AttemptHistory, 3)
+	for i, record := range result.AttemptHistory {
+		assert.Equal(t, int64(i+1), record.Attempt)
+		assert.Equal(t, int64(200), record.StatusCode)
+		assert.True(t, record.ConditionsEvaluated)
+	}
+	assert.False(t, result.AttemptHistory[0].ConditionsMet)
+	assert.NotEmpty(t, result.AttemptHistory[0].UnsatisfiedConditions)
+	assert.True(t, result.AttemptHistory[2].ConditionsMet)
+
+	// Without retry configuration a single attempt is still recorded
+	req, err = http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	result, err = ExecuteRequestWithRetry(context.Background(), req, config, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, result.AttemptHistory, 1)
+	assert.False(t, result.AttemptHistory[0].ConditionsEvaluated)
+}
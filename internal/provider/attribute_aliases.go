@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// headersDeprecationMessage is shown by Terraform for configurations that still set headers
+const headersDeprecationMessage = "Use request_headers instead. headers keeps working until the next major version."
+
+// attributeAlias pairs a deprecated attribute with the attribute replacing it. Configurations
+// may use either name while the deprecated one is phased out, but not both. Terraform warns
+// about the deprecated name through the attribute's DeprecationMessage.
+type attributeAlias struct {
+	deprecated  path.Path
+	replacement path.Path
+}
+
+// resourceAttributeAliases lists the deprecated attributes of httpx_request
+var resourceAttributeAliases = []attributeAlias{
+	{deprecated: path.Root("headers"), replacement: path.Root("request_headers")},
+	{deprecated: path.Root("on_destroy").AtName("headers"), replacement: path.Root("on_destroy").AtName("request_headers")},
+}
+
+// dataSourceAttributeAliases lists the deprecated attributes of the httpx_request data source
+var dataSourceAttributeAliases = []attributeAlias{
+	{deprecated: path.Root("headers"), replacement: path.Root("request_headers")},
+}
+
+// validateAttributeAliases rejects configurations that set both an attribute and its
+// deprecated alias
+func validateAttributeAliases(ctx context.Context, config tfsdk.Config, aliases []attributeAlias) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, alias := range aliases {
+		var deprecated, replacement attr.Value
+		diags.Append(config.GetAttribute(ctx, alias.deprecated, &deprecated)...)
+		diags.Append(config.GetAttribute(ctx, alias.replacement, &replacement)...)
+		if diags.HasError() {
+			return diags
+		}
+		if deprecated != nil && !deprecated.IsNull() && replacement != nil && !replacement.IsNull() {
+			diags.AddAttributeError(alias.deprecated, "Conflicting attributes",
+				alias.deprecated.String()+" is a deprecated alias of "+alias.replacement.String()+", set only "+alias.replacement.String())
+		}
+	}
+	return diags
+}
+
+// aliasedMap returns replacement, or the deprecated alias when replacement is not set
+func aliasedMap(replacement types.Map, deprecated types.Map) types.Map {
+	if replacement.IsNull() {
+		return deprecated
+	}
+	return replacement
+}
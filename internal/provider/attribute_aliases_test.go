@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAttributeAliases(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	headersType := tftypes.Map{ElementType: tftypes.String}
+	headers := tftypes.NewValue(headersType, map[string]tftypes.Value{"Accept": tftypes.NewValue(tftypes.String, "application/json")})
+
+	config := func(set map[string]tftypes.Value) tfsdk.Config {
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attrType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attrType, nil)
+		}
+		for name, value := range set {
+			attributes[name] = value
+		}
+		return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+	}
+
+	// Either name on its own is fine, including without an on_destroy block
+	assert.False(t, validateAttributeAliases(ctx, config(map[string]tftypes.Value{"headers": headers}), resourceAttributeAliases).HasError())
+	assert.False(t, validateAttributeAliases(ctx, config(map[string]tftypes.Value{"request_headers": headers}), resourceAttributeAliases).HasError())
+
+	diags := validateAttributeAliases(ctx, config(map[string]tftypes.Value{"headers": headers, "request_headers": headers}), resourceAttributeAliases)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), "headers is a deprecated alias of request_headers")
+}
+
+func TestAliasedMap(t *testing.T) {
+	deprecated := types.MapValueMust(types.StringType, map[string]attr.Value{"Accept": types.StringValue("text/plain")})
+	replacement := types.MapValueMust(types.StringType, map[string]attr.Value{"Accept": types.StringValue("application/json")})
+
+	assert.Equal(t, deprecated, aliasedMap(types.MapNull(types.StringType), deprecated))
+	assert.Equal(t, replacement, aliasedMap(replacement, deprecated))
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// auditRecord is one line of the audit log, written for every attempt
+type auditRecord struct {
+	Timestamp  string `json:"timestamp"`
+	Resource   string `json:"resource,omitempty"`
+	Operation  string `json:"operation,omitempty"`
+	Method     string `json:"method"`
+	URL        string `json:"url"`
+	StatusCode int64  `json:"status_code"`
+	DurationMs int64  `json:"duration_ms"`
+	Attempt    int64  `json:"attempt"`
+	Error      string `json:"error,omitempty"`
+}
+
+// auditLogger appends JSONL audit records to a file shared by every resource and data source
+type auditLogger struct {
+	mu   sync.Mutex
+	path string
+}
+
+// newAuditLogger checks that path can be opened for appending and returns a logger writing to it
+func newAuditLogger(path string) (*auditLogger, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &auditLogger{path: path}, nil
+}
+
+// write appends record as a single JSON line. The file is reopened for every record because
+// the provider has no shutdown hook to close it.
+func (l *auditLogger) write(record auditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WithAuditSource returns a copy of the provider config whose audit records name the
+// resource type and operation that sent the request
+func (p *ProviderConfig) WithAuditSource(resource, operation string) *ProviderConfig {
+	cfg := *p
+	cfg.AuditResource = resource
+	cfg.AuditOperation = operation
+	return &cfg
+}
+
+// auditAttempt records a finished attempt in the audit log, if one is configured.
+// URLs and errors are redacted the same way as in logs and diagnostics.
+func auditAttempt(ctx context.Context, req *http.Request, config *ProviderConfig, record AttemptRecord) {
+	if config == nil || config.AuditLog == nil {
+		return
+	}
+
+	err := config.AuditLog.write(auditRecord{
+		Timestamp:  time.Now().UTC().Format(time.RFC3339Nano),
+		Resource:   config.AuditResource,
+		Operation:  config.AuditOperation,
+		Method:     req.Method,
+		URL:        config.redact(req.URL.String()),
+		StatusCode: record.StatusCode,
+		DurationMs: record.DurationMs,
+		Attempt:    record.Attempt,
+		Error:      config.redact(record.Error),
+	})
+	if err != nil {
+		tflog.Warn(ctx, "Failed to write audit log record", map[string]interface{}{"error": err.Error()})
+	}
+}
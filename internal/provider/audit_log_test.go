@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLog(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := newAuditLogger(logPath)
+	assert.NoError(t, err)
+
+	providerConfig := &ProviderConfig{
+		TimeoutMs:            5000,
+		MaxResponseBodyBytes: 1024,
+		RedactQueryParams:    []string{"api_key"},
+		AuditLog:             auditLog,
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/items?api_key=secret", nil)
+	assert.NoError(t, err)
+
+	retryConfig := &RetryConfig{Attempts: 3, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed", RetryOnStatusCodes: []int64{503}}
+	_, err = ExecuteRequestWithRetry(context.Background(), req, providerConfig.WithAuditSource("httpx_request", "create"), retryConfig, nil, nil)
+	assert.NoError(t, err)
+
+	f, err := os.Open(logPath)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	var records []auditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record auditRecord
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, int64(1), records[0].Attempt)
+		assert.Equal(t, int64(503), records[0].StatusCode)
+		assert.Equal(t, int64(2), records[1].Attempt)
+		assert.Equal(t, int64(200), records[1].StatusCode)
+		assert.Equal(t, "httpx_request", records[1].Resource)
+		assert.Equal(t, "create", records[1].Operation)
+		assert.Equal(t, http.MethodPost, records[1].Method)
+		assert.Equal(t, server.URL+"/items?api_key=[REDACTED]", records[1].URL)
+		assert.NotEmpty(t, records[1].Timestamp)
+	}
+}
+
+func TestNewAuditLogger_InvalidPath(t *testing.T) {
+	_, err := newAuditLogger(filepath.Join(t.TempDir(), "missing", "audit.jsonl"))
+	assert.ErrorContains(t, err, "failed to open audit log")
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// BodyObjectJSON serializes a body_object value to JSON. Object keys are sorted so the
+// same configuration always produces the same request body.
+func BodyObjectJSON(ctx context.Context, value types.Dynamic) ([]byte, error) {
+	data, err := attrValueToJSON(ctx, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body_object: %w", err)
+	}
+	return json.Marshal(data)
+}
+
+// attrValueToJSON converts a framework attribute value into a value encoding/json can marshal
+func attrValueToJSON(ctx context.Context, value attr.Value) (interface{}, error) {
+	if value == nil || value.IsNull() {
+		return nil, nil
+	}
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value is not known yet")
+	}
+
+	switch v := value.(type) {
+	case types.Dynamic:
+		return attrValueToJSON(ctx, v.UnderlyingValue())
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Int64:
+		return v.ValueInt64(), nil
+	case types.Float64:
+		return v.ValueFloat64(), nil
+	case types.Number:
+		return json.Number(v.ValueBigFloat().Text('f', -1)), nil
+	case types.List:
+		return attrValuesToJSON(ctx, v.Elements())
+	case types.Set:
+		return attrValuesToJSON(ctx, v.Elements())
+	case types.Tuple:
+		return attrValuesToJSON(ctx, v.Elements())
+	case types.Map:
+		return attrMapToJSON(ctx, v.Elements())
+	case types.Object:
+		return attrMapToJSON(ctx, v.Attributes())
+	default:
+		return nil, fmt.Errorf("unsupported value type %s", value.Type(ctx))
+	}
+}
+
+// attrValuesToJSON converts a sequence of attribute values to a JSON array
+func attrValuesToJSON(ctx context.Context, values []attr.Value) (interface{}, error) {
+	items := make([]interface{}, 0, len(values))
+	for _, elem := range values {
+		item, err := attrValueToJSON(ctx, elem)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// attrMapToJSON converts keyed attribute values to a JSON object
+func attrMapToJSON(ctx context.Context, values map[string]attr.Value) (interface{}, error) {
+	object := make(map[string]interface{}, len(values))
+	for key, elem := range values {
+		item, err := attrValueToJSON(ctx, elem)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		object[key] = item
+	}
+	return object, nil
+}
+
+// InterpolateBodyObject expands ${...} templates in every string of a body_object value
+func InterpolateBodyObject(ctx context.Context, value types.Dynamic, interpolCtx *InterpolationContext) (types.Dynamic, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return value, nil
+	}
+
+	data, err := attrValueToJSON(ctx, value)
+	if err != nil {
+		return value, fmt.Errorf("invalid body_object: %w", err)
+	}
+
+	expanded, err := interpolateJSONStrings(ctx, data, interpolCtx)
+	if err != nil {
+		return value, err
+	}
+
+	result, err := jsonToAttrValue(ctx, expanded)
+	if err != nil {
+		return value, err
+	}
+	return types.DynamicValue(result), nil
+}
+
+// interpolateJSONStrings walks a JSON value and interpolates its strings
+func interpolateJSONStrings(ctx context.Context, data interface{}, interpolCtx *InterpolationContext) (interface{}, error) {
+	switch v := data.(type) {
+	case string:
+		return InterpolateString(ctx, v, interpolCtx)
+	case []interface{}:
+		for i, item := range v {
+			expanded, err := interpolateJSONStrings(ctx, item, interpolCtx)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = expanded
+		}
+		return v, nil
+	case map[string]interface{}:
+		for key, item := range v {
+			expanded, err := interpolateJSONStrings(ctx, item, interpolCtx)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = expanded
+		}
+		return v, nil
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10)), nil
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'g', -1, 64)), nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBodyObject() types.Dynamic {
+	tags := types.TupleValueMust(
+		[]attr.Type{types.StringType, types.NumberType},
+		[]attr.Value{types.StringValue("a"), types.NumberValue(big.NewFloat(2))},
+	)
+	object := types.ObjectValueMust(
+		map[string]attr.Type{
+			"name":    types.StringType,
+			"enabled": types.BoolType,
+			"ratio":   types.NumberType,
+			"tags":    tags.Type(context.Background()),
+			"owner":   types.StringType,
+		},
+		map[string]attr.Value{
+			"name":    types.StringValue("${self.outputs.name}"),
+			"enabled": types.BoolValue(true),
+			"ratio":   types.NumberValue(big.NewFloat(1.5)),
+			"tags":    tags,
+			"owner":   types.StringNull(),
+		},
+	)
+	return types.DynamicValue(object)
+}
+
+func TestBodyObjectJSON(t *testing.T) {
+	ctx := context.Background()
+
+	body, err := BodyObjectJSON(ctx, testBodyObject())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"enabled":true,"name":"${self.outputs.name}","owner":null,"ratio":1.5,"tags":["a",2]}`, string(body))
+
+	_, err = BodyObjectJSON(ctx, types.DynamicValue(types.StringUnknown()))
+	assert.Error(t, err)
+
+	list := types.DynamicValue(types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(1), types.Int64Value(2)}))
+	body, err = BodyObjectJSON(ctx, list)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2]`, string(body))
+}
+
+func TestInterpolateBodyObject(t *testing.T) {
+	ctx := context.Background()
+	interpolCtx := &InterpolationContext{Outputs: map[string]string{"name": "widget"}}
+
+	expanded, err := InterpolateBodyObject(ctx, testBodyObject(), interpolCtx)
+	assert.NoError(t, err)
+
+	body, err := BodyObjectJSON(ctx, expanded)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"enabled":true,"name":"widget","owner":null,"ratio":1.5,"tags":["a",2]}`, string(body))
+}
+
+func TestBuildRequest_BodyObject(t *testing.T) {
+	ctx := context.Background()
+
+	req, err := BuildRequest(ctx, &RequestConfig{
+		Url:        "https://example.com",
+		Method:     "POST",
+		BodyObject: testBodyObject(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"enabled":true,"name":"${self.outputs.name}","owner":null,"ratio":1.5,"tags":["a",2]}`, string(body))
+
+	_, err = BuildRequest(ctx, &RequestConfig{
+		Url:        "https://example.com",
+		Method:     "POST",
+		Body:       types.StringValue("raw"),
+		BodyObject: testBodyObject(),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultCommandTimeout bounds a hook command when timeout_ms is not set
+const defaultCommandTimeout = 10 * time.Second
+
+// commandOutputLimit caps how much of a hook's stdout and stderr is kept
+const commandOutputLimit = 1 << 20
+
+// errCommandVeto is returned when a hook command vetoes a request. Vetoes are not retried.
+var errCommandVeto = errors.New("vetoed by command hook")
+
+// commandHook is a local program run around each attempt
+type commandHook struct {
+	name    string
+	command []string
+	timeout time.Duration
+}
+
+// commandHookRequest is the JSON document a hook receives on stdin. post_response_command
+// also receives the response.
+type commandHookRequest struct {
+	Method   string               `json:"method"`
+	URL      string               `json:"url"`
+	Headers  map[string]string    `json:"headers"`
+	Body     string               `json:"body,omitempty"`
+	Response *commandHookResponse `json:"response,omitempty"`
+}
+
+// commandHookResponse is the response part of a post_response_command document
+type commandHookResponse struct {
+	StatusCode int64             `json:"status_code"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+}
+
+// commandHookResult is the optional JSON document a hook prints on stdout
+type commandHookResult struct {
+	// Headers are set on the request (pre_request_command only)
+	Headers map[string]string `json:"headers"`
+	// Veto cancels the call with the given reason
+	Veto string `json:"veto"`
+}
+
+// WithCommandHooks returns a copy of the provider config that runs the pre_request_command and
+// post_response_command blocks around every attempt
+func (p *ProviderConfig) WithCommandHooks(ctx context.Context, pre *CommandHookModel, post *CommandHookModel) (*ProviderConfig, error) {
+	cfg := *p
+	var err error
+	if cfg.PreRequestCommand, err = buildCommandHook(ctx, "pre_request_command", pre); err != nil {
+		return nil, err
+	}
+	if cfg.PostResponseCommand, err = buildCommandHook(ctx, "post_response_command", post); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// buildCommandHook converts a command hook block, returning nil when it is not configured
+func buildCommandHook(ctx context.Context, name string, model *CommandHookModel) (*commandHook, error) {
+	if model == nil || model.Command.IsNull() {
+		return nil, nil
+	}
+	command, err := ConvertTerraformList(ctx, model.Command, func(v interface{}) (string, error) {
+		if strVal, ok := v.(types.String); ok {
+			return strVal.ValueString(), nil
+		}
+		return "", fmt.Errorf("expected string, got %T", v)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s command: %w", name, err)
+	}
+	if len(command) == 0 || command[0] == "" {
+		return nil, fmt.Errorf("%s command must name a program", name)
+	}
+
+	hook := &commandHook{name: name, command: command, timeout: defaultCommandTimeout}
+	if !model.TimeoutMs.IsNull() && !model.TimeoutMs.IsUnknown() {
+		if model.TimeoutMs.ValueInt64() <= 0 {
+			return nil, fmt.Errorf("%s timeout_ms must be positive, got %d", name, model.TimeoutMs.ValueInt64())
+		}
+		hook.timeout = time.Duration(model.TimeoutMs.ValueInt64()) * time.Millisecond
+	}
+	return hook, nil
+}
+
+// runPreRequestCommand runs pre_request_command for the next attempt and sets the headers it returns
+func runPreRequestCommand(ctx context.Context, req *http.Request, config *ProviderConfig) error {
+	if config == nil || config.PreRequestCommand == nil {
+		return nil
+	}
+
+	input := hookRequestDocument(req, config.redaction())
+	result, err := config.PreRequestCommand.run(ctx, input)
+	if err != nil {
+		return err
+	}
+	for name, value := range result.Headers {
+		req.Header.Set(name, value)
+	}
+	return nil
+}
+
+// runPostResponseCommand runs post_response_command against an attempt's response
+func runPostResponseCommand(ctx context.Context, req *http.Request, response *ResponseResult, config *ProviderConfig) error {
+	if config == nil || config.PostResponseCommand == nil || response == nil || response.StatusCode == 0 {
+		return nil
+	}
+
+	redaction := config.redaction()
+	input := hookRequestDocument(req, redaction)
+	headers := make(map[string]string, len(response.Headers))
+	for name, value := range response.Headers {
+		headers[name] = utils.RedactHeaderValue(name, value, append([]string{"Set-Cookie"}, redaction.Headers...))
+	}
+	input.Response = &commandHookResponse{
+		StatusCode: response.StatusCode,
+		Headers:    headers,
+		Body:       redaction.Apply(response.Body),
+	}
+
+	_, err := config.PostResponseCommand.run(ctx, input)
+	return err
+}
+
+// hookRequestDocument describes req for a hook, with headers redacted like request_preview
+func hookRequestDocument(req *http.Request, redaction utils.Redaction) commandHookRequest {
+	input := commandHookRequest{
+		Method:  req.Method,
+		URL:     redaction.Apply(req.URL.String()),
+		Headers: sentRequestHeaders(req, redaction),
+	}
+	if req.GetBody != nil && req.ContentLength != 0 {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			_ = body.Close()
+			input.Body = string(data)
+		}
+	}
+	return input
+}
+
+// run executes the hook with input as JSON on stdin. A non-zero exit status or a veto in the
+// output cancels the call.
+func (h *commandHook) run(ctx context.Context, input commandHookRequest) (*commandHookResult, error) {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s input: %w", h.name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	//nolint:gosec // Running the configured program is the point of the hook
+	cmd := exec.CommandContext(ctx, h.command[0], h.command[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	stdout := &limitedBuffer{limit: commandOutputLimit}
+	stderr := &limitedBuffer{limit: commandOutputLimit}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	// Don't wait on child processes that keep the output pipes open after a timeout
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%w: %s timed out after %s", errCommandVeto, h.name, h.timeout)
+		}
+		detail := strings.TrimSpace(stderr.String())
+		if detail == "" {
+			detail = err.Error()
+		}
+		return nil, fmt.Errorf("%w: %s failed: %s", errCommandVeto, h.name, detail)
+	}
+
+	result := &commandHookResult{}
+	if out := bytes.TrimSpace(stdout.Bytes()); len(out) > 0 {
+		if err := json.Unmarshal(out, result); err != nil {
+			return nil, fmt.Errorf("%w: %s printed invalid JSON: %v", errCommandVeto, h.name, err)
+		}
+	}
+	if result.Veto != "" {
+		return nil, fmt.Errorf("%w: %s: %s", errCommandVeto, h.name, result.Veto)
+	}
+	return result, nil
+}
+
+// limitedBuffer keeps at most limit bytes, discarding the rest
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
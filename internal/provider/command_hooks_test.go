@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// shellHook returns a command hook block running script with sh
+func shellHook(script string) *CommandHookModel {
+	return &CommandHookModel{
+		Command:   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("sh"), types.StringValue("-c"), types.StringValue(script)}),
+		TimeoutMs: types.Int64Null(),
+	}
+}
+
+func TestCommandHooks(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-Signed", r.Header.Get("X-Signature"))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("unavailable"))
+	}))
+	defer server.Close()
+
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}
+	retryConfig := &RetryConfig{Attempts: 3, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed", RetryOnStatusCodes: []int64{503}}
+	execute := func(pre, post *CommandHookModel) (*ResponseResult, error) {
+		attempts = 0
+		cfg, err := providerConfig.WithCommandHooks(context.Background(), pre, post)
+		assert.NoError(t, err)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		return ExecuteRequestWithRetry(context.Background(), req, cfg, retryConfig, nil, nil)
+	}
+
+	t.Run("pre_request_command sets headers", func(t *testing.T) {
+		result, err := execute(shellHook(`cat >/dev/null; echo '{"headers": {"X-Signature": "abc"}}'`), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, "abc", result.Headers["X-Signed"])
+	})
+
+	t.Run("pre_request_command receives the request", func(t *testing.T) {
+		result, err := execute(shellHook(`grep -q '"method":"GET"' && echo '{"headers": {"X-Signature": "seen"}}'`), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "seen", result.Headers["X-Signed"])
+	})
+
+	t.Run("veto in output", func(t *testing.T) {
+		_, err := execute(shellHook(`echo '{"veto": "change freeze"}'`), nil)
+		assert.True(t, errors.Is(err, errCommandVeto))
+		assert.Contains(t, err.Error(), "change freeze")
+		assert.Equal(t, 0, attempts)
+	})
+
+	t.Run("non-zero exit is not retried", func(t *testing.T) {
+		_, err := execute(shellHook(`echo denied >&2; exit 3`), nil)
+		assert.True(t, errors.Is(err, errCommandVeto))
+		assert.Contains(t, err.Error(), "denied")
+		assert.Equal(t, 0, attempts)
+	})
+
+	t.Run("post_response_command vetoes the response", func(t *testing.T) {
+		result, err := execute(nil, shellHook(`grep -q '"status_code":503' && echo '{"veto": "unexpected status"}'`))
+		assert.True(t, errors.Is(err, errCommandVeto))
+		assert.Contains(t, err.Error(), "post_response_command: unexpected status")
+		assert.Equal(t, 1, attempts)
+		assert.Equal(t, int64(503), result.StatusCode)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		hook := shellHook(`sleep 5`)
+		hook.TimeoutMs = types.Int64Value(50)
+		_, err := execute(hook, nil)
+		assert.True(t, errors.Is(err, errCommandVeto))
+		assert.Contains(t, err.Error(), "timed out")
+	})
+}
+
+func TestBuildCommandHook(t *testing.T) {
+	hook, err := buildCommandHook(context.Background(), "pre_request_command", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, hook)
+
+	_, err = buildCommandHook(context.Background(), "pre_request_command", &CommandHookModel{
+		Command:   types.ListValueMust(types.StringType, []attr.Value{}),
+		TimeoutMs: types.Int64Null(),
+	})
+	assert.Error(t, err)
+
+	invalid := shellHook("true")
+	invalid.TimeoutMs = types.Int64Value(0)
+	_, err = buildCommandHook(context.Background(), "pre_request_command", invalid)
+	assert.Error(t, err)
+}
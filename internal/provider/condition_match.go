@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// match values for retry_until, expect and their condition groups
+const (
+	matchAll = "all"
+	matchAny = "any"
+)
+
+// matchValue returns the configured match value, or "" when it is unset
+func matchValue(match types.String) string {
+	if match.IsNull() || match.IsUnknown() {
+		return ""
+	}
+	return strings.ToLower(match.ValueString())
+}
+
+func invalidMatchMessage(match string) string {
+	return fmt.Sprintf("invalid match '%s', expected 'all' or 'any'", match)
+}
+
+// conditionGroupMessages prefixes the unmet conditions of condition group i
+func conditionGroupMessages(i int, messages []string) []string {
+	prefixed := make([]string, 0, len(messages))
+	for _, message := range messages {
+		prefixed = append(prefixed, fmt.Sprintf("condition[%d]: %s", i, message))
+	}
+	return prefixed
+}
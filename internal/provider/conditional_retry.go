@@ -2,10 +2,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -14,22 +14,115 @@ import (
 // RetryUntilConfig holds conditional retry configuration
 type RetryUntilConfig struct {
 	StatusCodes    []int64
+	StatusRanges   []statusRange
 	JsonPathEquals map[string]string
 	HeaderEquals   map[string]string
+	HeaderEqualsIgnoreCase bool
 	BodyRegex      string
+	Jq             string
+	IntervalMs     int64
+	InitialDelayMs int64
+	ConsecutiveSuccesses int64
+	Match          string
+	Conditions     []*RetryUntilConfig
 }
 
-// EvaluateRetryUntil checks if all retry_until conditions are satisfied
+// PollDelay returns the delay before the next poll when conditions are not met.
+// Retry-After (when respected) and status_delay_overrides take precedence over a configured
+// interval, which in turn takes precedence over the retry backoff.
+func (ruc *RetryUntilConfig) PollDelay(retryConfig *RetryConfig, attempt int64, statusCode int64, retryAfter string) time.Duration {
+	if ruc.IntervalMs > 0 {
+		if retryConfig.RespectRetryAfter && retryAfter != "" {
+			if delay, err := parseRetryAfter(retryAfter); err == nil {
+				return delay
+			}
+		}
+		if delay, ok := retryConfig.statusDelayOverride(statusCode); ok {
+			return delay
+		}
+		return time.Duration(ruc.IntervalMs) * time.Millisecond
+	}
+	return retryConfig.DelayForStatus(attempt, statusCode, retryAfter)
+}
+
+// EvaluateRetryUntil checks if the retry_until conditions are satisfied: all of them, or with
+// match = "any" at least one. Each condition group counts as one condition.
 func (ruc *RetryUntilConfig) EvaluateRetryUntil(ctx context.Context, result *ResponseResult) (bool, []string) {
 	if ruc == nil {
 		return true, nil // No conditions means always satisfied
 	}
 
+	switch ruc.Match {
+	case "", matchAll:
+	case matchAny:
+		return ruc.evaluateAny(ctx, result)
+	default:
+		return false, []string{invalidMatchMessage(ruc.Match)}
+	}
+
+	unsatisfied := ruc.unsatisfiedConditions(ctx, result)
+	for i, group := range ruc.Conditions {
+		if satisfied, groupUnsatisfied := group.EvaluateRetryUntil(ctx, result); !satisfied {
+			unsatisfied = append(unsatisfied, conditionGroupMessages(i, groupUnsatisfied)...)
+		}
+	}
+	return len(unsatisfied) == 0, unsatisfied
+}
+
+// evaluateAny checks if at least one retry_until condition or condition group is satisfied
+func (ruc *RetryUntilConfig) evaluateAny(ctx context.Context, result *ResponseResult) (bool, []string) {
+	conditions := ruc.split()
+	if len(conditions) == 0 && len(ruc.Conditions) == 0 {
+		return true, nil
+	}
+
+	var unsatisfied []string
+	for _, condition := range conditions {
+		conditionUnsatisfied := condition.unsatisfiedConditions(ctx, result)
+		if len(conditionUnsatisfied) == 0 {
+			return true, nil
+		}
+		unsatisfied = append(unsatisfied, conditionUnsatisfied...)
+	}
+	for i, group := range ruc.Conditions {
+		satisfied, groupUnsatisfied := group.EvaluateRetryUntil(ctx, result)
+		if satisfied {
+			return true, nil
+		}
+		unsatisfied = append(unsatisfied, conditionGroupMessages(i, groupUnsatisfied)...)
+	}
+	return false, unsatisfied
+}
+
+// split returns one config per configured condition, for evaluating them separately
+func (ruc *RetryUntilConfig) split() []*RetryUntilConfig {
+	var conditions []*RetryUntilConfig
+	if len(ruc.StatusCodes) > 0 || len(ruc.StatusRanges) > 0 {
+		conditions = append(conditions, &RetryUntilConfig{StatusCodes: ruc.StatusCodes, StatusRanges: ruc.StatusRanges})
+	}
+	if len(ruc.JsonPathEquals) > 0 {
+		conditions = append(conditions, &RetryUntilConfig{JsonPathEquals: ruc.JsonPathEquals})
+	}
+	if len(ruc.HeaderEquals) > 0 {
+		conditions = append(conditions, &RetryUntilConfig{HeaderEquals: ruc.HeaderEquals, HeaderEqualsIgnoreCase: ruc.HeaderEqualsIgnoreCase})
+	}
+	if ruc.BodyRegex != "" {
+		conditions = append(conditions, &RetryUntilConfig{BodyRegex: ruc.BodyRegex})
+	}
+	if ruc.Jq != "" {
+		conditions = append(conditions, &RetryUntilConfig{Jq: ruc.Jq})
+	}
+	return conditions
+}
+
+// unsatisfiedConditions describes each of the config's own conditions the response doesn't meet,
+// ignoring match and condition groups
+func (ruc *RetryUntilConfig) unsatisfiedConditions(ctx context.Context, result *ResponseResult) []string {
 	var unsatisfied []string
 
 	// Check status codes
-	if len(ruc.StatusCodes) > 0 {
-		found := false
+	if len(ruc.StatusCodes) > 0 || len(ruc.StatusRanges) > 0 {
+		found := statusInRanges(result.StatusCode, ruc.StatusRanges)
 		for _, code := range ruc.StatusCodes {
 			if result.StatusCode == code {
 				found = true
@@ -37,7 +130,11 @@ func (ruc *RetryUntilConfig) EvaluateRetryUntil(ctx context.Context, result *Res
 			}
 		}
 		if !found {
-			unsatisfied = append(unsatisfied, fmt.Sprintf("status code %d not in required codes %v", result.StatusCode, ruc.StatusCodes))
+			if len(ruc.StatusRanges) > 0 {
+				unsatisfied = append(unsatisfied, fmt.Sprintf("status code %d not in required codes %v or classes %s", result.StatusCode, ruc.StatusCodes, statusRangesString(ruc.StatusRanges)))
+			} else {
+				unsatisfied = append(unsatisfied, fmt.Sprintf("status code %d not in required codes %v", result.StatusCode, ruc.StatusCodes))
+			}
 		}
 	}
 
@@ -50,7 +147,7 @@ func (ruc *RetryUntilConfig) EvaluateRetryUntil(ctx context.Context, result *Res
 
 	// Check header conditions
 	if len(ruc.HeaderEquals) > 0 {
-		if !checkHeaderConditions(result.Headers, ruc.HeaderEquals) {
+		if !checkHeaderConditions(result.Headers, ruc.HeaderEquals, ruc.HeaderEqualsIgnoreCase) {
 			unsatisfied = append(unsatisfied, "header conditions not satisfied")
 		}
 	}
@@ -65,7 +162,17 @@ func (ruc *RetryUntilConfig) EvaluateRetryUntil(ctx context.Context, result *Res
 		}
 	}
 
-	return len(unsatisfied) == 0, unsatisfied
+	// Check jq condition
+	if ruc.Jq != "" {
+		holds, err := evaluateJqCondition(ctx, result.Body, ruc.Jq)
+		if err != nil {
+			unsatisfied = append(unsatisfied, err.Error())
+		} else if !holds {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("jq condition not satisfied: %s", ruc.Jq))
+		}
+	}
+
+	return unsatisfied
 }
 
 // checkJsonPathConditions evaluates JSON path conditions
@@ -74,8 +181,8 @@ func checkJsonPathConditions(ctx context.Context, body string, conditions map[st
 		return false
 	}
 
-	var jsonData interface{}
-	if err := json.Unmarshal([]byte(body), &jsonData); err != nil {
+	jsonData, err := decodeJSON(body)
+	if err != nil {
 		tflog.Debug(ctx, "Failed to parse JSON for path evaluation", map[string]interface{}{
 			"error": err.Error(),
 		})
@@ -96,10 +203,9 @@ func checkJsonPathConditions(ctx context.Context, body string, conditions map[st
 		actualStr := fmt.Sprintf("%v", actualValue)
 		
 		// Try to parse expected value as JSON to handle booleans/numbers properly
-		var expectedParsed interface{}
-		if err := json.Unmarshal([]byte(expectedValue), &expectedParsed); err == nil {
+		if expectedParsed, err := decodeJSON(expectedValue); err == nil {
 			// Successfully parsed as JSON, compare parsed values
-			if fmt.Sprintf("%v", expectedParsed) != fmt.Sprintf("%v", actualValue) {
+			if !jsonValuesEqual(expectedParsed, actualValue) {
 				return false
 			}
 		} else {
@@ -172,13 +278,14 @@ func evaluateJsonPath(data interface{}, path string) (interface{}, error) {
 	return current, nil
 }
 
-// checkHeaderConditions checks if header conditions are satisfied
-func checkHeaderConditions(headers map[string]string, conditions map[string]string) bool {
+// checkHeaderConditions checks if header conditions are satisfied, optionally comparing values
+// case-insensitively. Header names always match case-insensitively.
+func checkHeaderConditions(headers map[string]string, conditions map[string]string, ignoreCase bool) bool {
 	for headerName, expectedValue := range conditions {
 		found := false
 		for k, v := range headers {
 			if strings.EqualFold(k, headerName) {
-				if v == expectedValue {
+				if v == expectedValue || (ignoreCase && strings.EqualFold(v, expectedValue)) {
 					found = true
 					break
 				}
@@ -216,6 +323,7 @@ func BuildRetryUntilConfig(ctx context.Context, retryUntilModel *RetryUntilModel
 			config.StatusCodes = codes
 		}
 	}
+	config.StatusRanges = buildStatusRanges(ctx, "retry_until.status_classes", retryUntilModel.StatusClasses)
 
 	// Parse JSON path conditions
 	if !retryUntilModel.JsonPathEquals.IsNull() && !retryUntilModel.JsonPathEquals.IsUnknown() {
@@ -242,6 +350,122 @@ func BuildRetryUntilConfig(ctx context.Context, retryUntilModel *RetryUntilModel
 		config.BodyRegex = retryUntilModel.BodyRegex.ValueString()
 	}
 
+	if !retryUntilModel.Jq.IsNull() && !retryUntilModel.Jq.IsUnknown() {
+		config.Jq = retryUntilModel.Jq.ValueString()
+	}
+
+	if !retryUntilModel.IntervalMs.IsNull() && !retryUntilModel.IntervalMs.IsUnknown() {
+		config.IntervalMs = retryUntilModel.IntervalMs.ValueInt64()
+	}
+
+	if !retryUntilModel.InitialDelayMs.IsNull() && !retryUntilModel.InitialDelayMs.IsUnknown() {
+		config.InitialDelayMs = retryUntilModel.InitialDelayMs.ValueInt64()
+	}
+
+	if !retryUntilModel.ConsecutiveSuccesses.IsNull() && !retryUntilModel.ConsecutiveSuccesses.IsUnknown() {
+		config.ConsecutiveSuccesses = retryUntilModel.ConsecutiveSuccesses.ValueInt64()
+	}
+
+	config.HeaderEqualsIgnoreCase = retryUntilModel.HeaderEqualsIgnoreCase.ValueBool()
+
+	config.Match = matchValue(retryUntilModel.Match)
+	for _, condition := range retryUntilModel.Conditions {
+		config.Conditions = append(config.Conditions, BuildRetryUntilConfig(ctx, &RetryUntilModel{
+			StatusCodes:    condition.StatusCodes,
+			StatusClasses:  condition.StatusClasses,
+			JsonPathEquals: condition.JsonPathEquals,
+			HeaderEquals:   condition.HeaderEquals,
+			HeaderEqualsIgnoreCase: retryUntilModel.HeaderEqualsIgnoreCase,
+			BodyRegex:      condition.BodyRegex,
+			Jq:             condition.Jq,
+			Match:          condition.Match,
+		}))
+	}
+
 	return config
 }
 
+
+// AbortOnConfig holds conditions that stop retrying/polling early
+type AbortOnConfig struct {
+	StatusCodes    []int64
+	JsonPathEquals map[string]string
+	BodyRegex      string
+}
+
+// EvaluateAbortOn checks if any abort_on condition matches the response
+// Returns true and a description of the matched condition when the request should be aborted
+func (aoc *AbortOnConfig) EvaluateAbortOn(ctx context.Context, result *ResponseResult) (bool, string) {
+	if aoc == nil || result == nil {
+		return false, ""
+	}
+
+	// Check status codes
+	for _, code := range aoc.StatusCodes {
+		if result.StatusCode == code {
+			return true, fmt.Sprintf("status code %d matched abort_on.status_codes", code)
+		}
+	}
+
+	// Check JSON path conditions (any single path match aborts)
+	for path, expectedValue := range aoc.JsonPathEquals {
+		if checkJsonPathConditions(ctx, result.Body, map[string]string{path: expectedValue}) {
+			return true, fmt.Sprintf("JSON path '%s' equals '%s'", path, expectedValue)
+		}
+	}
+
+	// Check body regex
+	if aoc.BodyRegex != "" {
+		matched, err := regexp.MatchString(aoc.BodyRegex, result.Body)
+		if err != nil {
+			tflog.Warn(ctx, "Invalid abort_on.body_regex pattern", map[string]interface{}{
+				"error": err.Error(),
+			})
+		} else if matched {
+			return true, fmt.Sprintf("body matches regex: %s", aoc.BodyRegex)
+		}
+	}
+
+	return false, ""
+}
+
+// BuildAbortOnConfig converts AbortOnModel to AbortOnConfig
+func BuildAbortOnConfig(ctx context.Context, abortOnModel *AbortOnModel) *AbortOnConfig {
+	if abortOnModel == nil {
+		return nil
+	}
+
+	config := &AbortOnConfig{
+		StatusCodes:    []int64{},
+		JsonPathEquals: make(map[string]string),
+	}
+
+	// Parse status codes
+	if !abortOnModel.StatusCodes.IsNull() && !abortOnModel.StatusCodes.IsUnknown() {
+		codes, err := ConvertTerraformList(ctx, abortOnModel.StatusCodes, func(v interface{}) (int64, error) {
+			if intVal, ok := v.(types.Int64); ok {
+				return intVal.ValueInt64(), nil
+			}
+			return 0, fmt.Errorf("expected int64, got %T", v)
+		})
+		if err == nil {
+			config.StatusCodes = codes
+		}
+	}
+
+	// Parse JSON path conditions
+	if !abortOnModel.JsonPathEquals.IsNull() && !abortOnModel.JsonPathEquals.IsUnknown() {
+		for k, v := range abortOnModel.JsonPathEquals.Elements() {
+			if strVal, ok := v.(types.String); ok {
+				config.JsonPathEquals[k] = strVal.ValueString()
+			}
+		}
+	}
+
+	// Parse body regex
+	if !abortOnModel.BodyRegex.IsNull() && !abortOnModel.BodyRegex.IsUnknown() {
+		config.BodyRegex = abortOnModel.BodyRegex.ValueString()
+	}
+
+	return config
+}
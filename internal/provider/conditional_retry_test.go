@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"regexp"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestCheckJsonPathConditions(t *testing.T) {
@@ -63,6 +66,30 @@ func TestCheckJsonPathConditions(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "large integer match",
+			body: `{"id": 12345678901234567890}`,
+			conditions: map[string]string{
+				"id": "12345678901234567890",
+			},
+			want: true,
+		},
+		{
+			name: "large integer mismatch",
+			body: `{"id": 12345678901234567891}`,
+			conditions: map[string]string{
+				"id": "12345678901234567890",
+			},
+			want: false,
+		},
+		{
+			name: "numbers compared by value",
+			body: `{"count": 1.0}`,
+			conditions: map[string]string{
+				"count": "1",
+			},
+			want: true,
+		},
 		{
 			name:       "no conditions",
 			body:       `{"status": "ready"}`,
@@ -233,7 +260,7 @@ func TestCheckHeaderEquals(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := checkHeaderConditions(tt.headers, tt.conditions)
+			got := checkHeaderConditions(tt.headers, tt.conditions, false)
 			if got != tt.want {
 				t.Errorf("checkHeaderEquals() = %v, want %v", got, tt.want)
 			}
@@ -294,3 +321,121 @@ func TestCheckBodyRegex(t *testing.T) {
 	}
 }
 
+
+func TestAbortOnConfig_EvaluateAbortOn(t *testing.T) {
+	ctx := context.Background()
+
+	config := &AbortOnConfig{
+		StatusCodes:    []int64{400, 403},
+		JsonPathEquals: map[string]string{"status": "failed"},
+		BodyRegex:      `"error":\s*"fatal`,
+	}
+
+	tests := []struct {
+		name   string
+		config *AbortOnConfig
+		result *ResponseResult
+		want   bool
+	}{
+		{
+			name:   "status code matches",
+			config: config,
+			result: &ResponseResult{StatusCode: 403, Body: `{}`},
+			want:   true,
+		},
+		{
+			name:   "json path matches",
+			config: config,
+			result: &ResponseResult{StatusCode: 200, Body: `{"status": "failed"}`},
+			want:   true,
+		},
+		{
+			name:   "body regex matches",
+			config: config,
+			result: &ResponseResult{StatusCode: 200, Body: `{"error": "fatal: disk full"}`},
+			want:   true,
+		},
+		{
+			name:   "nothing matches",
+			config: config,
+			result: &ResponseResult{StatusCode: 200, Body: `{"status": "pending"}`},
+			want:   false,
+		},
+		{
+			name:   "nil config never aborts",
+			config: nil,
+			result: &ResponseResult{StatusCode: 403},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := tt.config.EvaluateAbortOn(ctx, tt.result)
+			if got != tt.want {
+				t.Errorf("EvaluateAbortOn() = %v (%s), want %v", got, reason, tt.want)
+			}
+			if got && reason == "" {
+				t.Errorf("EvaluateAbortOn() returned no reason for abort")
+			}
+		})
+	}
+}
+
+func TestRetryUntilConfig_Match(t *testing.T) {
+	ctx := context.Background()
+	ready := &ResponseResult{StatusCode: 200, Body: `{"status": "ready"}`}
+	exists := &ResponseResult{StatusCode: 409, Body: `{"status": "already-exists"}`}
+	pending := &ResponseResult{StatusCode: 202, Body: `{"status": "pending"}`}
+
+	// Done when the status is 200, or when the body says the object already exists
+	config := &RetryUntilConfig{
+		Match:       matchAny,
+		StatusCodes: []int64{200},
+		Conditions:  []*RetryUntilConfig{{BodyRegex: `"status":\s*"already-exists"`}},
+	}
+	for _, result := range []*ResponseResult{ready, exists} {
+		if satisfied, unsatisfied := config.EvaluateRetryUntil(ctx, result); !satisfied {
+			t.Errorf("EvaluateRetryUntil(%d) unsatisfied = %v", result.StatusCode, unsatisfied)
+		}
+	}
+	satisfied, unsatisfied := config.EvaluateRetryUntil(ctx, pending)
+	if satisfied || len(unsatisfied) != 2 {
+		t.Fatalf("EvaluateRetryUntil(pending) = %v, %v", satisfied, unsatisfied)
+	}
+	if unsatisfied[1] != `condition[0]: body does not match regex: "status":\s*"already-exists"` {
+		t.Errorf("unsatisfied[1] = %q", unsatisfied[1])
+	}
+
+	// By default the groups must hold along with the other conditions
+	config.Match = ""
+	if satisfied, _ := config.EvaluateRetryUntil(ctx, ready); satisfied {
+		t.Error("EvaluateRetryUntil(ready) satisfied without the condition group")
+	}
+
+	config.Match = "either"
+	if satisfied, unsatisfied := config.EvaluateRetryUntil(ctx, ready); satisfied || unsatisfied[0] != "invalid match 'either', expected 'all' or 'any'" {
+		t.Errorf("EvaluateRetryUntil() with invalid match = %v, %v", satisfied, unsatisfied)
+	}
+}
+
+func TestCheckHeaderConditions_IgnoreCase(t *testing.T) {
+	headers := map[string]string{"Content-Type": "Application/JSON"}
+	conditions := map[string]string{"content-type": "application/json"}
+
+	if checkHeaderConditions(headers, conditions, false) {
+		t.Error("checkHeaderConditions() matched values of different case")
+	}
+	if !checkHeaderConditions(headers, conditions, true) {
+		t.Error("checkHeaderConditions() with ignoreCase did not match")
+	}
+
+	// Condition groups inherit header_equals_ignore_case from retry_until
+	config := BuildRetryUntilConfig(context.Background(), &RetryUntilModel{
+		HeaderEqualsIgnoreCase: types.BoolValue(true),
+		Conditions:             []RetryUntilConditionModel{{HeaderEquals: types.MapValueMust(types.StringType, map[string]attr.Value{"Content-Type": types.StringValue("application/json")})}},
+	})
+	if satisfied, unsatisfied := config.EvaluateRetryUntil(context.Background(), &ResponseResult{StatusCode: 200, Headers: headers}); !satisfied {
+		t.Errorf("EvaluateRetryUntil() unsatisfied = %v", unsatisfied)
+	}
+}
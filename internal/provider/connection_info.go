@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// withRemoteAddrTrace returns a context that stores the remote address of every connection the
+// request uses in addr. After redirects it holds the address of the final hop.
+func withRemoteAddrTrace(ctx context.Context, addr *string) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Conn != nil {
+				*addr = info.Conn.RemoteAddr().String()
+			}
+		},
+	})
+}
+
+// responseStatusText returns the reason phrase of a response, falling back to the standard text
+// for protocols that don't send one (HTTP/2 and later)
+func responseStatusText(resp *http.Response) string {
+	if text := strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode)+" "); text != resp.Status && text != "" {
+		return text
+	}
+	return http.StatusText(resp.StatusCode)
+}
+
+// stringOrNull returns a string value, or null when value is empty
+func stringOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// tlsVersionValue returns the negotiated TLS version, e.g. "TLS 1.3", or null without TLS
+func tlsVersionValue(state *tls.ConnectionState) types.String {
+	if state == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(tls.VersionName(state.Version))
+}
+
+// tlsCipherSuiteValue returns the negotiated cipher suite name, or null without TLS
+func tlsCipherSuiteValue(state *tls.ConnectionState) types.String {
+	if state == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(tls.CipherSuiteName(state.CipherSuite))
+}
+
+// peerCertSha256Value returns the hex SHA-256 fingerprint of the server's leaf certificate, or
+// null when none was presented
+func peerCertSha256Value(state *tls.ConnectionState) types.String {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return types.StringNull()
+	}
+	sum := sha256.Sum256(state.PeerCertificates[0].Raw)
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteRequest_ConnectionInfo(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	t.Run("plain HTTP", func(t *testing.T) {
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		result, err := ExecuteRequest(context.Background(), req, &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024})
+		assert.NoError(t, err)
+		assert.Equal(t, "Accepted", result.StatusText)
+		assert.Equal(t, "HTTP/1.1", result.Protocol)
+		assert.Equal(t, strings.TrimPrefix(server.URL, "http://"), result.RemoteAddr)
+		assert.True(t, tlsVersionValue(result.TLS).IsNull())
+		assert.True(t, tlsCipherSuiteValue(result.TLS).IsNull())
+		assert.True(t, peerCertSha256Value(result.TLS).IsNull())
+	})
+
+	t.Run("TLS", func(t *testing.T) {
+		server := httptest.NewTLSServer(handler)
+		defer server.Close()
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		result, err := ExecuteRequest(context.Background(), req, &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024, InsecureSkipVerify: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "HTTP/1.1", result.Protocol)
+		assert.Equal(t, strings.TrimPrefix(server.URL, "https://"), result.RemoteAddr)
+
+		sum := sha256.Sum256(server.Certificate().Raw)
+		assert.Equal(t, "TLS 1.3", tlsVersionValue(result.TLS).ValueString())
+		assert.True(t, strings.HasPrefix(tlsCipherSuiteValue(result.TLS).ValueString(), "TLS_"))
+		assert.Equal(t, hex.EncodeToString(sum[:]), peerCertSha256Value(result.TLS).ValueString())
+	})
+
+}
+
+func TestResponseStatusText(t *testing.T) {
+	assert.Equal(t, "Created", responseStatusText(&http.Response{Status: "201 Created", StatusCode: 201}))
+	assert.Equal(t, "Everything Is Fine", responseStatusText(&http.Response{Status: "200 Everything Is Fine", StatusCode: 200}))
+	assert.Equal(t, "Not Found", responseStatusText(&http.Response{Status: "404", StatusCode: 404}))
+	assert.Equal(t, "", responseStatusText(&http.Response{Status: "599", StatusCode: 599}))
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"crypto/md5" //nolint:gosec // Content-MD5 is an integrity check required by some APIs, not a security control
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+)
+
+// Digest algorithms for auto_content_digest
+const (
+	contentDigestSHA256 = "sha256"
+	contentDigestMD5    = "md5"
+)
+
+// setContentDigest computes the digest of the request body with algorithm and sets the matching
+// header: Content-Digest (RFC 9530) for sha256 and Content-MD5 (RFC 1864) for md5. The body is
+// streamed through the hash, and a digest header configured explicitly is left untouched.
+func setContentDigest(req *http.Request, algorithm string) error {
+	var h hash.Hash
+	var header string
+	switch algorithm {
+	case "":
+		return nil
+	case contentDigestSHA256:
+		h, header = sha256.New(), "Content-Digest"
+	case contentDigestMD5:
+		h, header = md5.New(), "Content-MD5" //nolint:gosec // See import
+	default:
+		return fmt.Errorf("auto_content_digest must be 'sha256' or 'md5', got %q", algorithm)
+	}
+
+	if req.GetBody == nil || hasHeader(req.Header, header) {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Errorf("failed to read body for auto_content_digest: %w", err)
+	}
+	defer body.Close()
+	if _, err := io.Copy(h, body); err != nil {
+		return fmt.Errorf("failed to read body for auto_content_digest: %w", err)
+	}
+
+	digest := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	if algorithm == contentDigestSHA256 {
+		digest = "sha-256=:" + digest + ":"
+	}
+	req.Header.Set(header, digest)
+	return nil
+}
+
+// hasHeader reports whether name is set in header, including names kept in their configured
+// casing by preserve_header_case
+func hasHeader(header http.Header, name string) bool {
+	for k := range header {
+		if http.CanonicalHeaderKey(k) == http.CanonicalHeaderKey(name) {
+			return true
+		}
+	}
+	return false
+}
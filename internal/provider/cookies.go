@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// responseCookieAttrTypes describes the object type of response_cookies values
+var responseCookieAttrTypes = map[string]attr.Type{
+	"value":     types.StringType,
+	"domain":    types.StringType,
+	"path":      types.StringType,
+	"expires":   types.StringType,
+	"max_age":   types.Int64Type,
+	"secure":    types.BoolType,
+	"http_only": types.BoolType,
+	"same_site": types.StringType,
+}
+
+// findResponseCookie returns the last cookie with the given name (later Set-Cookie headers win)
+func findResponseCookie(cookies []*http.Cookie, name string) (*http.Cookie, bool) {
+	var found *http.Cookie
+	for _, cookie := range cookies {
+		if cookie.Name == name {
+			found = cookie
+		}
+	}
+	return found, found != nil
+}
+
+// ResponseCookiesValue converts cookies parsed from Set-Cookie headers to the response_cookies map value
+func ResponseCookiesValue(ctx context.Context, cookies []*http.Cookie) (types.Map, diag.Diagnostics) {
+	models := make(map[string]ResponseCookieModel, len(cookies))
+	for _, cookie := range cookies {
+		model := ResponseCookieModel{
+			Value:    types.StringValue(cookie.Value),
+			Domain:   types.StringNull(),
+			Path:     types.StringNull(),
+			Expires:  types.StringNull(),
+			MaxAge:   types.Int64Null(),
+			Secure:   types.BoolValue(cookie.Secure),
+			HttpOnly: types.BoolValue(cookie.HttpOnly),
+			SameSite: types.StringNull(),
+		}
+		if cookie.Domain != "" {
+			model.Domain = types.StringValue(cookie.Domain)
+		}
+		if cookie.Path != "" {
+			model.Path = types.StringValue(cookie.Path)
+		}
+		if !cookie.Expires.IsZero() {
+			model.Expires = types.StringValue(cookie.Expires.UTC().Format(time.RFC3339))
+		}
+		if cookie.MaxAge != 0 {
+			// http.Cookie uses -1 for "Max-Age=0" (delete now)
+			maxAge := int64(cookie.MaxAge)
+			if maxAge < 0 {
+				maxAge = 0
+			}
+			model.MaxAge = types.Int64Value(maxAge)
+		}
+		if sameSite := sameSiteString(cookie.SameSite); sameSite != "" {
+			model.SameSite = types.StringValue(sameSite)
+		}
+		models[cookie.Name] = model
+	}
+
+	return types.MapValueFrom(ctx, types.ObjectType{AttrTypes: responseCookieAttrTypes}, models)
+}
+
+// sameSiteString returns the SameSite attribute as written in Set-Cookie
+func sameSiteString(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	default:
+		return ""
+	}
+}
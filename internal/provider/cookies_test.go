@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseCookiesValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Set-Cookie", "session=abc123; Path=/; Domain=example.com; Max-Age=3600; Secure; HttpOnly; SameSite=Strict")
+		w.Header().Add("Set-Cookie", "theme=dark; Expires=Wed, 21 Oct 2037 07:28:00 GMT")
+		w.Header().Add("Set-Cookie", "stale=; Max-Age=0")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	result, err := ExecuteRequest(context.Background(), req, &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576})
+	assert.NoError(t, err)
+	assert.Len(t, result.Cookies, 3)
+
+	value, diags := ResponseCookiesValue(context.Background(), result.Cookies)
+	assert.False(t, diags.HasError())
+
+	var cookies map[string]ResponseCookieModel
+	diags = value.ElementsAs(context.Background(), &cookies, false)
+	assert.False(t, diags.HasError())
+
+	session := cookies["session"]
+	assert.Equal(t, "abc123", session.Value.ValueString())
+	assert.Equal(t, "example.com", session.Domain.ValueString())
+	assert.Equal(t, "/", session.Path.ValueString())
+	assert.Equal(t, int64(3600), session.MaxAge.ValueInt64())
+	assert.True(t, session.Secure.ValueBool())
+	assert.True(t, session.HttpOnly.ValueBool())
+	assert.Equal(t, "Strict", session.SameSite.ValueString())
+	assert.True(t, session.Expires.IsNull())
+
+	theme := cookies["theme"]
+	assert.Equal(t, "dark", theme.Value.ValueString())
+	assert.Equal(t, "2037-10-21T07:28:00Z", theme.Expires.ValueString())
+	assert.True(t, theme.MaxAge.IsNull())
+	assert.False(t, theme.Secure.ValueBool())
+	assert.True(t, theme.SameSite.IsNull())
+
+	assert.Equal(t, int64(0), cookies["stale"].MaxAge.ValueInt64())
+}
+
+func TestFindResponseCookie(t *testing.T) {
+	cookies := []*http.Cookie{
+		{Name: "session", Value: "first"},
+		{Name: "Session", Value: "other-case"},
+		{Name: "session", Value: "second"},
+	}
+
+	cookie, found := findResponseCookie(cookies, "session")
+	assert.True(t, found)
+	assert.Equal(t, "second", cookie.Value)
+
+	_, found = findResponseCookie(cookies, "missing")
+	assert.False(t, found)
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// defaultDataSourceCacheDir is used when data_source_cache_dir is not set. Terraform runs
+// providers in the root module directory, so this sits next to the other working files.
+const defaultDataSourceCacheDir = ".terraform/httpx-cache"
+
+// defaultCacheMaxAge is the maximum age of a cached result when cache_max_age is not set
+const defaultCacheMaxAge = time.Hour
+
+// Plan behaviors for plan_behavior
+const (
+	planBehaviorAlways = "always"
+	planBehaviorCached = "cached"
+)
+
+// parsePlanBehavior reports whether reads are served from the cache and the maximum age of a
+// cached result
+func parsePlanBehavior(planBehavior types.String, cacheMaxAge types.String) (bool, time.Duration, error) {
+	switch strings.ToLower(planBehavior.ValueString()) {
+	case "", planBehaviorAlways:
+		return false, 0, nil
+	case planBehaviorCached:
+	default:
+		return false, 0, fmt.Errorf("plan_behavior must be %q or %q, got %q", planBehaviorAlways, planBehaviorCached, planBehavior.ValueString())
+	}
+
+	if cacheMaxAge.IsNull() || cacheMaxAge.ValueString() == "" {
+		return true, defaultCacheMaxAge, nil
+	}
+	maxAge, err := time.ParseDuration(cacheMaxAge.ValueString())
+	if err != nil {
+		return false, 0, fmt.Errorf("invalid cache_max_age %q: %w", cacheMaxAge.ValueString(), err)
+	}
+	if maxAge <= 0 {
+		return false, 0, fmt.Errorf("cache_max_age must be positive, got %q", cacheMaxAge.ValueString())
+	}
+	return true, maxAge, nil
+}
+
+// dataSourceCachePath returns the cache file for a data source configuration. Any change to
+// the configuration, including its secrets, selects a different file.
+func dataSourceCachePath(dir string, config tfsdk.Config) string {
+	hash := sha256.Sum256([]byte(config.Raw.String()))
+	return filepath.Join(dir, hex.EncodeToString(hash[:])+".msgpack")
+}
+
+// loadCachedDataSourceState returns the cached state at path, or false when there is none or
+// it is older than maxAge. The sensitive arguments left out of the cache are taken from config.
+func loadCachedDataSourceState(path string, maxAge time.Duration, config tfsdk.Config, typ tftypes.Type, now time.Time) (tftypes.Value, bool, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tftypes.Value{}, false, nil
+	}
+	if err != nil {
+		return tftypes.Value{}, false, err
+	}
+	if now.Sub(info.ModTime()) > maxAge {
+		return tftypes.Value{}, false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return tftypes.Value{}, false, err
+	}
+	value, err := (&tfprotov6.DynamicValue{MsgPack: data}).Unmarshal(typ)
+	if err != nil {
+		// Written by a version of the provider with a different schema
+		return tftypes.Value{}, false, nil
+	}
+	value, err = tftypes.Transform(value, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !isSensitiveArgument(config, p) {
+			return v, nil
+		}
+		configValue, _, err := tftypes.WalkAttributePath(config.Raw, p)
+		if err != nil {
+			return v, nil
+		}
+		return configValue.(tftypes.Value), nil
+	})
+	if err != nil {
+		return tftypes.Value{}, false, err
+	}
+	return value, true, nil
+}
+
+// storeCachedDataSourceState writes state to path without its sensitive arguments such as
+// bearer_token. The file is readable only by the current user as it may hold response bodies and
+// outputs, which are only encrypted when state_encryption_key is set.
+func storeCachedDataSourceState(path string, state tfsdk.State, typ tftypes.Type) error {
+	raw, err := tftypes.Transform(state.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if isSensitiveArgument(tfsdk.Config{Schema: state.Schema}, p) {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		return err
+	}
+	value, err := tfprotov6.NewDynamicValue(typ, raw)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	// Write through a temporary file so concurrent plans never read a partial result
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(value.MsgPack); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// isSensitiveArgument reports whether p is a sensitive attribute set in configuration, as opposed
+// to a computed result, in the schema of data
+func isSensitiveArgument(data tfsdk.Config, p *tftypes.AttributePath) bool {
+	attribute, err := data.Schema.AttributeAtTerraformPath(context.Background(), p)
+	return err == nil && attribute.IsSensitive() && !attribute.IsComputed()
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePlanBehavior(t *testing.T) {
+	cached, maxAge, err := parsePlanBehavior(types.StringNull(), types.StringValue("1h"))
+	assert.NoError(t, err)
+	assert.False(t, cached)
+
+	// Cached results expire by default
+	cached, maxAge, err = parsePlanBehavior(types.StringValue("cached"), types.StringNull())
+	assert.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, defaultCacheMaxAge, maxAge)
+
+	cached, maxAge, err = parsePlanBehavior(types.StringValue("cached"), types.StringValue("90m"))
+	assert.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, 90*time.Minute, maxAge)
+
+	_, _, err = parsePlanBehavior(types.StringValue("sometimes"), types.StringNull())
+	assert.ErrorContains(t, err, "plan_behavior must be")
+	_, _, err = parsePlanBehavior(types.StringValue("cached"), types.StringValue("-1h"))
+	assert.ErrorContains(t, err, "must be positive")
+}
+
+func TestDataSourceCache(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	NewHttpxRequestDataSource().Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	nullAttributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		nullAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	config := func(url string) tfsdk.Config {
+		attributes := make(map[string]tftypes.Value, len(nullAttributes))
+		for name, value := range nullAttributes {
+			attributes[name] = value
+		}
+		attributes["url"] = tftypes.NewValue(tftypes.String, url)
+		attributes["bearer_token"] = tftypes.NewValue(tftypes.String, "s3cr3t-token")
+		return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+	}
+
+	dir := filepath.Join(t.TempDir(), "cache")
+	path := dataSourceCachePath(dir, config("https://api.example.com/slow"))
+	assert.NotEqual(t, path, dataSourceCachePath(dir, config("https://api.example.com/other")))
+
+	_, ok, err := loadCachedDataSourceState(path, time.Hour, config("https://api.example.com/slow"), objectType, time.Now())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	var model HttpxRequestDataSourceModel
+	assert.False(t, config("https://api.example.com/slow").Get(ctx, &model).HasError())
+	model.StatusCode = types.Int64Value(200)
+	model.Outputs = types.MapValueMust(types.StringType, nil)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	assert.False(t, state.Set(ctx, &model).HasError())
+	assert.NoError(t, storeCachedDataSourceState(path, state, objectType))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cr3t-token")
+
+	value, ok, err := loadCachedDataSourceState(path, time.Hour, config("https://api.example.com/slow"), objectType, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	cachedState := tfsdk.State{Schema: schemaResp.Schema, Raw: value}
+	var cachedModel HttpxRequestDataSourceModel
+	assert.False(t, cachedState.Get(ctx, &cachedModel).HasError())
+	assert.Equal(t, int64(200), cachedModel.StatusCode.ValueInt64())
+	assert.Equal(t, "https://api.example.com/slow", cachedModel.Url.ValueString())
+	// Sensitive arguments come from the configuration
+	assert.Equal(t, "s3cr3t-token", cachedModel.BearerToken.ValueString())
+
+	// Expired results are ignored
+	_, ok, err = loadCachedDataSourceState(path, time.Hour, config("https://api.example.com/slow"), objectType, time.Now().Add(2*time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &HttpxAssertDataSource{}
+var _ datasource.DataSourceWithConfigure = &HttpxAssertDataSource{}
+
+// HttpxAssertDataSource executes a request for a check block and reports only whether the
+// response met its expectations. It is a data source because check blocks can only scope data
+// sources, and it keeps no response data so posture checks produce no diffs.
+type HttpxAssertDataSource struct {
+	config *ProviderConfig
+}
+
+func NewHttpxAssertDataSource() datasource.DataSource {
+	return &HttpxAssertDataSource{}
+}
+
+func (d *HttpxAssertDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assert"
+}
+
+func (d *HttpxAssertDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source for check blocks: executes a request and exposes only whether the response met its expectations, e.g. `assert { condition = data.httpx_assert.health.passed, error_message = join(\"; \", data.httpx_assert.health.failures) }`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Data source identifier",
+			},
+			"url": schema.StringAttribute{
+				Required:    true,
+				Description: "The URL to send the request to",
+			},
+			"method": schema.StringAttribute{
+				Optional:    true,
+				Description: "HTTP method (GET, POST, PUT, PATCH, DELETE, etc.; default: GET)",
+			},
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Request headers as a map",
+			},
+			"query": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Query parameters",
+			},
+			"body": schema.StringAttribute{
+				Optional:    true,
+				Description: "Raw request body",
+			},
+			"bearer_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Bearer token for authentication",
+			},
+			"passed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the request succeeded and the response met every expectation. Null in dry-run mode.",
+			},
+			"failures": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Unmet expectations, or the error when the request itself failed. Empty when passed.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"expect": schema.SingleNestedBlock{
+				Description: "Response expectations. Without this block the response must have a 2xx status.",
+				Attributes: map[string]schema.Attribute{
+					"status_codes": schema.ListAttribute{
+						ElementType: types.Int64Type,
+						Optional:    true,
+						Description: "Expected HTTP status codes",
+					},
+					"status_classes": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Expected status classes or ranges, e.g. [\"2xx\", \"500-599\"]. A status matching either status_codes or status_classes passes.",
+					},
+					"header_present": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Headers that must be present",
+					},
+					"jq": schema.StringAttribute{
+						Optional:    true,
+						Description: "jq expression that must evaluate to a truthy value (not false or null) against the JSON body, e.g. '.status == \"ok\"'",
+					},
+					"body_sha256": schema.StringAttribute{
+						Optional:    true,
+						Description: "Expected hex-encoded SHA-256 of the response body",
+					},
+					"content_length": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Expected response body size in bytes",
+					},
+					"content_type": schema.StringAttribute{
+						Optional:    true,
+						Description: "Expected media type of the response, ignoring parameters such as charset. Supports '*' wildcards, e.g. 'application/*json*'.",
+					},
+					"tls_cert_min_days_valid": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Fail if the server's TLS certificate expires within this many days. Requires an HTTPS request.",
+					},
+					"match": schema.StringAttribute{
+						Optional:    true,
+						Description: "How the expectations combine: 'all' (default) requires every expectation and condition block to pass, 'any' requires at least one",
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"condition": schema.ListNestedBlock{
+						Description: "Expectation group that counts as one expectation of expect, e.g. status_codes = [409] with a jq check of the error, combined with status_codes = [200] and match = \"any\" to also accept an already-existing object",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"match": schema.StringAttribute{
+									Optional:    true,
+									Description: "How the group's expectations combine: 'all' (default) or 'any'",
+								},
+								"status_codes": schema.ListAttribute{
+									ElementType: types.Int64Type,
+									Optional:    true,
+									Description: "Expected HTTP status codes",
+								},
+								"status_classes": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Expected status classes or ranges, e.g. [\"2xx\"]",
+								},
+								"header_present": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Headers that must be present",
+								},
+								"jq": schema.StringAttribute{
+									Optional:    true,
+									Description: "jq expression that must evaluate to a truthy value against the JSON body",
+								},
+								"content_type": schema.StringAttribute{
+									Optional:    true,
+									Description: "Expected media type of the response, supporting '*' wildcards",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HttpxAssertDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ProviderConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			"Expected *ProviderConfig, got something else",
+		)
+		return
+	}
+
+	d.config = config
+}
+
+func (d *HttpxAssertDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model HttpxAssertDataSourceModel
+
+	// Read Terraform configuration into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	headers, err := ConvertTerraformMap(ctx, model.Headers)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid headers", err.Error())
+		return
+	}
+	query, err := ConvertTerraformMap(ctx, model.Query)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid query", err.Error())
+		return
+	}
+
+	httpReq, err := BuildRequest(ctx, &RequestConfig{
+		Url:              model.Url.ValueString(),
+		Method:           model.Method.ValueString(),
+		Headers:          headers,
+		Query:            query,
+		Body:             model.Body,
+		BearerToken:      model.BearerToken,
+		ProviderDefaults: d.config,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build request", err.Error())
+		return
+	}
+
+	reqConfig := d.config.WithAuditSource("data.httpx_assert", "read")
+	model.Id = types.StringValue(generateAssertDataSourceID(model))
+
+	// In dry-run mode the request is only logged
+	if reqConfig.DryRun {
+		logDryRunRequest(ctx, httpReq, reqConfig)
+		model.Passed = types.BoolNull()
+		model.Failures = types.ListNull(types.StringType)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
+	// A failed request is a failed assertion rather than an error, so the check reports it
+	var failures []string
+	result, err := ExecuteRequestWithRetry(ctx, httpReq, reqConfig, nil, nil, nil)
+	if err != nil {
+		notifyFailure(ctx, httpReq, reqConfig, result, err)
+		failures = []string{err.Error()}
+	} else {
+		failures = expectationFailures(ctx, result, model.Expect.toExpectModel())
+	}
+
+	setAssertResultValues(&model, failures)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// toExpectModel converts the expect block, defaulting to a 2xx status when it is absent
+func (m *AssertExpectModel) toExpectModel() *ExpectModel {
+	if m == nil {
+		return &ExpectModel{
+			StatusCodes:   types.ListNull(types.Int64Type),
+			StatusClasses: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("2xx")}),
+			HeaderPresent: types.ListNull(types.StringType),
+		}
+	}
+	return &ExpectModel{
+		StatusCodes:         m.StatusCodes,
+		StatusClasses:       m.StatusClasses,
+		HeaderPresent:       m.HeaderPresent,
+		Jq:                  m.Jq,
+		BodySha256:          m.BodySha256,
+		ContentLength:       m.ContentLength,
+		ContentType:         m.ContentType,
+		TlsCertMinDaysValid: m.TlsCertMinDaysValid,
+		Match:               m.Match,
+		Conditions:          m.Conditions,
+	}
+}
+
+// setAssertResultValues fills passed and failures from the unmet expectations
+func setAssertResultValues(model *HttpxAssertDataSourceModel, failures []string) {
+	elements := make([]attr.Value, 0, len(failures))
+	for _, failure := range failures {
+		elements = append(elements, types.StringValue(failure))
+	}
+	model.Passed = types.BoolValue(len(failures) == 0)
+	model.Failures = types.ListValueMust(types.StringType, elements)
+}
+
+// generateAssertDataSourceID generates a stable ID for the data source
+func generateAssertDataSourceID(model HttpxAssertDataSourceModel) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("ASSERT|%s|%s", model.Method.ValueString(), model.Url.ValueString())))
+	return hex.EncodeToString(hash[:])[:16]
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertExpectations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	get := func(path string) *ResponseResult {
+		httpReq, err := BuildRequest(context.Background(), &RequestConfig{Url: server.URL + path})
+		assert.NoError(t, err)
+		result, err := ExecuteRequestWithRetry(context.Background(), httpReq, &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}, nil, nil, nil)
+		assert.NoError(t, err)
+		return result
+	}
+	check := func(path string, expect *AssertExpectModel) HttpxAssertDataSourceModel {
+		var model HttpxAssertDataSourceModel
+		setAssertResultValues(&model, expectationFailures(context.Background(), get(path), expect.toExpectModel()))
+		return model
+	}
+
+	// Without an expect block a 2xx status is required
+	model := check("/health", nil)
+	assert.True(t, model.Passed.ValueBool())
+	assert.Empty(t, model.Failures.Elements())
+
+	model = check("/down", nil)
+	assert.False(t, model.Passed.ValueBool())
+	assert.Len(t, model.Failures.Elements(), 1)
+
+	expect := &AssertExpectModel{
+		StatusCodes:   types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(200)}),
+		StatusClasses: types.ListNull(types.StringType),
+		HeaderPresent: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("X-Request-Id")}),
+		Jq:            types.StringValue(`.status == "degraded"`),
+		ContentType:   types.StringValue("application/json"),
+	}
+	model = check("/health", expect)
+	assert.False(t, model.Passed.ValueBool())
+	assert.Equal(t, []attr.Value{
+		types.StringValue("required header 'X-Request-Id' not present"),
+		types.StringValue(`jq condition not satisfied: .status == "degraded"`),
+	}, model.Failures.Elements())
+}
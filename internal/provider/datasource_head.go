@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &HttpxHeadDataSource{}
+var _ datasource.DataSourceWithConfigure = &HttpxHeadDataSource{}
+
+type HttpxHeadDataSource struct {
+	config *ProviderConfig
+}
+
+func NewHttpxHeadDataSource() datasource.DataSource {
+	return &HttpxHeadDataSource{}
+}
+
+func (d *HttpxHeadDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_head"
+}
+
+func (d *HttpxHeadDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source for HEAD requests: cheap existence and metadata checks that never read a response body",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Data source identifier",
+			},
+			"url": schema.StringAttribute{
+				Required:    true,
+				Description: "The URL to send the HEAD request to",
+			},
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Request headers as a map",
+			},
+			"query": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Query parameters",
+			},
+			"bearer_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Bearer token for authentication",
+			},
+			"status_code": schema.Int64Attribute{
+				Computed:    true,
+				Description: "HTTP status code of the response",
+			},
+			"exists": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the response status was 2xx",
+			},
+			"response_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Response headers",
+			},
+			"content_length": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Content-Length of the resource, null when the server does not send it",
+			},
+			"last_modified": schema.StringAttribute{
+				Computed:    true,
+				Description: "Last-Modified header of the resource, null when absent",
+			},
+			"etag": schema.StringAttribute{
+				Computed:    true,
+				Description: "ETag header of the resource, null when absent",
+			},
+		},
+	}
+}
+
+func (d *HttpxHeadDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ProviderConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			"Expected *ProviderConfig, got something else",
+		)
+		return
+	}
+
+	d.config = config
+}
+
+func (d *HttpxHeadDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model HttpxHeadDataSourceModel
+
+	// Read Terraform configuration into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	headers, err := ConvertTerraformMap(ctx, model.Headers)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid headers", err.Error())
+		return
+	}
+	query, err := ConvertTerraformMap(ctx, model.Query)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid query", err.Error())
+		return
+	}
+
+	httpReq, err := BuildRequest(ctx, &RequestConfig{
+		Url:              model.Url.ValueString(),
+		Method:           http.MethodHead,
+		Headers:          headers,
+		Query:            query,
+		BearerToken:      model.BearerToken,
+		ProviderDefaults: d.config,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build request", err.Error())
+		return
+	}
+
+	reqConfig := d.config.WithAuditSource("data.httpx_head", "read")
+	model.Id = types.StringValue(generateHeadDataSourceID(model))
+
+	// In dry-run mode the request is only logged
+	if reqConfig.DryRun {
+		logDryRunRequest(ctx, httpReq, reqConfig)
+		setHeadResultValues(&model, nil)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
+	result, err := ExecuteRequestWithRetry(ctx, httpReq, reqConfig, nil, nil, nil)
+	if err != nil {
+		notifyFailure(ctx, httpReq, reqConfig, result, err)
+		resp.Diagnostics.AddError("Request failed", requestFailureDetail(err, result))
+		return
+	}
+
+	setHeadResultValues(&model, result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// setHeadResultValues fills the computed attributes from a HEAD response, or nulls them when
+// no request was made
+func setHeadResultValues(model *HttpxHeadDataSourceModel, result *ResponseResult) {
+	model.StatusCode = types.Int64Null()
+	model.Exists = types.BoolNull()
+	model.ResponseHeaders = types.MapNull(types.StringType)
+	model.ContentLength = types.Int64Null()
+	model.LastModified = types.StringNull()
+	model.Etag = types.StringNull()
+	if result == nil {
+		return
+	}
+
+	model.StatusCode = types.Int64Value(result.StatusCode)
+	model.Exists = types.BoolValue(result.StatusCode >= 200 && result.StatusCode < 300)
+
+	model.ResponseHeaders = headerMapValue(result.Headers)
+
+	if value, ok := lookupHeader(result.Headers, "Content-Length"); ok {
+		if length, err := strconv.ParseInt(value, 10, 64); err == nil && length >= 0 {
+			model.ContentLength = types.Int64Value(length)
+		}
+	}
+	if value, ok := lookupHeader(result.Headers, "Last-Modified"); ok {
+		model.LastModified = types.StringValue(value)
+	}
+	if value, ok := lookupHeader(result.Headers, "ETag"); ok {
+		model.Etag = types.StringValue(value)
+	}
+}
+
+// headerMapValue converts response headers into a map attribute value
+func headerMapValue(headers map[string]string) types.Map {
+	elements := make(map[string]attr.Value, len(headers))
+	for name, value := range headers {
+		elements[name] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// generateHeadDataSourceID generates a stable ID for the data source
+func generateHeadDataSourceID(model HttpxHeadDataSourceModel) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("HEAD|%s", model.Url.ValueString())))
+	return hex.EncodeToString(hash[:])[:16]
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetHeadResultValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", "1048576")
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2026 07:28:00 GMT")
+		w.Header().Set("ETag", `"abc123"`)
+	}))
+	defer server.Close()
+
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 16}
+	head := func(path string) *ResponseResult {
+		httpReq, err := BuildRequest(context.Background(), &RequestConfig{Url: server.URL + path, Method: http.MethodHead})
+		assert.NoError(t, err)
+		result, err := ExecuteRequestWithRetry(context.Background(), httpReq, providerConfig, nil, nil, nil)
+		assert.NoError(t, err)
+		return result
+	}
+
+	var model HttpxHeadDataSourceModel
+	setHeadResultValues(&model, head("/object"))
+	assert.Equal(t, int64(200), model.StatusCode.ValueInt64())
+	assert.True(t, model.Exists.ValueBool())
+	assert.Equal(t, int64(1048576), model.ContentLength.ValueInt64(), "body size limits don't apply to HEAD")
+	assert.Equal(t, "Wed, 21 Oct 2026 07:28:00 GMT", model.LastModified.ValueString())
+	assert.Equal(t, `"abc123"`, model.Etag.ValueString())
+
+	setHeadResultValues(&model, head("/missing"))
+	assert.Equal(t, int64(404), model.StatusCode.ValueInt64())
+	assert.False(t, model.Exists.ValueBool())
+	assert.True(t, model.Etag.IsNull())
+
+	setHeadResultValues(&model, nil)
+	assert.True(t, model.StatusCode.IsNull())
+	assert.True(t, model.ResponseHeaders.IsNull())
+}
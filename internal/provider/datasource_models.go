@@ -8,31 +8,193 @@ import (
 type HttpxRequestDataSourceModel struct {
 	Id                  types.String `tfsdk:"id"`
 	Url                 types.String `tfsdk:"url"`
+	PathParams          types.Map    `tfsdk:"path_params"`
 	Method              types.String `tfsdk:"method"`
+	AllowCustomMethods  types.Bool   `tfsdk:"allow_custom_methods"`
 	Headers             types.Map    `tfsdk:"headers"`
+	RequestHeaders      types.Map    `tfsdk:"request_headers"`
 	Query               types.Map    `tfsdk:"query"`
+	Cookies             types.Map    `tfsdk:"cookies"`
+	PreserveHeaderCase  types.Bool   `tfsdk:"preserve_header_case"`
 	Body                types.String `tfsdk:"body"`
 	BodyJson            types.String `tfsdk:"body_json"`
+	BodyObject          types.Dynamic `tfsdk:"body_object"`
 	BodyFile            types.String `tfsdk:"body_file"`
+	AutoContentDigest   types.String `tfsdk:"auto_content_digest"`
 	BearerToken         types.String `tfsdk:"bearer_token"`
 	TimeoutMs           types.Int64  `tfsdk:"timeout_ms"`
+	ConnectTimeoutMs   types.Int64  `tfsdk:"connect_timeout_ms"`
+	TlsHandshakeTimeoutMs types.Int64 `tfsdk:"tls_handshake_timeout_ms"`
+	ResponseHeaderTimeoutMs types.Int64 `tfsdk:"response_header_timeout_ms"`
+	PinnedSpkiSha256 types.List `tfsdk:"pinned_spki_sha256"`
 	InsecureSkipVerify  types.Bool   `tfsdk:"insecure_skip_verify"`
 	ProxyUrl            types.String `tfsdk:"proxy_url"`
 	ResponseSensitive   types.Bool   `tfsdk:"response_sensitive"`
 	StoreResponseBody   types.Bool   `tfsdk:"store_response_body"`
+	MaxResponseBodyBytes types.Int64  `tfsdk:"max_response_body_bytes"`
+	OnBodyOverflow       types.String `tfsdk:"on_body_overflow"`
+	RedactHeaders        types.List   `tfsdk:"redact_headers"`
+	NonceHeader          types.String `tfsdk:"nonce_header"`
+	NonceScope           types.String `tfsdk:"nonce_scope"`
+	CaptureTranscript    types.Bool   `tfsdk:"capture_transcript"`
+	ResponseBodyFile     types.String `tfsdk:"response_body_file"`
+	Range                types.String `tfsdk:"range"`
+	TransferEncoding     types.String `tfsdk:"transfer_encoding"`
+	Resume               types.Bool   `tfsdk:"resume"`
+	ResponseHeaderNames  types.String `tfsdk:"response_header_names"`
+	IgnoreResponseHeaders types.List  `tfsdk:"ignore_response_headers"`
+	NormalizeResponseBody types.Bool  `tfsdk:"normalize_response_body"`
+	IgnoreBodyPaths      types.List   `tfsdk:"ignore_body_paths"`
 	StatusCode          types.Int64  `tfsdk:"status_code"`
+	StatusText          types.String `tfsdk:"status_text"`
+	Protocol            types.String `tfsdk:"protocol"`
+	RemoteAddr          types.String `tfsdk:"remote_addr"`
+	TlsVersion          types.String `tfsdk:"tls_version"`
+	TlsCipherSuite      types.String `tfsdk:"tls_cipher_suite"`
+	PeerCertSha256      types.String `tfsdk:"peer_cert_sha256"`
 	ResponseHeaders     types.Map    `tfsdk:"response_headers"`
+	ResponseCookies     types.Map    `tfsdk:"response_cookies"`
+	ResponseLinks       types.Map    `tfsdk:"response_links"`
+	EffectiveRequestUrl types.String `tfsdk:"effective_request_url"`
+	EffectiveUrl        types.String `tfsdk:"effective_url"`
+	RedirectChain       types.List   `tfsdk:"redirect_chain"`
 	ResponseBody        types.String `tfsdk:"response_body"`
+	ResponseBodyJson    types.Dynamic `tfsdk:"response_body_json"`
+	ResponseBodyFileSha256 types.String `tfsdk:"response_body_file_sha256"`
 	Outputs             types.Map    `tfsdk:"outputs"`
+	OutputsLists        types.Map    `tfsdk:"outputs_lists"`
+	OutputsJson         types.String `tfsdk:"outputs_json"`
 	LastAttemptCount    types.Int64  `tfsdk:"last_attempt_count"`
+	LastResponseAt      types.String `tfsdk:"last_response_at"`
 	LastError           types.String `tfsdk:"last_error"`
+	AttemptHistory      types.List   `tfsdk:"attempt_history"`
+	Transcript          types.String `tfsdk:"transcript"`
+	Nonce               types.String `tfsdk:"nonce"`
+	RequestHeadersSent  types.Map    `tfsdk:"request_headers_sent"`
+
+	DeferUntilApply types.Bool   `tfsdk:"defer_until_apply"`
+	PlanBehavior    types.String `tfsdk:"plan_behavior"`
+	CacheMaxAge     types.String `tfsdk:"cache_max_age"`
 
 	// Blocks
 	HeaderBlocks        []HeaderBlockModel        `tfsdk:"header"`
 	BasicAuth           *ResourceBasicAuthModel    `tfsdk:"basic_auth"`
 	Retry               *RetryModel                `tfsdk:"retry"`
 	RetryUntil          *RetryUntilModel           `tfsdk:"retry_until"`
+	AbortOn             *AbortOnModel              `tfsdk:"abort_on"`
 	Expect              *ExpectModel                `tfsdk:"expect"`
+	PreRequestCommand   *CommandHookModel           `tfsdk:"pre_request_command"`
+	PostResponseCommand *CommandHookModel           `tfsdk:"post_response_command"`
 	ExtractBlocks       []ExtractBlockModel         `tfsdk:"extract"`
+	Paginate            *PaginateModel              `tfsdk:"paginate"`
+}
+
+// PaginateModel represents the paginate block
+type PaginateModel struct {
+	Mode        types.String `tfsdk:"mode"`
+	MaxPages    types.Int64  `tfsdk:"max_pages"`
+	ItemPath    types.String `tfsdk:"item_path"`
+	CursorPath  types.String `tfsdk:"cursor_path"`
+	CursorParam types.String `tfsdk:"cursor_param"`
+	PageParam   types.String `tfsdk:"page_param"`
+}
+
+
+// HttpxRequestsDataSourceModel represents the httpx_requests data source state
+type HttpxRequestsDataSourceModel struct {
+	Id             types.String                `tfsdk:"id"`
+	Requests       map[string]RequestSpecModel `tfsdk:"requests"`
+	MaxConcurrency types.Int64                 `tfsdk:"max_concurrency"`
+	FailOnError    types.Bool                  `tfsdk:"fail_on_error"`
+	Results        types.Map                   `tfsdk:"results"`
 }
 
+// RequestSpecModel represents one request executed by httpx_requests
+type RequestSpecModel struct {
+	Url                types.String `tfsdk:"url"`
+	Method             types.String `tfsdk:"method"`
+	AllowCustomMethods types.Bool   `tfsdk:"allow_custom_methods"`
+	Headers            types.Map    `tfsdk:"headers"`
+	Query              types.Map    `tfsdk:"query"`
+	Body               types.String `tfsdk:"body"`
+	BodyJson           types.String `tfsdk:"body_json"`
+	BearerToken        types.String `tfsdk:"bearer_token"`
+}
+
+// RequestResultModel represents the outcome of one request executed by httpx_requests
+type RequestResultModel struct {
+	StatusCode      types.Int64  `tfsdk:"status_code"`
+	ResponseBody    types.String `tfsdk:"response_body"`
+	ResponseHeaders types.Map    `tfsdk:"response_headers"`
+	Error           types.String `tfsdk:"error"`
+}
+
+// HttpxHeadDataSourceModel represents the httpx_head data source state
+type HttpxHeadDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Url             types.String `tfsdk:"url"`
+	Headers         types.Map    `tfsdk:"headers"`
+	Query           types.Map    `tfsdk:"query"`
+	BearerToken     types.String `tfsdk:"bearer_token"`
+	StatusCode      types.Int64  `tfsdk:"status_code"`
+	Exists          types.Bool   `tfsdk:"exists"`
+	ResponseHeaders types.Map    `tfsdk:"response_headers"`
+	ContentLength   types.Int64  `tfsdk:"content_length"`
+	LastModified    types.String `tfsdk:"last_modified"`
+	Etag            types.String `tfsdk:"etag"`
+}
+
+// HttpxOptionsDataSourceModel represents the httpx_options data source state
+type HttpxOptionsDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Url             types.String `tfsdk:"url"`
+	Headers         types.Map    `tfsdk:"headers"`
+	BearerToken     types.String `tfsdk:"bearer_token"`
+	Origin          types.String `tfsdk:"origin"`
+	RequestMethod   types.String `tfsdk:"request_method"`
+	RequestHeaders  types.List   `tfsdk:"request_headers"`
+	StatusCode      types.Int64  `tfsdk:"status_code"`
+	ResponseHeaders types.Map    `tfsdk:"response_headers"`
+	AllowedMethods  types.List   `tfsdk:"allowed_methods"`
+	Cors            types.Object `tfsdk:"cors"`
+}
+
+// CorsModel represents the CORS headers parsed by httpx_options
+type CorsModel struct {
+	AllowOrigin      types.String `tfsdk:"allow_origin"`
+	AllowMethods     types.List   `tfsdk:"allow_methods"`
+	AllowHeaders     types.List   `tfsdk:"allow_headers"`
+	AllowCredentials types.Bool   `tfsdk:"allow_credentials"`
+	ExposeHeaders    types.List   `tfsdk:"expose_headers"`
+	MaxAge           types.Int64  `tfsdk:"max_age"`
+}
+
+// HttpxAssertDataSourceModel represents the httpx_assert data source state
+type HttpxAssertDataSourceModel struct {
+	Id          types.String       `tfsdk:"id"`
+	Url         types.String       `tfsdk:"url"`
+	Method      types.String       `tfsdk:"method"`
+	Headers     types.Map          `tfsdk:"headers"`
+	Query       types.Map          `tfsdk:"query"`
+	Body        types.String       `tfsdk:"body"`
+	BearerToken types.String       `tfsdk:"bearer_token"`
+	Expect      *AssertExpectModel `tfsdk:"expect"`
+	Passed      types.Bool         `tfsdk:"passed"`
+	Failures    types.List         `tfsdk:"failures"`
+}
+
+// AssertExpectModel represents the expectations checked by httpx_assert
+type AssertExpectModel struct {
+	StatusCodes         types.List   `tfsdk:"status_codes"`
+	StatusClasses       types.List   `tfsdk:"status_classes"`
+	HeaderPresent       types.List   `tfsdk:"header_present"`
+	Jq                  types.String `tfsdk:"jq"`
+	BodySha256          types.String `tfsdk:"body_sha256"`
+	ContentLength       types.Int64  `tfsdk:"content_length"`
+	ContentType         types.String `tfsdk:"content_type"`
+	TlsCertMinDaysValid types.Int64  `tfsdk:"tls_cert_min_days_valid"`
+	Match               types.String `tfsdk:"match"`
+
+	// Blocks
+	Conditions []ExpectConditionModel `tfsdk:"condition"`
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &HttpxOptionsDataSource{}
+var _ datasource.DataSourceWithConfigure = &HttpxOptionsDataSource{}
+
+// corsAttrTypes describes the httpx_options cors object
+var corsAttrTypes = map[string]attr.Type{
+	"allow_origin":      types.StringType,
+	"allow_methods":     types.ListType{ElemType: types.StringType},
+	"allow_headers":     types.ListType{ElemType: types.StringType},
+	"allow_credentials": types.BoolType,
+	"expose_headers":    types.ListType{ElemType: types.StringType},
+	"max_age":           types.Int64Type,
+}
+
+type HttpxOptionsDataSource struct {
+	config *ProviderConfig
+}
+
+func NewHttpxOptionsDataSource() datasource.DataSource {
+	return &HttpxOptionsDataSource{}
+}
+
+func (d *HttpxOptionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_options"
+}
+
+func (d *HttpxOptionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source for OPTIONS requests, optionally sent as a CORS preflight, exposing allowed methods and CORS headers for validating gateway configuration",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Data source identifier",
+			},
+			"url": schema.StringAttribute{
+				Required:    true,
+				Description: "The URL to send the OPTIONS request to",
+			},
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Request headers as a map",
+			},
+			"bearer_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Bearer token for authentication",
+			},
+			"origin": schema.StringAttribute{
+				Optional:    true,
+				Description: "Origin header to send, e.g. \"https://app.example.com\"",
+			},
+			"request_method": schema.StringAttribute{
+				Optional:    true,
+				Description: "Access-Control-Request-Method header to send, making the request a CORS preflight",
+			},
+			"request_headers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Header names to send in Access-Control-Request-Headers",
+			},
+			"status_code": schema.Int64Attribute{
+				Computed:    true,
+				Description: "HTTP status code of the response",
+			},
+			"response_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Response headers",
+			},
+			"allowed_methods": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Methods listed in the Allow header",
+			},
+			"cors": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "CORS response headers, parsed",
+				Attributes: map[string]schema.Attribute{
+					"allow_origin": schema.StringAttribute{
+						Computed:    true,
+						Description: "Access-Control-Allow-Origin",
+					},
+					"allow_methods": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+						Description: "Access-Control-Allow-Methods",
+					},
+					"allow_headers": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+						Description: "Access-Control-Allow-Headers",
+					},
+					"allow_credentials": schema.BoolAttribute{
+						Computed:    true,
+						Description: "Whether Access-Control-Allow-Credentials is \"true\"",
+					},
+					"expose_headers": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+						Description: "Access-Control-Expose-Headers",
+					},
+					"max_age": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Access-Control-Max-Age in seconds, null when absent",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HttpxOptionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ProviderConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			"Expected *ProviderConfig, got something else",
+		)
+		return
+	}
+
+	d.config = config
+}
+
+func (d *HttpxOptionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model HttpxOptionsDataSourceModel
+
+	// Read Terraform configuration into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	headers, err := ConvertTerraformMap(ctx, model.Headers)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid headers", err.Error())
+		return
+	}
+	requestHeaders, err := ConvertTerraformList(ctx, model.RequestHeaders, func(v interface{}) (string, error) {
+		if strVal, ok := v.(types.String); ok {
+			return strVal.ValueString(), nil
+		}
+		return "", fmt.Errorf("expected string, got %T", v)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid request_headers", err.Error())
+		return
+	}
+	if headers == nil {
+		headers = make(map[string]string)
+	}
+	if !model.Origin.IsNull() && model.Origin.ValueString() != "" {
+		headers["Origin"] = model.Origin.ValueString()
+	}
+	if !model.RequestMethod.IsNull() && model.RequestMethod.ValueString() != "" {
+		headers["Access-Control-Request-Method"] = model.RequestMethod.ValueString()
+	}
+	if len(requestHeaders) > 0 {
+		headers["Access-Control-Request-Headers"] = strings.Join(requestHeaders, ", ")
+	}
+
+	httpReq, err := BuildRequest(ctx, &RequestConfig{
+		Url:              model.Url.ValueString(),
+		Method:           http.MethodOptions,
+		Headers:          headers,
+		BearerToken:      model.BearerToken,
+		ProviderDefaults: d.config,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build request", err.Error())
+		return
+	}
+
+	reqConfig := d.config.WithAuditSource("data.httpx_options", "read")
+	model.Id = types.StringValue(generateOptionsDataSourceID(model))
+
+	// In dry-run mode the request is only logged
+	if reqConfig.DryRun {
+		logDryRunRequest(ctx, httpReq, reqConfig)
+		resp.Diagnostics.Append(setOptionsResultValues(ctx, &model, nil)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
+	result, err := ExecuteRequestWithRetry(ctx, httpReq, reqConfig, nil, nil, nil)
+	if err != nil {
+		notifyFailure(ctx, httpReq, reqConfig, result, err)
+		resp.Diagnostics.AddError("Request failed", requestFailureDetail(err, result))
+		return
+	}
+
+	resp.Diagnostics.Append(setOptionsResultValues(ctx, &model, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// setOptionsResultValues fills the computed attributes from an OPTIONS response, or nulls them
+// when no request was made
+func setOptionsResultValues(ctx context.Context, model *HttpxOptionsDataSourceModel, result *ResponseResult) diag.Diagnostics {
+	model.StatusCode = types.Int64Null()
+	model.ResponseHeaders = types.MapNull(types.StringType)
+	model.AllowedMethods = types.ListNull(types.StringType)
+	model.Cors = types.ObjectNull(corsAttrTypes)
+	if result == nil {
+		return nil
+	}
+
+	model.StatusCode = types.Int64Value(result.StatusCode)
+	model.ResponseHeaders = headerMapValue(result.Headers)
+	model.AllowedMethods = headerListValue(result.Headers, "Allow")
+
+	cors := CorsModel{
+		AllowOrigin:      types.StringNull(),
+		AllowMethods:     headerListValue(result.Headers, "Access-Control-Allow-Methods"),
+		AllowHeaders:     headerListValue(result.Headers, "Access-Control-Allow-Headers"),
+		AllowCredentials: types.BoolValue(false),
+		ExposeHeaders:    headerListValue(result.Headers, "Access-Control-Expose-Headers"),
+		MaxAge:           types.Int64Null(),
+	}
+	if value, ok := lookupHeader(result.Headers, "Access-Control-Allow-Origin"); ok {
+		cors.AllowOrigin = types.StringValue(value)
+	}
+	if value, ok := lookupHeader(result.Headers, "Access-Control-Allow-Credentials"); ok {
+		cors.AllowCredentials = types.BoolValue(strings.EqualFold(strings.TrimSpace(value), "true"))
+	}
+	if value, ok := lookupHeader(result.Headers, "Access-Control-Max-Age"); ok {
+		if seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+			cors.MaxAge = types.Int64Value(seconds)
+		}
+	}
+
+	corsValue, diags := types.ObjectValueFrom(ctx, corsAttrTypes, cors)
+	model.Cors = corsValue
+	return diags
+}
+
+// headerListValue splits a comma-separated header into a list value, empty when the header is absent
+func headerListValue(headers map[string]string, name string) types.List {
+	elements := []attr.Value{}
+	if value, ok := lookupHeader(headers, name); ok {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				elements = append(elements, types.StringValue(item))
+			}
+		}
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// generateOptionsDataSourceID generates a stable ID for the data source
+func generateOptionsDataSourceID(model HttpxOptionsDataSourceModel) string {
+	hashInput := fmt.Sprintf("OPTIONS|%s|%s|%s",
+		model.Url.ValueString(),
+		model.Origin.ValueString(),
+		model.RequestMethod.ValueString())
+
+	hash := sha256.Sum256([]byte(hashInput))
+	return hex.EncodeToString(hash[:])[:16]
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOptionsResultValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", "GET, POST,OPTIONS")
+		if r.Header.Get("Origin") == "https://app.example.com" && r.Header.Get("Access-Control-Request-Method") == "PUT" {
+			w.Header().Set("Access-Control-Allow-Origin", "https://app.example.com")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, PUT")
+			w.Header().Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Set("Access-Control-Max-Age", "600")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	httpReq, err := BuildRequest(ctx, &RequestConfig{
+		Url:    server.URL,
+		Method: http.MethodOptions,
+		Headers: map[string]string{
+			"Origin":                         "https://app.example.com",
+			"Access-Control-Request-Method":  "PUT",
+			"Access-Control-Request-Headers": "Authorization, X-Trace",
+		},
+	})
+	assert.NoError(t, err)
+	result, err := ExecuteRequestWithRetry(ctx, httpReq, &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}, nil, nil, nil)
+	assert.NoError(t, err)
+
+	var model HttpxOptionsDataSourceModel
+	assert.False(t, setOptionsResultValues(ctx, &model, result).HasError())
+	assert.Equal(t, int64(204), model.StatusCode.ValueInt64())
+
+	var allowed []string
+	assert.False(t, model.AllowedMethods.ElementsAs(ctx, &allowed, false).HasError())
+	assert.Equal(t, []string{"GET", "POST", "OPTIONS"}, allowed)
+
+	var cors CorsModel
+	assert.False(t, model.Cors.As(ctx, &cors, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, "https://app.example.com", cors.AllowOrigin.ValueString())
+	assert.True(t, cors.AllowCredentials.ValueBool())
+	assert.Equal(t, int64(600), cors.MaxAge.ValueInt64())
+	var allowHeaders []string
+	assert.False(t, cors.AllowHeaders.ElementsAs(ctx, &allowHeaders, false).HasError())
+	assert.Equal(t, []string{"Authorization", "X-Trace"}, allowHeaders)
+	assert.Empty(t, cors.ExposeHeaders.Elements())
+
+	assert.False(t, setOptionsResultValues(ctx, &model, nil).HasError())
+	assert.True(t, model.Cors.IsNull())
+}
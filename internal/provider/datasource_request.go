@@ -5,15 +5,20 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ datasource.DataSource = &HttpxRequestDataSource{}
 var _ datasource.DataSourceWithConfigure = &HttpxRequestDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &HttpxRequestDataSource{}
 
 type HttpxRequestDataSource struct {
 	config *ProviderConfig
@@ -40,32 +45,65 @@ func (d *HttpxRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 				Required:    true,
 				Description: "The URL to make the request to",
 			},
+			"path_params": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Values substituted for {name} tokens in the URL, path-escaped so IDs containing '/' or spaces stay in one segment",
+			},
 			"method": schema.StringAttribute{
-				Required:    true,
-				Description: "HTTP method (GET, POST, PUT, PATCH, DELETE, etc.)",
+				Optional:    true,
+				Description: "HTTP method (GET, POST, PUT, PATCH, DELETE, etc.; default: GET)",
+			},
+			"allow_custom_methods": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Allow methods other than the standard HTTP methods, e.g. PROPFIND or PURGE (default: false)",
 			},
 			"headers": schema.MapAttribute{
+				ElementType:        types.StringType,
+				Optional:           true,
+				DeprecationMessage: headersDeprecationMessage,
+				Description:        "Request headers as a map. Deprecated in favor of request_headers.",
+			},
+			"request_headers": schema.MapAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
-				Description: "Request headers as a map",
+				Description: "Request headers as a map. Replaces headers; repeated or case-sensitive headers still use header blocks.",
 			},
 			"query": schema.MapAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Description: "Query parameters",
 			},
+			"cookies": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Cookies to send, rendered into the Cookie header (values are quoted when needed)",
+			},
+			"preserve_header_case": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Send header names exactly as configured instead of canonicalizing them, for legacy servers with case-sensitive header handling (HTTP/1.x only; HTTP/2 always lowercases)",
+			},
 			"body": schema.StringAttribute{
 				Optional:    true,
 				Description: "Raw request body (mutually exclusive with body_json and body_file)",
 			},
 			"body_json": schema.StringAttribute{
 				Optional:    true,
-				Description: "JSON-encodable object (mutually exclusive with body and body_file)",
+				Description: "JSON-encodable object (mutually exclusive with body, body_object and body_file)",
+			},
+			"body_object": schema.DynamicAttribute{
+				Optional:    true,
+				Description: "Request body written as a native HCL object or list, serialized to JSON with sorted keys (mutually exclusive with body, body_json and body_file)",
 			},
 			"body_file": schema.StringAttribute{
 				Optional:    true,
 				Description: "Path to file to read and send (mutually exclusive with body and body_json)",
 			},
+			"auto_content_digest": schema.StringAttribute{
+				Optional:    true,
+				Description: "Compute a digest of the request body and send it: \"sha256\" sets Content-Digest, \"md5\" sets Content-MD5. An explicitly configured header is kept.",
+			},
 			"bearer_token": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
@@ -75,6 +113,23 @@ func (d *HttpxRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 				Optional:    true,
 				Description: "Request timeout in milliseconds",
 			},
+			"connect_timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time allowed to establish a TCP connection, in milliseconds (overrides the provider setting)",
+			},
+			"tls_handshake_timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time allowed for the TLS handshake, in milliseconds (overrides the provider setting)",
+			},
+			"response_header_timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time allowed between sending the request and receiving the response headers, in milliseconds (overrides the provider setting)",
+			},
+			"pinned_spki_sha256": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Base64 SHA-256 SubjectPublicKeyInfo digests, one of which the server must present (overrides the provider setting)",
+			},
 			"insecure_skip_verify": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Skip TLS certificate verification",
@@ -91,33 +146,269 @@ func (d *HttpxRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 				Optional:    true,
 				Description: "Whether to store response body in state (defaults to false for data sources)",
 			},
+			"defer_until_apply": schema.BoolAttribute{
+				Optional:    true,
+				Description: "For endpoints whose prerequisites are created in the same apply. When the request fails during plan, Terraform is asked to defer this read until after the apply, which needs a Terraform version supporting deferred actions; otherwise, and during apply, the failure is an error. Add depends_on on the prerequisites so Terraform reads the data source again during apply, once they exist.",
+			},
+			"plan_behavior": schema.StringAttribute{
+				Optional:    true,
+				Description: "'always' (default) sends the request on every read. 'cached' serves the last result of the same configuration from data_source_cache_dir, so plans and refreshes of slow endpoints don't wait on them. The cache is also served during apply, so a result can be up to cache_max_age old there too; the request is sent again once the configuration changes or the result is older than cache_max_age. Sensitive arguments such as bearer_token are not written to the cache.",
+			},
+			"cache_max_age": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum age of a cached result as a Go duration such as '1h', after which the request is sent again. Used with plan_behavior = \"cached\" (default: 1h).",
+			},
+			"max_response_body_bytes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum response body size in bytes for this request. Overrides the provider's max_response_body_bytes.",
+			},
+			"on_body_overflow": schema.StringAttribute{
+				Optional:    true,
+				Description: "What to do when the response body exceeds max_response_body_bytes: 'truncate' (default) keeps the first bytes followed by a truncation marker, 'fail' returns an error instead of a corrupted body.",
+			},
+			"redact_headers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Additional headers to redact in logs and diagnostics for this request, e.g. [\"X-Internal-Token\"]. Appended to the provider's redact_headers.",
+			},
+			"nonce_header": schema.StringAttribute{
+				Optional:    true,
+				Description: "Header that carries a generated anti-replay nonce (128 random bits, hex encoded), e.g. \"X-Nonce\"",
+			},
+			"nonce_scope": schema.StringAttribute{
+				Optional:    true,
+				Description: "When a new nonce is generated: 'attempt' (default) for every attempt including retries, or 'request' for one value shared by all attempts of an execution",
+			},
+			"capture_transcript": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Store a redacted request/response transcript of every attempt (headers and bodies truncated to 2KB) in transcript, for debugging a single resource without enabling debug logging for the provider",
+			},
+			"response_body_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local path to write the response body to, e.g. for a rendered artifact consumed by a later local-exec or archive step. Parent directories are created as needed. Independent of store_response_body.",
+			},
+			"range": schema.StringAttribute{
+				Optional:    true,
+				Description: "Byte range to request, sent as the Range header, e.g. \"bytes=0-1048575\"",
+			},
+			"transfer_encoding": schema.StringAttribute{
+				Optional:    true,
+				Description: "How the request body is framed on HTTP/1.1, instead of Go's automatic choice: 'chunked' always streams it with Transfer-Encoding: chunked, 'identity' always sends Content-Length, buffering the body when its size isn't known up front, for servers that refuse chunked bodies",
+			},
+			"resume": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Resume interrupted downloads on retry by requesting only the bytes not yet received (with If-Range when the response has an ETag or Last-Modified), instead of restarting from byte zero. Only applies to a single \"bytes=start-[end]\" range or no range.",
+			},
+			"response_header_names": schema.StringAttribute{
+				Optional:    true,
+				Description: "How response_headers names are cased: 'canonical' (default) as in Content-Type, or 'lower' as in content-type, so references don't depend on the casing a server or protocol version uses",
+			},
+			"ignore_response_headers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Response headers to leave out of response_headers, e.g. [\"Date\", \"X-Request-Id\", \"Cf-.*\"]. Entries are regular expressions matched case-insensitively against the full header name. Avoids perpetual diffs from volatile headers with read_mode = \"refresh\".",
+			},
+			"normalize_response_body": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Store JSON response bodies re-serialized compactly with sorted keys, so key-order changes don't cause diffs. Non-JSON bodies are stored unchanged.",
+			},
+			"ignore_body_paths": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "JSON paths removed from the response body before it is stored, e.g. [\"meta.request_id\", \"items[*].updated_at\"]. Implies normalize_response_body. Extraction still sees the full body.",
+			},
 			"status_code": schema.Int64Attribute{
 				Computed:    true,
 				Description: "HTTP status code",
 			},
+			"status_text": schema.StringAttribute{
+				Computed:    true,
+				Description: "HTTP status text of the final response, e.g. \"OK\" or \"Service Unavailable\"",
+			},
+			"protocol": schema.StringAttribute{
+				Computed:    true,
+				Description: "Protocol negotiated for the final response, e.g. \"HTTP/1.1\" or \"HTTP/2.0\"",
+			},
+			"remote_addr": schema.StringAttribute{
+				Computed:    true,
+				Description: "Remote IP:port the final attempt was sent to (the proxy when one is used), null when no network connection was made",
+			},
+			"tls_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "TLS version negotiated for the final attempt, e.g. \"TLS 1.3\", null for plain HTTP",
+			},
+			"tls_cipher_suite": schema.StringAttribute{
+				Computed:    true,
+				Description: "TLS cipher suite negotiated for the final attempt, e.g. \"TLS_AES_128_GCM_SHA256\", null for plain HTTP",
+			},
+			"peer_cert_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex SHA-256 fingerprint of the server's leaf certificate on the final attempt, null for plain HTTP",
+			},
 			"response_headers": schema.MapAttribute{
 				ElementType: types.StringType,
 				Computed:    true,
 				Description: "Response headers",
 			},
+			"response_cookies": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "Cookies parsed from Set-Cookie response headers, keyed by cookie name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Computed:    true,
+							Description: "Cookie value",
+						},
+						"domain": schema.StringAttribute{
+							Computed:    true,
+							Description: "Domain attribute",
+						},
+						"path": schema.StringAttribute{
+							Computed:    true,
+							Description: "Path attribute",
+						},
+						"expires": schema.StringAttribute{
+							Computed:    true,
+							Description: "Expires attribute in RFC 3339 format",
+						},
+						"max_age": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Max-Age attribute in seconds",
+						},
+						"secure": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the Secure attribute is set",
+						},
+						"http_only": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the HttpOnly attribute is set",
+						},
+						"same_site": schema.StringAttribute{
+							Computed:    true,
+							Description: "SameSite attribute: 'Lax', 'Strict', or 'None'",
+						},
+					},
+				},
+			},
+			"response_links": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Targets of the RFC 8288 Link response header keyed by relation type (e.g. next, prev), resolved against the effective URL",
+			},
+			"effective_request_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "Fully resolved request URL after path parameter substitution and query merging, with redacted query parameters masked",
+			},
+			"effective_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "Final URL of the request after following redirects",
+			},
+			"redirect_chain": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Redirects followed to reach effective_url, in order",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"url": schema.StringAttribute{
+							Computed:    true,
+							Description: "URL that returned the redirect",
+						},
+						"status_code": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Redirect status code",
+						},
+						"location": schema.StringAttribute{
+							Computed:    true,
+							Description: "Resolved URL the redirect pointed to",
+						},
+					},
+				},
+			},
 			"response_body": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   false, // Will be set dynamically based on response_sensitive
 				Description: "Response body",
 			},
+			"response_body_json": schema.DynamicAttribute{
+				Computed:    true,
+				Description: "Response body parsed as JSON for native indexing (null when the body is not JSON or not stored)",
+			},
+			"response_body_file_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex-encoded SHA-256 of the body written to response_body_file",
+			},
 			"outputs": schema.MapAttribute{
 				ElementType: types.StringType,
 				Computed:    true,
 				Description: "Extracted values from extract blocks",
 			},
+			"outputs_lists": schema.MapAttribute{
+				ElementType: types.ListType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "Extracted arrays from extract blocks whose json_path resolves to an array (or that set for_each_path)",
+			},
+			"outputs_json": schema.StringAttribute{
+				Computed:    true,
+				Description: "JSON array of the items from every page when a paginate block is configured",
+			},
 			"last_attempt_count": schema.Int64Attribute{
 				Computed:    true,
 				Description: "Number of attempts made",
 			},
+			"last_response_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC 3339 timestamp of when the request was executed",
+			},
 			"last_error": schema.StringAttribute{
 				Computed:    true,
 				Description: "Last error message (redacted)",
 			},
+			"attempt_history": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Per-attempt details of the last execution (most recent 20 attempts)",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"attempt": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Attempt number",
+						},
+						"status_code": schema.Int64Attribute{
+							Computed:    true,
+							Description: "HTTP status code (null on transport errors)",
+						},
+						"duration_ms": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Attempt duration in milliseconds",
+						},
+						"error": schema.StringAttribute{
+							Computed:    true,
+							Description: "Error message (redacted)",
+						},
+						"conditions_met": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether retry_until conditions were met (null without retry_until)",
+						},
+						"unsatisfied_conditions": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "retry_until conditions that were not met",
+						},
+					},
+				},
+			},
+			"transcript": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Redacted transcript of the attempts of the last execution when capture_transcript is true",
+			},
+			"nonce": schema.StringAttribute{
+				Computed:    true,
+				Description: "Nonce sent with the last attempt when nonce_header is set",
+			},
+			"request_headers_sent": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Final merged headers sent with the last attempt (provider defaults, headers, header blocks, auth and nonce), with sensitive values redacted",
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"header": schema.ListNestedBlock{
@@ -167,20 +458,43 @@ func (d *HttpxRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 					},
 					"backoff": schema.StringAttribute{
 						Optional:    true,
-						Description: "Backoff strategy: 'fixed', 'linear', or 'exponential'",
+						Description: "Backoff strategy: 'fixed', 'linear', 'exponential', or 'decorrelated'",
 					},
 					"jitter": schema.BoolAttribute{
 						Optional:    true,
 						Description: "Add jitter to retry delays",
 					},
+					"jitter_mode": schema.StringAttribute{
+						Optional:    true,
+						Description: "Jitter strategy: 'none', 'full', 'equal', or 'percentage' (adds up to 25%). Overrides jitter when set.",
+					},
 					"retry_on_status_codes": schema.ListAttribute{
 						ElementType: types.Int64Type,
 						Optional:    true,
 						Description: "HTTP status codes that should trigger a retry",
 					},
+					"retry_on_status_classes": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Status classes or ranges to retry on, e.g. [\"5xx\"] or [\"500-599\"]. When set without retry_on_status_codes, the default status codes are not used.",
+					},
 					"respect_retry_after": schema.BoolAttribute{
 						Optional:    true,
-						Description: "Respect Retry-After header if present",
+						Description: "Respect Retry-After and rate limit reset headers (RateLimit-Reset, X-RateLimit-Reset) if present",
+					},
+					"retry_on_errors": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Transport error classes that should trigger a retry: 'dns', 'connect', 'timeout', 'tls', 'reset', 'other' (any other transport error), or 'any'. Defaults to ['any'].",
+					},
+					"safe_methods_only": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Only retry transport errors that may have reached the server (timeouts, resets) for idempotent methods or requests carrying an Idempotency-Key header (default: true). DNS, connect and TLS errors are always retried",
+					},
+					"status_delay_overrides": schema.MapAttribute{
+						ElementType: types.Int64Type,
+						Optional:    true,
+						Description: "Delay in milliseconds to use for specific status codes instead of the backoff curve, e.g. { \"423\" = 30000 }. Retry-After still takes precedence when respected.",
 					},
 				},
 			},
@@ -192,6 +506,11 @@ func (d *HttpxRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 						Optional:    true,
 						Description: "Status codes that satisfy the condition",
 					},
+					"status_classes": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Status classes or ranges to wait for, e.g. [\"2xx\"] or [\"200-204\"]. A status matching either status_codes or status_classes satisfies the condition.",
+					},
 					"json_path_equals": schema.MapAttribute{
 						ElementType: types.StringType,
 						Optional:    true,
@@ -202,10 +521,94 @@ func (d *HttpxRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 						Optional:    true,
 						Description: "Header conditions that must equal specified values",
 					},
+					"header_equals_ignore_case": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Compare header_equals values case-insensitively, here and in condition blocks (default: false)",
+					},
 					"body_regex": schema.StringAttribute{
 						Optional:    true,
 						Description: "Regex pattern that must match the response body",
 					},
+					"jq": schema.StringAttribute{
+						Optional:    true,
+						Description: "jq expression that must evaluate to a truthy value (not false or null) against the JSON body, e.g. '.status == \"ready\"'",
+					},
+					"interval_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Fixed delay between polls when conditions are not met. Transport error retries keep the retry block's backoff. Defaults to the retry backoff.",
+					},
+					"initial_delay_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Delay before the first poll in milliseconds",
+					},
+					"consecutive_successes": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Number of consecutive polls that must satisfy the conditions before succeeding (default: 1)",
+					},
+					"match": schema.StringAttribute{
+						Optional:    true,
+						Description: "How the conditions combine: 'all' (default) requires every condition and condition block to be satisfied, 'any' requires at least one",
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"condition": schema.ListNestedBlock{
+						Description: "Condition group that counts as one condition of retry_until, e.g. status_codes = [409] with a jq check of the error, combined with status_codes = [200] and match = \"any\" to also accept an already-existing object",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"match": schema.StringAttribute{
+									Optional:    true,
+									Description: "How the group's conditions combine: 'all' (default) or 'any'",
+								},
+								"status_codes": schema.ListAttribute{
+									ElementType: types.Int64Type,
+									Optional:    true,
+									Description: "Status codes that satisfy the condition",
+								},
+								"status_classes": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Status classes or ranges that satisfy the condition, e.g. [\"2xx\"]",
+								},
+								"json_path_equals": schema.MapAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "JSON path conditions that must equal specified values",
+								},
+								"header_equals": schema.MapAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Header conditions that must equal specified values",
+								},
+								"body_regex": schema.StringAttribute{
+									Optional:    true,
+									Description: "Regex pattern that must match the response body",
+								},
+								"jq": schema.StringAttribute{
+									Optional:    true,
+									Description: "jq expression that must evaluate to a truthy value against the JSON body",
+								},
+							},
+						},
+					},
+				},
+			},
+			"abort_on": schema.SingleNestedBlock{
+				Description: "Conditions that stop retrying/polling immediately with an error. The request is aborted when any condition matches.",
+				Attributes: map[string]schema.Attribute{
+					"status_codes": schema.ListAttribute{
+						ElementType: types.Int64Type,
+						Optional:    true,
+						Description: "Status codes that abort the request",
+					},
+					"json_path_equals": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "JSON path conditions that abort the request when a path equals the specified value",
+					},
+					"body_regex": schema.StringAttribute{
+						Optional:    true,
+						Description: "Regex pattern that aborts the request when it matches the response body",
+					},
 				},
 			},
 			"expect": schema.SingleNestedBlock{
@@ -216,6 +619,11 @@ func (d *HttpxRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 						Optional:    true,
 						Description: "Expected HTTP status codes",
 					},
+					"status_classes": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Expected status classes or ranges, e.g. [\"2xx\", \"500-599\"]. A status matching either status_codes or status_classes passes.",
+					},
 					"json_path_exists": schema.ListAttribute{
 						ElementType: types.StringType,
 						Optional:    true,
@@ -231,6 +639,131 @@ func (d *HttpxRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 						Optional:    true,
 						Description: "Headers that must be present",
 					},
+					"jq": schema.StringAttribute{
+						Optional:    true,
+						Description: "jq expression that must evaluate to a truthy value (not false or null) against the JSON body, e.g. '.items | length > 0'",
+					},
+					"body_sha256": schema.StringAttribute{
+						Optional:    true,
+						Description: "Expected hex-encoded SHA-256 of the response body, for verifying downloaded artifacts",
+					},
+					"content_length": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Expected response body size in bytes",
+					},
+					"content_type": schema.StringAttribute{
+						Optional:    true,
+						Description: "Expected media type of the response, ignoring parameters such as charset. Supports '*' wildcards, e.g. 'application/*json*'.",
+					},
+					"tls_cert_min_days_valid": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Fail if the server's TLS certificate expires within this many days. Requires an HTTPS request.",
+					},
+					"severity": schema.StringAttribute{
+						Optional:    true,
+						Description: "How failed expectations are reported: 'error' (default) fails the operation, 'warning' emits a warning diagnostic and continues",
+					},
+					"error_message": schema.StringAttribute{
+						Optional:    true,
+						Description: "Message to report instead of the generic one when expectations fail, e.g. \"quota exceeded, request an increase via the portal: ${self.response_body_excerpt}\". Supports ${self.status_code}, ${self.response_body}, ${self.response_body_excerpt}, ${self.response_headers.NAME}, ${self.unsatisfied_conditions} and template functions such as ${jsonpath(self.response_body, \"error.message\")}.",
+					},
+					"match": schema.StringAttribute{
+						Optional:    true,
+						Description: "How the expectations combine: 'all' (default) requires every expectation and condition block to pass, 'any' requires at least one",
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"condition": schema.ListNestedBlock{
+						Description: "Expectation group that counts as one expectation of expect, e.g. status_codes = [409] with a jq check of the error, combined with status_codes = [200] and match = \"any\" to also accept an already-existing object",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"match": schema.StringAttribute{
+									Optional:    true,
+									Description: "How the group's expectations combine: 'all' (default) or 'any'",
+								},
+								"status_codes": schema.ListAttribute{
+									ElementType: types.Int64Type,
+									Optional:    true,
+									Description: "Expected HTTP status codes",
+								},
+								"status_classes": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Expected status classes or ranges, e.g. [\"2xx\"]",
+								},
+								"header_present": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Headers that must be present",
+								},
+								"jq": schema.StringAttribute{
+									Optional:    true,
+									Description: "jq expression that must evaluate to a truthy value against the JSON body",
+								},
+								"content_type": schema.StringAttribute{
+									Optional:    true,
+									Description: "Expected media type of the response, supporting '*' wildcards",
+								},
+							},
+						},
+					},
+				},
+			},
+			"pre_request_command": schema.SingleNestedBlock{
+				Description: "Local program run before every attempt. It receives the request as JSON on stdin ({\"method\", \"url\", \"headers\", \"body\"}, with sensitive values redacted) and may print {\"headers\": {...}} to set request headers or {\"veto\": \"reason\"} to cancel the call. A non-zero exit status also cancels the call.",
+				Attributes: map[string]schema.Attribute{
+					"command": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Program and arguments to run, e.g. [\"/usr/local/bin/sign-request\", \"--profile\", \"prod\"]",
+					},
+					"timeout_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Time the command may run before the call is cancelled (default: 10000)",
+					},
+				},
+			},
+			"post_response_command": schema.SingleNestedBlock{
+				Description: "Local program run after every attempt that received a response. It receives the request and a \"response\" object ({\"status_code\", \"headers\", \"body\"}) as JSON on stdin and may print {\"veto\": \"reason\"} to fail the call. A non-zero exit status also fails the call. Vetoed calls are not retried.",
+				Attributes: map[string]schema.Attribute{
+					"command": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Program and arguments to run",
+					},
+					"timeout_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Time the command may run before the call fails (default: 10000)",
+					},
+				},
+			},
+			"paginate": schema.SingleNestedBlock{
+				Description: "Follow pagination and merge the items of every page into outputs_json",
+				Attributes: map[string]schema.Attribute{
+					"mode": schema.StringAttribute{
+						Optional:    true,
+						Description: "How the next page is found: 'link_header' (rel=\"next\" in the Link header), 'json_cursor' (cursor_path in the body) or 'page_param' (incrementing page_param)",
+					},
+					"max_pages": schema.Int64Attribute{
+						Optional:    true,
+						Description: fmt.Sprintf("Maximum number of pages to fetch, including the first (default: %d)", defaultPaginateMaxPages),
+					},
+					"item_path": schema.StringAttribute{
+						Optional:    true,
+						Description: "JSON path to the array of items in each page, e.g. 'data.items' (default: the body itself)",
+					},
+					"cursor_path": schema.StringAttribute{
+						Optional:    true,
+						Description: "JSON path to the next-page cursor, required for json_cursor. Pagination stops when it is missing or empty",
+					},
+					"cursor_param": schema.StringAttribute{
+						Optional:    true,
+						Description: fmt.Sprintf("Query parameter the cursor is sent in (default: %q)", defaultPaginateCursorParam),
+					},
+					"page_param": schema.StringAttribute{
+						Optional:    true,
+						Description: fmt.Sprintf("Query parameter holding the page number for page_param (default: %q). Pagination stops at the first empty page", defaultPaginatePageParam),
+					},
 				},
 			},
 			"extract": schema.ListNestedBlock{
@@ -245,10 +778,26 @@ func (d *HttpxRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 							Optional:    true,
 							Description: "JSON path to extract from",
 						},
+						"for_each_path": schema.StringAttribute{
+							Optional:    true,
+							Description: "Path evaluated against each element of the array at json_path (or the body root when json_path is unset); results populate outputs_lists",
+						},
+						"jq": schema.StringAttribute{
+							Optional:    true,
+							Description: "jq expression evaluated against the JSON body instead of json_path, e.g. '.items | map(.id) | join(\",\")'. Multiple outputs are returned as a JSON array.",
+						},
 						"header": schema.StringAttribute{
 							Optional:    true,
 							Description: "Header name to extract from",
 						},
+						"cookie": schema.StringAttribute{
+							Optional:    true,
+							Description: "Cookie name to extract the value of from Set-Cookie response headers",
+						},
+						"link_rel": schema.StringAttribute{
+							Optional:    true,
+							Description: "Link header relation type to extract the target URL of, e.g. \"next\"",
+						},
 					},
 				},
 			},
@@ -273,6 +822,14 @@ func (d *HttpxRequestDataSource) Configure(_ context.Context, req datasource.Con
 	d.config = config
 }
 
+func (d *HttpxRequestDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateAttributeAliases(ctx, req.Config, dataSourceAttributeAliases)...)
+	resp.Diagnostics.Append(validateExtractBlocks(ctx, req.Config, path.Root("extract"))...)
+	resp.Diagnostics.Append(validateRegexAttributes(ctx, req.Config, dataSourceRegexAttributes, dataSourceRetryConditionBlocks)...)
+	resp.Diagnostics.Append(validateResponseHeaderNames(ctx, req.Config)...)
+	resp.Diagnostics.Append(validateRetryBlocks(ctx, req.Config, dataSourceRetryBlocks)...)
+}
+
 func (d *HttpxRequestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var model HttpxRequestDataSourceModel
 
@@ -282,8 +839,28 @@ func (d *HttpxRequestDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	// Serve the last result of this configuration when plan_behavior = "cached"
+	cached, cacheMaxAge, err := parsePlanBehavior(model.PlanBehavior, model.CacheMaxAge)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid plan_behavior", err.Error())
+		return
+	}
+	cachePath := ""
+	if cached && !d.config.DryRun {
+		cachePath = dataSourceCachePath(d.config.DataSourceCacheDir, req.Config)
+		stateType := resp.State.Schema.Type().TerraformType(ctx)
+		value, ok, err := loadCachedDataSourceState(cachePath, cacheMaxAge, req.Config, stateType, time.Now())
+		if err != nil {
+			resp.Diagnostics.AddWarning("Failed to read cached result", err.Error())
+		} else if ok {
+			tflog.Debug(ctx, "Serving cached data source result", map[string]interface{}{"path": cachePath})
+			resp.State.Raw = value
+			return
+		}
+	}
+
 	// Build request configuration
-	headers, err := ConvertTerraformMap(ctx, model.Headers)
+	headers, err := ConvertTerraformMap(ctx, aliasedMap(model.RequestHeaders, model.Headers))
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid Headers", err.Error())
 		return
@@ -295,16 +872,36 @@ func (d *HttpxRequestDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	cookies, err := ConvertTerraformMap(ctx, model.Cookies)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Cookies", err.Error())
+		return
+	}
+
+	pathParams, err := ConvertTerraformMap(ctx, model.PathParams)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Path Params", err.Error())
+		return
+	}
+
 	// Build HTTP request
 	httpReq, err := BuildRequest(ctx, &RequestConfig{
 		Url:              model.Url.ValueString(),
+		PathParams:       pathParams,
 		Method:           model.Method.ValueString(),
+		AllowCustomMethods: model.AllowCustomMethods.ValueBool(),
 		Headers:          headers,
 		HeaderBlocks:     model.HeaderBlocks,
+		PreserveHeaderCase: model.PreserveHeaderCase.ValueBool(),
 		Query:            query,
+		Cookies:          cookies,
 		Body:             model.Body,
 		BodyJson:         model.BodyJson,
+		BodyObject:       model.BodyObject,
 		BodyFile:         model.BodyFile,
+		AutoContentDigest: model.AutoContentDigest.ValueString(),
+		Range: model.Range.ValueString(),
+		TransferEncoding: model.TransferEncoding.ValueString(),
 		BasicAuth:        model.BasicAuth,
 		BearerToken:      model.BearerToken,
 		ProviderDefaults: d.config,
@@ -314,23 +911,94 @@ func (d *HttpxRequestDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	// Extend the provider's redact_headers with this request's own list
+	reqConfig, err := d.config.WithAuditSource("data.httpx_request", "read").WithRedactHeaders(ctx, model.RedactHeaders)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid redact_headers", err.Error())
+		return
+	}
+	model.EffectiveRequestUrl = types.StringValue(reqConfig.redact(httpReq.URL.String()))
+
+	// In dry-run mode the request is only logged
+	if reqConfig.DryRun {
+		logDryRunRequest(ctx, httpReq, reqConfig)
+		model.Id = types.StringValue(generateDataSourceID(model))
+		setDryRunDataSourceComputedValues(&model)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
+	// Apply per-resource response body limits
+	execConfig, err := reqConfig.WithResponseBodyLimit(model.MaxResponseBodyBytes, model.OnBodyOverflow)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid response body limit", err.Error())
+		return
+	}
+	execConfig = execConfig.WithCaptureTranscript(model.CaptureTranscript).WithResume(model.Resume)
+	execConfig, err = execConfig.WithNonce(model.NonceHeader, model.NonceScope)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid nonce configuration", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithCommandHooks(ctx, model.PreRequestCommand, model.PostResponseCommand)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid command hook", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithPhaseTimeouts(model.ConnectTimeoutMs, model.TlsHandshakeTimeoutMs, model.ResponseHeaderTimeoutMs)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout configuration", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithPinnedSPKI(ctx, model.PinnedSpkiSha256)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid pinned_spki_sha256", err.Error())
+		return
+	}
+
 	// Build retry configs
 	retryConfig := BuildRetryConfig(ctx, model.Retry)
 	retryUntilConfig := BuildRetryUntilConfig(ctx, model.RetryUntil)
+	abortOnConfig := BuildAbortOnConfig(ctx, model.AbortOn)
 
 	// Execute request with retry and conditional retry
-	result, err := ExecuteRequestWithRetry(ctx, httpReq, d.config, retryConfig, retryUntilConfig)
+	result, err := ExecuteRequestWithRetry(ctx, httpReq, execConfig, retryConfig, retryUntilConfig, abortOnConfig)
 	if err != nil {
-		resp.Diagnostics.AddError("Request failed", err.Error())
+		if deferDataSourceRead(ctx, req, resp, &model, requestFailureDetail(err, result)) {
+			return
+		}
+		notifyFailure(ctx, httpReq, execConfig, result, err)
+		resp.Diagnostics.AddError("Request failed", requestFailureDetail(err, result))
 		return
 	}
 
 	// Validate expectations
 	if model.Expect != nil {
 		if err := ValidateExpectations(ctx, result, model.Expect); err != nil {
-			resp.Diagnostics.AddError("Expectation validation failed", err.Error())
+			if expectationIsWarning(model.Expect) {
+				resp.Diagnostics.AddWarning("Expectation validation failed", err.Error())
+			} else {
+				if deferDataSourceRead(ctx, req, resp, &model, err.Error()) {
+					return
+				}
+				notifyFailure(ctx, httpReq, execConfig, result, fmt.Errorf("expectation validation failed: %w", err))
+				resp.Diagnostics.AddError("Expectation validation failed", err.Error())
+				return
+			}
+		}
+	}
+
+	// Follow pagination and merge items from every page
+	model.OutputsJson = types.StringNull()
+	if model.Paginate != nil {
+		outputsJson, err := CollectPaginatedItems(ctx, httpReq, result, model.Paginate, func(pageReq *http.Request) (*ResponseResult, error) {
+			return ExecuteRequestWithRetry(ctx, pageReq, execConfig, retryConfig, retryUntilConfig, abortOnConfig)
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Pagination failed", err.Error())
 			return
 		}
+		model.OutputsJson = types.StringValue(outputsJson)
 	}
 
 	// Generate ID (hash of request inputs for stability)
@@ -339,7 +1007,20 @@ func (d *HttpxRequestDataSource) Read(ctx context.Context, req datasource.ReadRe
 	// Set computed attributes
 	model.Id = types.StringValue(id)
 	model.StatusCode = types.Int64Value(result.StatusCode)
+	model.StatusText = stringOrNull(result.StatusText)
+	model.Protocol = stringOrNull(result.Protocol)
+	model.RemoteAddr = stringOrNull(result.RemoteAddr)
+	model.TlsVersion = tlsVersionValue(result.TLS)
+	model.TlsCipherSuite = tlsCipherSuiteValue(result.TLS)
+	model.PeerCertSha256 = peerCertSha256Value(result.TLS)
 	model.LastAttemptCount = types.Int64Value(result.AttemptCount)
+	model.LastResponseAt = currentTimestamp()
+	historyValue, historyDiags := AttemptHistoryValue(ctx, result.AttemptHistory)
+	resp.Diagnostics.Append(historyDiags...)
+	model.AttemptHistory = historyValue
+	model.Transcript = TranscriptValue(result.AttemptHistory)
+	model.Nonce = NonceValue(result.AttemptHistory)
+	model.RequestHeadersSent = RequestHeadersSentValue(result.AttemptHistory)
 	if result.Error != "" {
 		model.LastError = types.StringValue(result.Error)
 	} else {
@@ -347,11 +1028,22 @@ func (d *HttpxRequestDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	// Set response headers
-	responseHeaders := make(map[string]attr.Value)
-	for k, v := range result.Headers {
-		responseHeaders[k] = types.StringValue(v)
+	responseHeaders, err := ResponseHeadersValue(ctx, normalizeResponseHeaderNames(result.Headers, model.ResponseHeaderNames), model.IgnoreResponseHeaders)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ignore_response_headers", err.Error())
+		return
 	}
-	model.ResponseHeaders = types.MapValueMust(types.StringType, responseHeaders)
+	model.ResponseHeaders = responseHeaders
+
+	responseCookies, cookieDiags := ResponseCookiesValue(ctx, result.Cookies)
+	resp.Diagnostics.Append(cookieDiags...)
+	model.ResponseCookies = responseCookies
+	model.ResponseLinks = ResponseLinksValue(result)
+
+	redirectChain, redirectDiags := RedirectChainValue(ctx, result.RedirectChain)
+	resp.Diagnostics.Append(redirectDiags...)
+	model.RedirectChain = redirectChain
+	model.EffectiveUrl = effectiveURLValue(result)
 
 	// Set response body (default to false for data sources to avoid polluting state)
 	storeBody := false
@@ -363,10 +1055,25 @@ func (d *HttpxRequestDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	if storeBody {
-		model.ResponseBody = types.StringValue(result.Body)
+		storedBody, err := NormalizeResponseBody(ctx, result.Body, model.NormalizeResponseBody, model.IgnoreBodyPaths)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to normalize response body", err.Error())
+			return
+		}
+		model.ResponseBody = types.StringValue(storedBody)
+		model.ResponseBodyJson = ResponseBodyJsonValue(ctx, storedBody)
 	} else {
 		model.ResponseBody = types.StringNull()
+		model.ResponseBodyJson = types.DynamicNull()
+	}
+
+	// Write response body to a local file if configured
+	bodyFileSha256, err := ResponseBodyFileValue(model.ResponseBodyFile, result.Body)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to write response body file", err.Error())
+		return
 	}
+	model.ResponseBodyFileSha256 = bodyFileSha256
 
 	// Extract values from response
 	extractedOutputs, err := ExtractValues(ctx, result, model.ExtractBlocks)
@@ -381,8 +1088,25 @@ func (d *HttpxRequestDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 	model.Outputs = types.MapValueMust(types.StringType, outputsMap)
 
+	// Extract arrays into list outputs
+	outputsLists, listDiags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, ExtractListValues(ctx, result, model.ExtractBlocks))
+	resp.Diagnostics.Append(listDiags...)
+	model.OutputsLists = outputsLists
+
+	// Encrypt stored payloads when state_encryption_key is set
+	if err := sealResponseState(ctx, d.config, dataSourceResponseFields(&model)); err != nil {
+		resp.Diagnostics.AddError("Failed to encrypt state", err.Error())
+		return
+	}
+
 	// Save state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+
+	if cachePath != "" && !resp.Diagnostics.HasError() {
+		if err := storeCachedDataSourceState(cachePath, resp.State, resp.State.Schema.Type().TerraformType(ctx)); err != nil {
+			resp.Diagnostics.AddWarning("Failed to cache result", err.Error())
+		}
+	}
 }
 
 // generateDataSourceID generates a stable ID for the data source
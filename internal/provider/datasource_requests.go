@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &HttpxRequestsDataSource{}
+var _ datasource.DataSourceWithConfigure = &HttpxRequestsDataSource{}
+
+// defaultMaxConcurrency is the worker pool size when max_concurrency is not set
+const defaultMaxConcurrency = 4
+
+// requestResultAttrTypes describes a single httpx_requests result object
+var requestResultAttrTypes = map[string]attr.Type{
+	"status_code":      types.Int64Type,
+	"response_body":    types.StringType,
+	"response_headers": types.MapType{ElemType: types.StringType},
+	"error":            types.StringType,
+}
+
+type HttpxRequestsDataSource struct {
+	config *ProviderConfig
+}
+
+func NewHttpxRequestsDataSource() datasource.DataSource {
+	return &HttpxRequestsDataSource{}
+}
+
+func (d *HttpxRequestsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_requests"
+}
+
+func (d *HttpxRequestsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source for executing many HTTP requests concurrently with a bounded worker pool",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Data source identifier",
+			},
+			"requests": schema.MapNestedAttribute{
+				Required:    true,
+				Description: "Requests to execute, keyed by a name used to look up the matching entry in results",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"url": schema.StringAttribute{
+							Required:    true,
+							Description: "The URL to make the request to",
+						},
+						"method": schema.StringAttribute{
+							Optional:    true,
+							Description: "HTTP method (default: GET)",
+						},
+						"allow_custom_methods": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Allow methods other than the standard HTTP methods, e.g. PROPFIND or PURGE (default: false)",
+						},
+						"headers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Request headers as a map",
+						},
+						"query": schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Query parameters",
+						},
+						"body": schema.StringAttribute{
+							Optional:    true,
+							Description: "Raw request body",
+						},
+						"body_json": schema.StringAttribute{
+							Optional:    true,
+							Description: "JSON request body (sets Content-Type: application/json)",
+						},
+						"bearer_token": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Bearer token for authentication",
+						},
+					},
+				},
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Maximum number of requests in flight at once (default: %d)", defaultMaxConcurrency),
+			},
+			"fail_on_error": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Fail the read when any request fails (default: true). When false, failures are reported in results[*].error",
+			},
+			"results": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "Results keyed by the same names as requests",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"status_code": schema.Int64Attribute{
+							Computed:    true,
+							Description: "HTTP status code of the response",
+						},
+						"response_body": schema.StringAttribute{
+							Computed:    true,
+							Description: "Response body",
+						},
+						"response_headers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Response headers",
+						},
+						"error": schema.StringAttribute{
+							Computed:    true,
+							Description: "Error message when the request failed",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HttpxRequestsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ProviderConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			"Expected *ProviderConfig, got something else",
+		)
+		return
+	}
+
+	d.config = config
+}
+
+func (d *HttpxRequestsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model HttpxRequestsDataSourceModel
+
+	// Read Terraform configuration into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	concurrency := int64(defaultMaxConcurrency)
+	if !model.MaxConcurrency.IsNull() && !model.MaxConcurrency.IsUnknown() {
+		concurrency = model.MaxConcurrency.ValueInt64()
+		if concurrency < 1 {
+			resp.Diagnostics.AddError("Invalid max_concurrency", fmt.Sprintf("max_concurrency must be at least 1, got %d", concurrency))
+			return
+		}
+	}
+
+	failOnError := true
+	if !model.FailOnError.IsNull() && !model.FailOnError.IsUnknown() {
+		failOnError = model.FailOnError.ValueBool()
+	}
+
+	outcomes := executeRequestSpecs(ctx, model.Requests, d.config, int(concurrency))
+
+	results := make(map[string]attr.Value, len(outcomes))
+	for _, outcome := range outcomes {
+		if outcome.err != nil && failOnError {
+			resp.Diagnostics.AddError("Request failed", fmt.Sprintf("requests[%q]: %s", outcome.key, requestFailureDetail(outcome.err, outcome.result)))
+			continue
+		}
+
+		value, diags := requestResultValue(ctx, outcome)
+		resp.Diagnostics.Append(diags...)
+		results[outcome.key] = value
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resultsValue, diags := types.MapValue(types.ObjectType{AttrTypes: requestResultAttrTypes}, results)
+	resp.Diagnostics.Append(diags...)
+	model.Results = resultsValue
+	model.Id = types.StringValue(generateRequestsDataSourceID(model))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// requestSpecOutcome is the result of executing one request spec
+type requestSpecOutcome struct {
+	key    string
+	result *ResponseResult
+	err    error
+}
+
+// executeRequestSpecs runs every request spec with at most concurrency requests in flight.
+// Outcomes are returned sorted by key.
+func executeRequestSpecs(ctx context.Context, specs map[string]RequestSpecModel, providerConfig *ProviderConfig, concurrency int) []requestSpecOutcome {
+	keys := make([]string, 0, len(specs))
+	for key := range specs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	outcomes := make([]requestSpecOutcome, len(keys))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency && w < len(keys); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				spec := specs[keys[i]]
+				specConfig := providerConfig.WithAuditSource(fmt.Sprintf("data.httpx_requests[%q]", keys[i]), "read")
+				result, err := executeRequestSpec(ctx, &spec, specConfig)
+				outcomes[i] = requestSpecOutcome{key: keys[i], result: result, err: err}
+			}
+		}()
+	}
+
+	for i := range keys {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return outcomes
+}
+
+// executeRequestSpec builds and executes a single request spec. In dry-run mode the request is
+// only logged and a nil result is returned.
+func executeRequestSpec(ctx context.Context, spec *RequestSpecModel, providerConfig *ProviderConfig) (*ResponseResult, error) {
+	headers, err := ConvertTerraformMap(ctx, spec.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid headers: %w", err)
+	}
+	query, err := ConvertTerraformMap(ctx, spec.Query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	httpReq, err := BuildRequest(ctx, &RequestConfig{
+		Url:                spec.Url.ValueString(),
+		Method:             spec.Method.ValueString(),
+		AllowCustomMethods: spec.AllowCustomMethods.ValueBool(),
+		Headers:            headers,
+		Query:              query,
+		Body:               spec.Body,
+		BodyJson:           spec.BodyJson,
+		BearerToken:        spec.BearerToken,
+		ProviderDefaults:   providerConfig,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	if providerConfig.DryRun {
+		logDryRunRequest(ctx, httpReq, providerConfig)
+		return nil, nil
+	}
+
+	result, err := ExecuteRequestWithRetry(ctx, httpReq, providerConfig, nil, nil, nil)
+	if err != nil {
+		notifyFailure(ctx, httpReq, providerConfig, result, err)
+	}
+	return result, err
+}
+
+// requestResultValue converts an outcome into a results object
+func requestResultValue(ctx context.Context, outcome requestSpecOutcome) (attr.Value, diag.Diagnostics) {
+	model := RequestResultModel{
+		StatusCode:      types.Int64Null(),
+		ResponseBody:    types.StringNull(),
+		ResponseHeaders: types.MapNull(types.StringType),
+		Error:           types.StringNull(),
+	}
+
+	if outcome.result != nil && outcome.result.StatusCode != 0 {
+		model.StatusCode = types.Int64Value(outcome.result.StatusCode)
+		model.ResponseBody = types.StringValue(outcome.result.Body)
+		headers := make(map[string]attr.Value, len(outcome.result.Headers))
+		for name, value := range outcome.result.Headers {
+			headers[name] = types.StringValue(value)
+		}
+		model.ResponseHeaders = types.MapValueMust(types.StringType, headers)
+	}
+	if outcome.err != nil {
+		model.Error = types.StringValue(requestFailureDetail(outcome.err, outcome.result))
+	}
+
+	return types.ObjectValueFrom(ctx, requestResultAttrTypes, model)
+}
+
+// generateRequestsDataSourceID hashes the request names, methods and URLs
+func generateRequestsDataSourceID(model HttpxRequestsDataSourceModel) string {
+	keys := make([]string, 0, len(model.Requests))
+	for key := range model.Requests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		spec := model.Requests[key]
+		fmt.Fprintf(h, "%s|%s|%s\n", key, spec.Method.ValueString(), spec.Url.ValueString())
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteRequestSpecs(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Path", r.URL.Path)
+		_, _ = w.Write([]byte(r.Method + " " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	specs := map[string]RequestSpecModel{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		specs[name] = RequestSpecModel{Url: types.StringValue(server.URL + "/" + name)}
+	}
+	specs["post"] = RequestSpecModel{Url: types.StringValue(server.URL + "/post"), Method: types.StringValue("post"), Body: types.StringValue("x")}
+	specs["unreachable"] = RequestSpecModel{Url: types.StringValue("http://127.0.0.1:1/")}
+
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576}
+	outcomes := executeRequestSpecs(context.Background(), specs, providerConfig, 2)
+
+	assert.Len(t, outcomes, len(specs))
+	assert.LessOrEqual(t, maxInFlight, 2)
+	assert.Equal(t, "a", outcomes[0].key, "outcomes are sorted by key")
+
+	byKey := map[string]requestSpecOutcome{}
+	for _, outcome := range outcomes {
+		byKey[outcome.key] = outcome
+	}
+	assert.NoError(t, byKey["a"].err)
+	assert.Equal(t, "GET /a", byKey["a"].result.Body)
+	assert.Equal(t, "POST /post", byKey["post"].result.Body)
+	assert.Error(t, byKey["unreachable"].err)
+
+	value, diags := requestResultValue(context.Background(), byKey["a"])
+	assert.False(t, diags.HasError())
+	var result RequestResultModel
+	assert.False(t, value.(types.Object).As(context.Background(), &result, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, int64(200), result.StatusCode.ValueInt64())
+	assert.True(t, result.Error.IsNull())
+
+	value, diags = requestResultValue(context.Background(), byKey["unreachable"])
+	assert.False(t, diags.HasError())
+	assert.False(t, value.(types.Object).As(context.Background(), &result, basetypes.ObjectAsOptions{}).HasError())
+	assert.True(t, result.StatusCode.IsNull())
+	assert.Contains(t, result.Error.ValueString(), "request failed")
+}
@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// deferDataSourceRead handles a failed request of a data source with defer_until_apply set,
+// whose prerequisites may not exist yet, by deferring the read when Terraform allows it. Terraform
+// never allows deferral during apply, so an apply-time failure is still an error. It reports
+// whether it handled the failure.
+func deferDataSourceRead(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse, model *HttpxRequestDataSourceModel, cause string) bool {
+	if !model.DeferUntilApply.ValueBool() || !req.ClientCapabilities.DeferralAllowed {
+		return false
+	}
+
+	tflog.Info(ctx, "Deferring data source read until its prerequisites exist", map[string]interface{}{
+		"cause": cause,
+	})
+	resp.Deferred = &datasource.Deferred{Reason: datasource.DeferredReasonAbsentPrereq}
+	return true
+}
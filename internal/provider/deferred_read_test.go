@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeferDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	NewHttpxRequestDataSource().Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	nullAttributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		nullAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nullAttributes)}
+	newResponse := func() *datasource.ReadResponse {
+		return &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	}
+
+	var model HttpxRequestDataSourceModel
+	assert.False(t, config.Get(ctx, &model).HasError())
+	model.Url = types.StringValue("https://api.example.com/v1/clusters/new")
+
+	// Without defer_until_apply the failure is left to the caller
+	resp := newResponse()
+	assert.False(t, deferDataSourceRead(ctx, datasource.ReadRequest{}, resp, &model, "connection refused"))
+	assert.Nil(t, resp.Deferred)
+
+	model.DeferUntilApply = types.BoolValue(true)
+	req := datasource.ReadRequest{ClientCapabilities: datasource.ReadClientCapabilities{DeferralAllowed: true}}
+	resp = newResponse()
+	assert.True(t, deferDataSourceRead(ctx, req, resp, &model, "connection refused"))
+	assert.Equal(t, datasource.DeferredReasonAbsentPrereq, resp.Deferred.Reason)
+
+	// Without deferral support, e.g. during apply, the failure is left to the caller
+	resp = newResponse()
+	assert.False(t, deferDataSourceRead(ctx, datasource.ReadRequest{}, resp, &model, "connection refused"))
+	assert.Nil(t, resp.Deferred)
+}
+
+func TestDataSourceReadDeferUntilApplyFailsDuringApply(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	var schemaResp datasource.SchemaResponse
+	NewHttpxRequestDataSource().Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: nullObject(objectType, map[string]tftypes.Value{
+		"url":               tftypes.NewValue(tftypes.String, server.URL+"/v1/clusters/new"),
+		"defer_until_apply": tftypes.NewValue(tftypes.Bool, true),
+	})}
+	d := &HttpxRequestDataSource{config: &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}}
+
+	read := func(deferralAllowed bool) *datasource.ReadResponse {
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+		d.Read(ctx, datasource.ReadRequest{Config: config, ClientCapabilities: datasource.ReadClientCapabilities{DeferralAllowed: deferralAllowed}}, resp)
+		return resp
+	}
+
+	resp := read(true)
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.NotNil(t, resp.Deferred)
+
+	// Terraform doesn't allow deferral during apply, where the read must still fail
+	resp = read(false)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Nil(t, resp.Deferred)
+	assert.True(t, resp.State.Raw.IsNull())
+}
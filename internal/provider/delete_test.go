@@ -2,10 +2,15 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -230,3 +235,159 @@ func TestDeleteMissingInterpolationKey(t *testing.T) {
 	assert.Contains(t, err.Error(), "output key not found")
 }
 
+
+// TestParseDestroyFailureMode tests failure_mode defaults and validation
+func TestParseDestroyFailureMode(t *testing.T) {
+	mode, err := parseDestroyFailureMode(types.StringNull())
+	assert.NoError(t, err)
+	assert.Equal(t, "abort", mode)
+
+	for _, valid := range []string{"abort", "warn", "ignore"} {
+		mode, err = parseDestroyFailureMode(types.StringValue(valid))
+		assert.NoError(t, err)
+		assert.Equal(t, valid, mode)
+	}
+
+	_, err = parseDestroyFailureMode(types.StringValue("skip"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failure_mode must be one of")
+}
+
+// TestHandleDestroyFailure tests that only abort mode keeps the resource in state
+func TestHandleDestroyFailure(t *testing.T) {
+	ctx := context.Background()
+
+	abortResp := &resource.DeleteResponse{}
+	handleDestroyFailure(ctx, abortResp, "abort", "Destroy request failed", "status 500")
+	assert.True(t, abortResp.Diagnostics.HasError())
+
+	warnResp := &resource.DeleteResponse{}
+	handleDestroyFailure(ctx, warnResp, "warn", "Destroy request failed", "status 500")
+	assert.False(t, warnResp.Diagnostics.HasError())
+	assert.Equal(t, 1, warnResp.Diagnostics.WarningsCount())
+
+	ignoreResp := &resource.DeleteResponse{}
+	handleDestroyFailure(ctx, ignoreResp, "ignore", "Destroy request failed", "status 500")
+	assert.Empty(t, ignoreResp.Diagnostics)
+}
+
+// TestContainsStatusCode tests treat_status_as_success matching
+func TestContainsStatusCode(t *testing.T) {
+	assert.True(t, containsStatusCode([]int64{404, 410}, 404))
+	assert.False(t, containsStatusCode([]int64{404, 410}, 500))
+	assert.False(t, containsStatusCode(nil, 404))
+}
+
+// TestRefreshForDestroy tests that the pre-destroy refresh updates outputs used for interpolation
+func TestRefreshForDestroy(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "rotated-456"}`))
+	}))
+	defer server.Close()
+
+	state := &HttpxRequestResourceModel{
+		Id: types.StringValue("res-123"),
+		Outputs: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"resource_id": types.StringValue("stale-123"),
+		}),
+		ExtractBlocks: []ExtractBlockModel{
+			{
+				Name:     types.StringValue("resource_id"),
+				JsonPath: types.StringValue("id"),
+			},
+		},
+		OnDestroy: &RequestConfigModel{
+			Method:               types.StringValue("DELETE"),
+			Url:                  types.StringValue(server.URL + "/${self.outputs.resource_id}"),
+			RefreshBeforeDestroy: types.BoolValue(true),
+		},
+	}
+	state.Url = types.StringValue(server.URL)
+	state.Method = types.StringValue("GET")
+
+	r := &HttpxRequestResource{config: &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576}}
+	err := r.refreshForDestroy(ctx, state, nil)
+	assert.NoError(t, err)
+
+	interpolCtx, err := BuildInterpolationContextFromState(ctx, state)
+	assert.NoError(t, err)
+	expandedURL, err := InterpolateString(ctx, state.OnDestroy.Url.ValueString(), interpolCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/rotated-456", expandedURL)
+	assert.Equal(t, int64(200), state.StatusCode.ValueInt64())
+
+	// Extraction follows fail_on_extract_error like create and read, keeping the stored outputs
+	state.Outputs = types.MapValueMust(types.StringType, map[string]attr.Value{"resource_id": types.StringValue("stale-123")})
+	state.ExtractBlocks = append(state.ExtractBlocks, ExtractBlockModel{Name: types.StringValue("region"), JsonPath: types.StringValue("region")})
+	state.FailOnExtractError = types.BoolValue(true)
+	assert.ErrorContains(t, r.refreshForDestroy(ctx, state, nil), "region")
+	assert.Equal(t, types.StringValue("stale-123"), state.Outputs.Elements()["resource_id"])
+}
+
+// TestDeleteAfterDisable tests that on_destroy still runs for a request disabled after it executed
+func TestDeleteAfterDisable(t *testing.T) {
+	ctx := context.Background()
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	nullAttributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		nullAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	onDestroyType := objectType.AttributeTypes["on_destroy"].(tftypes.Object)
+	onDestroyAttributes := make(map[string]tftypes.Value, len(onDestroyType.AttributeTypes))
+	for name, attrType := range onDestroyType.AttributeTypes {
+		onDestroyAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	onDestroyAttributes["method"] = tftypes.NewValue(tftypes.String, "DELETE")
+	onDestroyAttributes["url"] = tftypes.NewValue(tftypes.String, server.URL+"/items/${self.outputs.id}")
+	nullAttributes["on_destroy"] = tftypes.NewValue(onDestroyType, onDestroyAttributes)
+	var model HttpxRequestResourceModel
+	assert.False(t, tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nullAttributes)}.Get(ctx, &model).HasError())
+
+	// State after a create that ran while enabled
+	model.Url = types.StringValue(server.URL + "/items")
+	model.Method = types.StringValue("POST")
+	model.Id = types.StringValue("abc123")
+	model.StatusCode = types.Int64Value(201)
+	model.LastResponseAt = types.StringValue("2026-03-01T12:00:00Z")
+	model.Outputs = types.MapValueMust(types.StringType, map[string]attr.Value{"id": types.StringValue("i-1")})
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	assert.False(t, state.Set(ctx, &model).HasError())
+
+	disabled := model
+	disabled.Enabled = types.BoolValue(false)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	assert.False(t, plan.Set(ctx, &disabled).HasError())
+
+	r := &HttpxRequestResource{config: &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}}
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, updateResp)
+	assert.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+	assert.Empty(t, deleted)
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), "%v", deleteResp.Diagnostics)
+	assert.Equal(t, []string{"/items/i-1"}, deleted)
+
+	// A request created while disabled never ran, so on_destroy is skipped
+	deleted = nil
+	neverRan := disabled
+	setDisabledComputedValues(&neverRan)
+	assert.False(t, state.Set(ctx, &neverRan).HasError())
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resource.DeleteResponse{State: state})
+	assert.Empty(t, deleted)
+}
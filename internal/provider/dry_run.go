@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logDryRunRequest logs the request that would have been sent when the provider runs with dry_run
+func logDryRunRequest(ctx context.Context, httpReq *http.Request, providerConfig *ProviderConfig) {
+	tflog.Info(ctx, "Dry run enabled, skipping request", map[string]interface{}{
+		"method":  httpReq.Method,
+		"url":     providerConfig.redact(httpReq.URL.String()),
+		"request": renderRequestSummary(httpReq, providerConfig.redaction()),
+	})
+}
+
+// addDryRunNotSentError fails a resource operation whose request was only logged. Saving state
+// (or dropping it on delete) would make Terraform believe the remote object was created, updated
+// or deleted, so the real request would never be sent once dry_run is turned off.
+func addDryRunNotSentError(diags *diag.Diagnostics, operation string) {
+	diags.AddError("dry_run: request not sent",
+		fmt.Sprintf("The provider runs with dry_run, so the %s request was only logged and state was left unchanged. Turn off dry_run to send it.", operation))
+}
+
+// setDryRunDataSourceComputedValues sets computed attributes to null markers when no request was sent
+func setDryRunDataSourceComputedValues(model *HttpxRequestDataSourceModel) {
+	model.StatusCode = types.Int64Null()
+	model.StatusText = types.StringNull()
+	model.Protocol = types.StringNull()
+	model.RemoteAddr = types.StringNull()
+	model.TlsVersion = types.StringNull()
+	model.TlsCipherSuite = types.StringNull()
+	model.PeerCertSha256 = types.StringNull()
+	model.ResponseHeaders = types.MapNull(types.StringType)
+	model.ResponseCookies = types.MapNull(types.ObjectType{AttrTypes: responseCookieAttrTypes})
+	model.ResponseLinks = types.MapNull(types.StringType)
+	model.EffectiveUrl = types.StringNull()
+	model.RedirectChain = types.ListNull(types.ObjectType{AttrTypes: redirectHopAttrTypes})
+	model.ResponseBody = types.StringNull()
+	model.ResponseBodyJson = types.DynamicNull()
+	model.ResponseBodyFileSha256 = types.StringNull()
+	model.Outputs = types.MapNull(types.StringType)
+	model.OutputsLists = types.MapNull(types.ListType{ElemType: types.StringType})
+	model.OutputsJson = types.StringNull()
+	model.LastAttemptCount = types.Int64Value(0)
+	model.LastError = types.StringNull()
+	model.AttemptHistory = types.ListNull(types.ObjectType{AttrTypes: attemptHistoryAttrTypes})
+	model.Transcript = types.StringNull()
+	model.Nonce = types.StringNull()
+	model.RequestHeadersSent = types.MapNull(types.StringType)
+	model.LastResponseAt = types.StringNull()
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderRequestSummary(t *testing.T) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, "https://api.example.com/items/1?sig=abc&v=2", strings.NewReader("abc"))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-Api-Key", "key")
+	req.Header.Set("Content-Type", "text/plain")
+
+	summary := renderRequestSummary(req, utils.Redaction{Headers: []string{"x-api-key"}, QueryParams: []string{"sig"}})
+	assert.Equal(t, strings.Join([]string{
+		"PUT https://api.example.com/items/1?sig=[REDACTED]&v=2",
+		"Authorization: [REDACTED]",
+		"Content-Type: text/plain",
+		"X-Api-Key: [REDACTED]",
+		"Body: 3 bytes",
+	}, "\n"), summary)
+
+	// Logging must not consume the request
+	logDryRunRequest(context.Background(), req, &ProviderConfig{})
+	assert.Equal(t, int64(3), req.ContentLength)
+}
+
+func TestSetDryRunDataSourceComputedValues(t *testing.T) {
+	model := &HttpxRequestDataSourceModel{
+		StatusCode:   types.Int64Value(200),
+		ResponseBody: types.StringValue(`{"id": "123"}`),
+		LastError:    types.StringValue("previous error"),
+		Outputs: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"id": types.StringValue("123"),
+		}),
+		LastResponseAt: types.StringValue("2026-01-01T00:00:00Z"),
+	}
+
+	setDryRunDataSourceComputedValues(model)
+
+	assert.True(t, model.StatusCode.IsNull())
+	assert.True(t, model.ResponseBody.IsNull())
+	assert.True(t, model.ResponseBodyJson.IsNull())
+	assert.True(t, model.ResponseHeaders.IsNull())
+	assert.True(t, model.Outputs.IsNull())
+	assert.True(t, model.OutputsLists.IsNull())
+	assert.True(t, model.LastError.IsNull())
+	assert.True(t, model.LastResponseAt.IsNull())
+	assert.Equal(t, int64(0), model.LastAttemptCount.ValueInt64())
+}
+
+func TestDryRunCreateLeavesStateEmpty(t *testing.T) {
+	ctx := context.Background()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024, DryRun: true}
+
+	// Saving state would make Terraform skip the real request once dry_run is turned off
+	for _, r := range []resource.Resource{&HttpxRequestResource{config: providerConfig}, &HttpxBatchResource{config: providerConfig}} {
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+		set := map[string]tftypes.Value{"url": tftypes.NewValue(tftypes.String, server.URL)}
+		if requestType, ok := objectType.AttributeTypes["request"].(tftypes.List); ok {
+			spec := nullObject(requestType.ElementType.(tftypes.Object), map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "first"),
+				"url":  tftypes.NewValue(tftypes.String, server.URL),
+			})
+			set = map[string]tftypes.Value{"request": tftypes.NewValue(requestType, []tftypes.Value{spec})}
+		}
+
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+		r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: nullObject(objectType, set)}}, &resp)
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "dry_run: request not sent", resp.Diagnostics.Errors()[0].Summary())
+		assert.True(t, resp.State.Raw.IsNull())
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+}
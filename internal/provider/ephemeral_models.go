@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// HttpxRequestEphemeralResourceModel represents the httpx_request ephemeral resource result
+type HttpxRequestEphemeralResourceModel struct {
+	Url             types.String                 `tfsdk:"url"`
+	Method          types.String                 `tfsdk:"method"`
+	Headers         types.Map                    `tfsdk:"headers"`
+	Query           types.Map                    `tfsdk:"query"`
+	Body            types.String                 `tfsdk:"body"`
+	BearerToken     types.String                 `tfsdk:"bearer_token"`
+	ExtractBlocks   []EphemeralExtractBlockModel `tfsdk:"extract"`
+	StatusCode      types.Int64                  `tfsdk:"status_code"`
+	ResponseBody    types.String                 `tfsdk:"response_body"`
+	ResponseHeaders types.Map                    `tfsdk:"response_headers"`
+	Outputs         types.Map                    `tfsdk:"outputs"`
+}
+
+// EphemeralExtractBlockModel represents an extract block of the ephemeral resource, which has no
+// for_each_path since there are no outputs_lists to fill
+type EphemeralExtractBlockModel struct {
+	Name     types.String `tfsdk:"name"`
+	JsonPath types.String `tfsdk:"json_path"`
+	Jq       types.String `tfsdk:"jq"`
+	Header   types.String `tfsdk:"header"`
+	Cookie   types.String `tfsdk:"cookie"`
+	LinkRel  types.String `tfsdk:"link_rel"`
+}
+
+// toExtractBlocks converts ephemeral extract blocks for extractValues
+func toExtractBlocks(blocks []EphemeralExtractBlockModel) []ExtractBlockModel {
+	converted := make([]ExtractBlockModel, len(blocks))
+	for i, block := range blocks {
+		converted[i] = ExtractBlockModel{
+			Name:        block.Name,
+			JsonPath:    block.JsonPath,
+			ForEachPath: types.StringNull(),
+			Jq:          block.Jq,
+			Header:      block.Header,
+			Cookie:      block.Cookie,
+			LinkRel:     block.LinkRel,
+		}
+	}
+	return converted
+}
@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &HttpxRequestEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &HttpxRequestEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &HttpxRequestEphemeralResource{}
+
+// HttpxRequestEphemeralResource executes a request whose results are never stored in the plan or
+// state, such as a short-lived token fetched to configure another provider. Terraform opens it
+// again in every run that references it.
+type HttpxRequestEphemeralResource struct {
+	config *ProviderConfig
+}
+
+func NewHttpxRequestEphemeralResource() ephemeral.EphemeralResource {
+	return &HttpxRequestEphemeralResource{}
+}
+
+func (e *HttpxRequestEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_request"
+}
+
+func (e *HttpxRequestEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ephemeral resource that executes a request and exposes values that are never stored in the plan or state, e.g. a short-lived token for another provider's configuration: `token = ephemeral.httpx_request.k8s.outputs.token`",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Required:    true,
+				Description: "The URL to send the request to",
+			},
+			"method": schema.StringAttribute{
+				Optional:    true,
+				Description: "HTTP method (GET, POST, PUT, PATCH, DELETE, etc.; default: GET)",
+			},
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Request headers as a map",
+			},
+			"query": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Query parameters",
+			},
+			"body": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Raw request body",
+			},
+			"bearer_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Bearer token for authentication",
+			},
+			"status_code": schema.Int64Attribute{
+				Computed:    true,
+				Description: "HTTP status code of the response",
+			},
+			"response_body": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Response body",
+			},
+			"response_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Response headers",
+			},
+			"outputs": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Values extracted from the response. Every extract block must produce a value.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"extract": schema.ListNestedBlock{
+				Description: "Extract values from response",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Name of the extracted value",
+						},
+						"json_path": schema.StringAttribute{
+							Optional:    true,
+							Description: "JSON path to extract from",
+						},
+						"jq": schema.StringAttribute{
+							Optional:    true,
+							Description: "jq expression evaluated against the JSON body instead of json_path",
+						},
+						"header": schema.StringAttribute{
+							Optional:    true,
+							Description: "Header name to extract from",
+						},
+						"cookie": schema.StringAttribute{
+							Optional:    true,
+							Description: "Cookie name to extract the value of from Set-Cookie response headers",
+						},
+						"link_rel": schema.StringAttribute{
+							Optional:    true,
+							Description: "Link header relation type to extract the target URL of, e.g. \"next\"",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (e *HttpxRequestEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ProviderConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected EphemeralResource Configure Type",
+			"Expected *ProviderConfig, got something else",
+		)
+		return
+	}
+
+	e.config = config
+}
+
+func (e *HttpxRequestEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateExtractBlocks(ctx, req.Config, path.Root("extract"))...)
+}
+
+func (e *HttpxRequestEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model HttpxRequestEphemeralResourceModel
+
+	// Read Terraform configuration into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	headers, err := ConvertTerraformMap(ctx, model.Headers)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid headers", err.Error())
+		return
+	}
+	query, err := ConvertTerraformMap(ctx, model.Query)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid query", err.Error())
+		return
+	}
+
+	httpReq, err := BuildRequest(ctx, &RequestConfig{
+		Url:              model.Url.ValueString(),
+		Method:           model.Method.ValueString(),
+		Headers:          headers,
+		Query:            query,
+		Body:             model.Body,
+		BearerToken:      model.BearerToken,
+		ProviderDefaults: e.config,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build request", err.Error())
+		return
+	}
+
+	reqConfig := e.config.WithAuditSource("ephemeral.httpx_request", "open")
+
+	// In dry-run mode the request is only logged
+	if reqConfig.DryRun {
+		logDryRunRequest(ctx, httpReq, reqConfig)
+		model.StatusCode = types.Int64Null()
+		model.ResponseBody = types.StringNull()
+		model.ResponseHeaders = types.MapNull(types.StringType)
+		model.Outputs = types.MapNull(types.StringType)
+		resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+		return
+	}
+
+	result, err := ExecuteRequestWithRetry(ctx, httpReq, reqConfig, nil, nil, nil)
+	if err != nil {
+		notifyFailure(ctx, httpReq, reqConfig, result, err)
+		resp.Diagnostics.AddError("Request failed", err.Error())
+		return
+	}
+	if err := setEphemeralResultValues(ctx, &model, result); err != nil {
+		resp.Diagnostics.AddError("Request failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+// setEphemeralResultValues fills the computed attributes from result. A non-2xx response or an
+// extract block without a value is an error, since the values usually configure another provider
+// that would otherwise fail later with a less helpful message.
+func setEphemeralResultValues(ctx context.Context, model *HttpxRequestEphemeralResourceModel, result *ResponseResult) error {
+	if result.StatusCode < 200 || result.StatusCode > 299 {
+		return fmt.Errorf("received status %d", result.StatusCode)
+	}
+
+	extractedOutputs, extractFailures := extractValues(ctx, result, toExtractBlocks(model.ExtractBlocks))
+	if len(extractFailures) > 0 {
+		return fmt.Errorf("extraction failed: %s", strings.Join(extractFailures, "; "))
+	}
+	outputsMap := make(map[string]attr.Value, len(extractedOutputs))
+	for k, v := range extractedOutputs {
+		outputsMap[k] = types.StringValue(v)
+	}
+
+	responseHeaders, err := ResponseHeadersValue(ctx, result.Headers, types.ListNull(types.StringType))
+	if err != nil {
+		return err
+	}
+
+	model.StatusCode = types.Int64Value(result.StatusCode)
+	model.ResponseBody = types.StringValue(result.Body)
+	model.ResponseHeaders = responseHeaders
+	model.Outputs = types.MapValueMust(types.StringType, outputsMap)
+	return nil
+}
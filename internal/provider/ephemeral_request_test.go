@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEphemeralResultValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/denied" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":{"token":"short-lived"}}`))
+	}))
+	defer server.Close()
+
+	get := func(path string) *ResponseResult {
+		httpReq, err := BuildRequest(context.Background(), &RequestConfig{Url: server.URL + path})
+		assert.NoError(t, err)
+		result, err := ExecuteRequestWithRetry(context.Background(), httpReq, &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}, nil, nil, nil)
+		assert.NoError(t, err)
+		return result
+	}
+	extract := func(name, jsonPath string) EphemeralExtractBlockModel {
+		return EphemeralExtractBlockModel{Name: types.StringValue(name), JsonPath: types.StringValue(jsonPath)}
+	}
+
+	model := HttpxRequestEphemeralResourceModel{ExtractBlocks: []EphemeralExtractBlockModel{extract("token", "status.token")}}
+	assert.NoError(t, setEphemeralResultValues(context.Background(), &model, get("/token")))
+	assert.Equal(t, int64(200), model.StatusCode.ValueInt64())
+	assert.Equal(t, types.StringValue("short-lived"), model.Outputs.Elements()["token"])
+	assert.Equal(t, types.StringValue("application/json"), model.ResponseHeaders.Elements()["Content-Type"])
+
+	// An unextractable value would misconfigure the provider it feeds, so it is an error
+	model = HttpxRequestEphemeralResourceModel{ExtractBlocks: []EphemeralExtractBlockModel{extract("token", "status.missing")}}
+	assert.ErrorContains(t, setEphemeralResultValues(context.Background(), &model, get("/token")), "extraction failed")
+
+	model = HttpxRequestEphemeralResourceModel{}
+	assert.ErrorContains(t, setEphemeralResultValues(context.Background(), &model, get("/denied")), "received status 403")
+}
+
+func TestEphemeralResourceSchema(t *testing.T) {
+	var resp ephemeral.SchemaResponse
+	NewHttpxRequestEphemeralResource().Schema(context.Background(), ephemeral.SchemaRequest{}, &resp)
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.False(t, resp.Schema.ValidateImplementation(context.Background()).HasError())
+	assert.True(t, resp.Schema.Attributes["outputs"].IsSensitive())
+
+	// Without outputs_lists there is nowhere for for_each_path results to go
+	objectType := resp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	extractType := objectType.AttributeTypes["extract"].(tftypes.List)
+	blockType := extractType.ElementType.(tftypes.Object)
+	assert.NotContains(t, blockType.AttributeTypes, "for_each_path")
+
+	// Conflicting sources are still rejected for the ephemeral extract blocks
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	blockAttributes := make(map[string]tftypes.Value, len(blockType.AttributeTypes))
+	for name, attrType := range blockType.AttributeTypes {
+		blockAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	blockAttributes["name"] = tftypes.NewValue(tftypes.String, "token")
+	blockAttributes["json_path"] = tftypes.NewValue(tftypes.String, "status.token")
+	blockAttributes["header"] = tftypes.NewValue(tftypes.String, "X-Token")
+	attributes["extract"] = tftypes.NewValue(extractType, []tftypes.Value{tftypes.NewValue(blockType, blockAttributes)})
+	validateResp := &ephemeral.ValidateConfigResponse{}
+	e := &HttpxRequestEphemeralResource{}
+	e.ValidateConfig(context.Background(), ephemeral.ValidateConfigRequest{Config: tfsdk.Config{Schema: resp.Schema, Raw: tftypes.NewValue(objectType, attributes)}}, validateResp)
+	assert.Equal(t, 1, validateResp.Diagnostics.ErrorsCount())
+	assert.Contains(t, validateResp.Diagnostics.Errors()[0].Detail(), "json_path and header are set")
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// errorResponseBodyLimit caps the body kept in error_response_body
+const errorResponseBodyLimit = 4096
+
+// errorResponseBodyValue returns the redacted and truncated final response body of a failed
+// request, encrypted when state_encryption_key is set, or null when no response was received
+func errorResponseBodyValue(result *ResponseResult, config *ProviderConfig) types.String {
+	if result == nil || result.StatusCode == 0 {
+		return types.StringNull()
+	}
+	value := types.StringValue(utils.TruncateString(config.redact(result.Body), errorResponseBodyLimit))
+	if config != nil && config.StateCipher != nil {
+		return config.StateCipher.sealString(value)
+	}
+	return value
+}
+
+// saveFailedCreate keeps a create that received a response but failed in state, so Terraform
+// marks it tainted and error_response_body can be inspected. The ID stays null, which makes
+// Read and Delete treat the request as never having succeeded.
+func saveFailedCreate(ctx context.Context, resp *resource.CreateResponse, model HttpxRequestResourceModel, result *ResponseResult, config *ProviderConfig) {
+	if result == nil || result.StatusCode == 0 {
+		return
+	}
+
+	setDisabledComputedValues(&model)
+	model.Id = types.StringNull()
+	model.CreatedAt = types.StringNull()
+	model.StatusCode = types.Int64Value(result.StatusCode)
+	model.LastAttemptCount = types.Int64Value(result.AttemptCount)
+	if result.Error != "" {
+		model.LastError = types.StringValue(config.redact(result.Error))
+	}
+	model.ErrorResponseBody = errorResponseBodyValue(result, config)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorResponseBodyValue(t *testing.T) {
+	config := &ProviderConfig{RedactHeaders: []string{"Authorization"}}
+
+	assert.True(t, errorResponseBodyValue(nil, config).IsNull())
+	assert.True(t, errorResponseBodyValue(&ResponseResult{Error: "connection refused"}, config).IsNull())
+
+	value := errorResponseBodyValue(&ResponseResult{StatusCode: 500, Body: "echo: Authorization: Bearer secret"}, config)
+	assert.NotContains(t, value.ValueString(), "secret")
+
+	long := errorResponseBodyValue(&ResponseResult{StatusCode: 500, Body: strings.Repeat("x", errorResponseBodyLimit+1)}, config)
+	assert.Equal(t, strings.Repeat("x", errorResponseBodyLimit)+"... [TRUNCATED]", long.ValueString())
+}
+
+func TestSaveFailedCreate(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	nullAttributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		nullAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nullAttributes)}
+
+	// Computed attributes are unknown in the plan of a new resource
+	var model HttpxRequestResourceModel
+	assert.False(t, plan.Get(ctx, &model).HasError())
+	model.Url = types.StringValue("https://api.example.com/items")
+	model.Id = types.StringUnknown()
+	model.CreatedAt = types.StringUnknown()
+	model.StatusCode = types.Int64Unknown()
+	model.Outputs = types.MapUnknown(types.StringType)
+	model.ErrorResponseBody = types.StringUnknown()
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	saveFailedCreate(ctx, resp, model, nil, &ProviderConfig{})
+	assert.True(t, resp.State.Raw.IsNull(), "nothing is saved without a response")
+
+	saveFailedCreate(ctx, resp, model, &ResponseResult{StatusCode: 422, Body: `{"error": "invalid name"}`, AttemptCount: 2}, &ProviderConfig{})
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsFullyKnown())
+
+	var saved HttpxRequestResourceModel
+	assert.False(t, resp.State.Get(ctx, &saved).HasError())
+	assert.True(t, saved.Id.IsNull())
+	assert.Equal(t, "https://api.example.com/items", saved.Url.ValueString())
+	assert.Equal(t, int64(422), saved.StatusCode.ValueInt64())
+	assert.Equal(t, int64(2), saved.LastAttemptCount.ValueInt64())
+	assert.Equal(t, `{"error": "invalid name"}`, saved.ErrorResponseBody.ValueString())
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// extractSourceAttributes are the extract block attributes naming where a value comes from.
+// Extraction silently prefers one over another when several are set, so only one is allowed.
+var extractSourceAttributes = []string{"json_path", "jq", "header", "cookie", "link_rel"}
+
+// validateExtractBlocks rejects extract blocks at blocksPath that set more than one source
+func validateExtractBlocks(ctx context.Context, config tfsdk.Config, blocksPath path.Path) diag.Diagnostics {
+	// Extract blocks differ between schemas (the ephemeral resource has no for_each_path), so
+	// they are read as objects rather than into ExtractBlockModel
+	var blocks []types.Object
+	diags := config.GetAttribute(ctx, blocksPath, &blocks)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i, block := range blocks {
+		sources := block.Attributes()
+		var set []string
+		for _, name := range extractSourceAttributes {
+			if value, ok := sources[name].(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+				set = append(set, name)
+			}
+		}
+		if len(set) > 1 {
+			diags.AddAttributeError(blocksPath.AtListIndex(i).AtName(set[1]), "Conflicting extract sources",
+				"An extract block takes its value from exactly one of json_path, jq, header, cookie or link_rel, but "+
+					strings.Join(set, " and ")+" are set. Use a separate extract block for each value.")
+		}
+	}
+	return diags
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateExtractBlocks(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	extractType := objectType.AttributeTypes["extract"].(tftypes.List)
+	blockType := extractType.ElementType.(tftypes.Object)
+
+	config := func(blocks ...map[string]string) tfsdk.Config {
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attrType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attrType, nil)
+		}
+		var values []tftypes.Value
+		for _, block := range blocks {
+			blockAttributes := make(map[string]tftypes.Value, len(blockType.AttributeTypes))
+			for name, attrType := range blockType.AttributeTypes {
+				blockAttributes[name] = tftypes.NewValue(attrType, nil)
+			}
+			for name, value := range block {
+				blockAttributes[name] = tftypes.NewValue(tftypes.String, value)
+			}
+			values = append(values, tftypes.NewValue(blockType, blockAttributes))
+		}
+		attributes["extract"] = tftypes.NewValue(extractType, values)
+		return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+	}
+
+	valid := config(
+		map[string]string{"name": "id", "json_path": "data.id"},
+		map[string]string{"name": "ids", "json_path": "items", "for_each_path": "id"},
+		map[string]string{"name": "etag", "header": "ETag"},
+	)
+	assert.False(t, validateExtractBlocks(ctx, valid, path.Root("extract")).HasError())
+
+	// Without an on_destroy block its extract blocks are null
+	assert.False(t, validateExtractBlocks(ctx, valid, path.Root("on_destroy").AtName("extract")).HasError())
+
+	conflicting := config(
+		map[string]string{"name": "id", "json_path": "data.id"},
+		map[string]string{"name": "etag", "json_path": "data.etag", "header": "ETag"},
+	)
+	diags := validateExtractBlocks(ctx, conflicting, path.Root("extract"))
+	assert.Equal(t, 1, diags.ErrorsCount())
+	assert.Contains(t, diags.Errors()[0].Detail(), "json_path and header are set")
+}
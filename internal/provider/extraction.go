@@ -1,17 +1,31 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math/big"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // ExtractValues extracts values from response based on extract blocks
 func ExtractValues(ctx context.Context, result *ResponseResult, extractBlocks []ExtractBlockModel) (map[string]string, error) {
+	outputs, _ := extractValues(ctx, result, extractBlocks)
+	return outputs, nil
+}
+
+// extractValues is ExtractValues, also describing each extract block that did not resolve and
+// was set to ""
+func extractValues(ctx context.Context, result *ResponseResult, extractBlocks []ExtractBlockModel) (map[string]string, []string) {
 	outputs := make(map[string]string)
+	var failures []string
 
 	if len(extractBlocks) == 0 {
 		return outputs, nil
@@ -21,7 +35,8 @@ func ExtractValues(ctx context.Context, result *ResponseResult, extractBlocks []
 	var jsonData interface{}
 	hasJsonData := false
 	if result.Body != "" {
-		if err := json.Unmarshal([]byte(result.Body), &jsonData); err == nil {
+		if decoded, err := decodeJSON(result.Body); err == nil {
+			jsonData = decoded
 			hasJsonData = true
 		}
 	}
@@ -47,6 +62,7 @@ func ExtractValues(ctx context.Context, result *ResponseResult, extractBlocks []
 						"name": name,
 						"path": jsonPath,
 					})
+					failures = append(failures, fmt.Sprintf("%s: json_path %q: response body is not valid JSON", name, jsonPath))
 					outputs[name] = ""
 					continue
 				}
@@ -58,30 +74,31 @@ func ExtractValues(ctx context.Context, result *ResponseResult, extractBlocks []
 						"path":  jsonPath,
 						"error": extractErr.Error(),
 					})
+					failures = append(failures, fmt.Sprintf("%s: json_path %q: %v", name, jsonPath, extractErr))
 					outputs[name] = ""
 					continue
 				}
 
-				// Convert extracted value to string
-				// Handle different types appropriately
-				switch v := extractedValue.(type) {
-				case string:
-					value = v
-				case bool:
-					value = fmt.Sprintf("%t", v)
-				case float64:
-					// JSON numbers are float64
-					value = fmt.Sprintf("%g", v)
-				case nil:
-					value = ""
-				default:
-					// For complex types, marshal to JSON string
-					if jsonBytes, marshalErr := json.Marshal(v); marshalErr == nil {
-						value = string(jsonBytes)
-					} else {
-						value = fmt.Sprintf("%v", v)
-					}
+				value = formatExtractedValue(extractedValue)
+			}
+		}
+
+		// Extract with jq (takes precedence over json_path)
+		if !extract.Jq.IsNull() && !extract.Jq.IsUnknown() {
+			expr := extract.Jq.ValueString()
+			if expr != "" {
+				extractedValue, jqErr := evaluateJqValue(ctx, result.Body, expr)
+				if jqErr != nil {
+					tflog.Debug(ctx, "Failed to extract with jq", map[string]interface{}{
+						"name":  name,
+						"jq":    expr,
+						"error": jqErr.Error(),
+					})
+					failures = append(failures, fmt.Sprintf("%s: jq %q: %v", name, expr, jqErr))
+					outputs[name] = ""
+					continue
 				}
+				value = formatExtractedValue(extractedValue)
 			}
 		}
 
@@ -102,6 +119,41 @@ func ExtractValues(ctx context.Context, result *ResponseResult, extractBlocks []
 						"name":        name,
 						"header_name": headerName,
 					})
+					failures = append(failures, fmt.Sprintf("%s: header %q not found", name, headerName))
+					value = ""
+				}
+			}
+		}
+
+		// Extract from cookie (takes precedence over json_path and header)
+		if !extract.Cookie.IsNull() && !extract.Cookie.IsUnknown() {
+			cookieName := extract.Cookie.ValueString()
+			if cookieName != "" {
+				if cookie, found := findResponseCookie(result.Cookies, cookieName); found {
+					value = cookie.Value
+				} else {
+					tflog.Debug(ctx, "Cookie not found for extraction", map[string]interface{}{
+						"name":        name,
+						"cookie_name": cookieName,
+					})
+					failures = append(failures, fmt.Sprintf("%s: cookie %q not found", name, cookieName))
+					value = ""
+				}
+			}
+		}
+
+		// Extract a Link header target (takes precedence over json_path, header and cookie)
+		if !extract.LinkRel.IsNull() && !extract.LinkRel.IsUnknown() {
+			rel := extract.LinkRel.ValueString()
+			if rel != "" {
+				if target, found := responseLinks(result)[strings.ToLower(rel)]; found {
+					value = target
+				} else {
+					tflog.Debug(ctx, "Link relation not found for extraction", map[string]interface{}{
+						"name":     name,
+						"link_rel": rel,
+					})
+					failures = append(failures, fmt.Sprintf("%s: link_rel %q not found", name, rel))
 					value = ""
 				}
 			}
@@ -114,5 +166,153 @@ func ExtractValues(ctx context.Context, result *ResponseResult, extractBlocks []
 		})
 	}
 
-	return outputs, nil
+	return outputs, failures
+}
+
+// reportExtractFailures reports extract blocks that did not resolve as an error when
+// fail_on_extract_error is set and as a warning otherwise. It returns true for an error.
+func reportExtractFailures(diags *diag.Diagnostics, failOnExtractError types.Bool, failures []string) bool {
+	if len(failures) == 0 {
+		return false
+	}
+	if failOnExtractError.ValueBool() {
+		diags.AddError("Extraction failed", fmt.Sprintf("Some values could not be extracted (fail_on_extract_error is set):\n%s", strings.Join(failures, "\n")))
+		return true
+	}
+	diags.AddWarning("Extraction warnings", fmt.Sprintf("Some values could not be extracted and were set to \"\": %s", strings.Join(failures, "; ")))
+	return false
+}
+
+// ExtractListValues extracts arrays from the response for extract blocks whose json_path
+// (or jq) resolves to an array or that set for_each_path. With for_each_path, the path is evaluated
+// against each array element and elements where it does not resolve are skipped.
+func ExtractListValues(ctx context.Context, result *ResponseResult, extractBlocks []ExtractBlockModel) map[string][]string {
+	lists := make(map[string][]string)
+
+	if len(extractBlocks) == 0 || result.Body == "" {
+		return lists
+	}
+
+	jsonData, err := decodeJSON(result.Body)
+	if err != nil {
+		return lists
+	}
+
+	for _, extract := range extractBlocks {
+		if extract.Name.IsNull() || extract.Name.IsUnknown() || extract.Name.ValueString() == "" {
+			continue
+		}
+		name := extract.Name.ValueString()
+
+		jsonPath := ""
+		if !extract.JsonPath.IsNull() && !extract.JsonPath.IsUnknown() {
+			jsonPath = extract.JsonPath.ValueString()
+		}
+		jqExpr := ""
+		if !extract.Jq.IsNull() && !extract.Jq.IsUnknown() {
+			jqExpr = extract.Jq.ValueString()
+		}
+		forEachPath := ""
+		if !extract.ForEachPath.IsNull() && !extract.ForEachPath.IsUnknown() {
+			forEachPath = extract.ForEachPath.ValueString()
+		}
+		if jsonPath == "" && jqExpr == "" && forEachPath == "" {
+			continue
+		}
+
+		var value interface{}
+		var err error
+		if jqExpr != "" {
+			value, err = evaluateJqValue(ctx, result.Body, jqExpr)
+		} else {
+			value, err = evaluateJsonPath(jsonData, jsonPath)
+		}
+		if err != nil {
+			continue
+		}
+		arr, ok := value.([]interface{})
+		if !ok {
+			if forEachPath != "" {
+				tflog.Debug(ctx, "Cannot apply for_each_path, value is not an array", map[string]interface{}{
+					"name": name,
+					"path": jsonPath,
+				})
+			}
+			continue
+		}
+
+		items := make([]string, 0, len(arr))
+		for i, element := range arr {
+			if forEachPath != "" {
+				element, err = evaluateJsonPath(element, forEachPath)
+				if err != nil {
+					tflog.Debug(ctx, "Skipping array element without for_each_path", map[string]interface{}{
+						"name":  name,
+						"index": i,
+						"error": err.Error(),
+					})
+					continue
+				}
+			}
+			items = append(items, formatExtractedValue(element))
+		}
+		lists[name] = items
+	}
+
+	return lists
+}
+
+// formatExtractedValue converts a value extracted from JSON to its string form
+func formatExtractedValue(extractedValue interface{}) string {
+	// Handle different types appropriately
+	switch v := extractedValue.(type) {
+	case string:
+		return v
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case json.Number:
+		// Numbers are decoded as json.Number to keep 64-bit IDs intact
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		// For complex types, marshal to JSON string
+		if jsonBytes, marshalErr := json.Marshal(v); marshalErr == nil {
+			return string(jsonBytes)
+		}
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// decodeJSON parses a JSON document, keeping numbers as json.Number so large integers
+// are not rounded through float64
+func decodeJSON(data string) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(data)))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+	return value, nil
+}
+
+// jsonValuesEqual compares two decoded JSON values; numbers are compared by value
+// so that 1, 1.0 and 1e0 are equal
+func jsonValuesEqual(a, b interface{}) bool {
+	aNum, aIsNum := a.(json.Number)
+	bNum, bIsNum := b.(json.Number)
+	if aIsNum && bIsNum {
+		aRat, aOk := new(big.Rat).SetString(aNum.String())
+		bRat, bOk := new(big.Rat).SetString(bNum.String())
+		if aOk && bOk {
+			return aRat.Cmp(bRat) == 0
+		}
+	}
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
 }
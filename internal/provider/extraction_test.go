@@ -2,8 +2,10 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -153,6 +155,66 @@ func TestExtractValues(t *testing.T) {
 			want:          map[string]string{},
 			wantErr:       false,
 		},
+		{
+			name: "extract cookie",
+			result: &ResponseResult{
+				Body: `{}`,
+				Headers: map[string]string{
+					"Set-Cookie": "session=old; Path=/, session=abc123; Path=/; HttpOnly, theme=dark",
+				},
+				Cookies: []*http.Cookie{
+					{Name: "session", Value: "old", Path: "/"},
+					{Name: "session", Value: "abc123", Path: "/", HttpOnly: true},
+					{Name: "theme", Value: "dark"},
+				},
+			},
+			extractBlocks: []ExtractBlockModel{
+				{
+					Name:   types.StringValue("session"),
+					Cookie: types.StringValue("session"),
+				},
+				{
+					Name:   types.StringValue("missing"),
+					Cookie: types.StringValue("csrf"),
+				},
+			},
+			want: map[string]string{
+				"session": "abc123",
+				"missing": "",
+			},
+			wantErr: false,
+		},
+		{
+			name: "extract large integer without precision loss",
+			result: &ResponseResult{
+				Body: `{"id": 12345678901234567890, "ratio": 0.5, "nested": {"ids": [9007199254740993]}}`,
+			},
+			extractBlocks: []ExtractBlockModel{
+				{
+					Name:     types.StringValue("id"),
+					JsonPath: types.StringValue("id"),
+				},
+				{
+					Name:     types.StringValue("ratio"),
+					JsonPath: types.StringValue("ratio"),
+				},
+				{
+					Name:     types.StringValue("nested"),
+					JsonPath: types.StringValue("nested"),
+				},
+				{
+					Name: types.StringValue("jq_id"),
+					Jq:   types.StringValue(".nested.ids[0]"),
+				},
+			},
+			want: map[string]string{
+				"id":     "12345678901234567890",
+				"ratio":  "0.5",
+				"nested": `{"ids":[9007199254740993]}`,
+				"jq_id":  "9007199254740993",
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -177,3 +239,106 @@ func TestExtractValues(t *testing.T) {
 	}
 }
 
+
+func TestExtractListValues(t *testing.T) {
+	result := &ResponseResult{
+		Body: `{"items": [{"id": "a", "n": 1}, {"id": "b", "n": 2}, {"n": 3}], "tags": ["x", "y"], "name": "test"}`,
+	}
+
+	extractBlocks := []ExtractBlockModel{
+		{Name: types.StringValue("tags"), JsonPath: types.StringValue("tags")},
+		{Name: types.StringValue("ids"), JsonPath: types.StringValue("items"), ForEachPath: types.StringValue("id")},
+		{Name: types.StringValue("counts"), JsonPath: types.StringValue("items"), ForEachPath: types.StringValue("n")},
+		{Name: types.StringValue("items"), JsonPath: types.StringValue("items[0]")},
+		{Name: types.StringValue("name"), JsonPath: types.StringValue("name")},
+		{Name: types.StringValue("header"), Header: types.StringValue("X-Request-ID")},
+	}
+
+	got := ExtractListValues(context.Background(), result, extractBlocks)
+	want := map[string][]string{
+		"tags":   {"x", "y"},
+		"ids":    {"a", "b"},
+		"counts": {"1", "2", "3"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("ExtractListValues() = %v, want %v", got, want)
+	}
+	for name, values := range want {
+		if len(got[name]) != len(values) {
+			t.Errorf("ExtractListValues() [%s] = %v, want %v", name, got[name], values)
+			continue
+		}
+		for i := range values {
+			if got[name][i] != values[i] {
+				t.Errorf("ExtractListValues() [%s] = %v, want %v", name, got[name], values)
+			}
+		}
+	}
+
+	// for_each_path without json_path applies to a root array
+	rootResult := &ResponseResult{Body: `[{"id": "1"}, {"id": "2"}]`}
+	rootLists := ExtractListValues(context.Background(), rootResult, []ExtractBlockModel{
+		{Name: types.StringValue("ids"), ForEachPath: types.StringValue("id")},
+	})
+	if len(rootLists["ids"]) != 2 || rootLists["ids"][0] != "1" || rootLists["ids"][1] != "2" {
+		t.Errorf("ExtractListValues() root array = %v, want [1 2]", rootLists["ids"])
+	}
+
+	// Non-JSON bodies produce no lists
+	if lists := ExtractListValues(context.Background(), &ResponseResult{Body: "not json"}, extractBlocks); len(lists) != 0 {
+		t.Errorf("ExtractListValues() with non-JSON body = %v, want empty", lists)
+	}
+}
+
+func TestExtractValuesFailures(t *testing.T) {
+	result := &ResponseResult{
+		Body:    `{"id": "123"}`,
+		Headers: map[string]string{"X-Request-Id": "abc"},
+	}
+	blocks := []ExtractBlockModel{
+		{Name: types.StringValue("id"), JsonPath: types.StringValue("id")},
+		{Name: types.StringValue("missing"), JsonPath: types.StringValue("data.missing")},
+		{Name: types.StringValue("request_id"), Header: types.StringValue("X-Request-Id")},
+		{Name: types.StringValue("etag"), Header: types.StringValue("ETag")},
+	}
+
+	outputs, failures := extractValues(context.Background(), result, blocks)
+	if outputs["id"] != "123" || outputs["request_id"] != "abc" {
+		t.Errorf("extractValues() outputs = %v", outputs)
+	}
+	if outputs["missing"] != "" || outputs["etag"] != "" {
+		t.Errorf("extractValues() unresolved outputs = %v, want empty strings", outputs)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("extractValues() failures = %v, want 2", failures)
+	}
+	if failures[1] != `etag: header "ETag" not found` {
+		t.Errorf("extractValues() failures[1] = %q", failures[1])
+	}
+}
+
+func TestReportExtractFailures(t *testing.T) {
+	failures := []string{`etag: header "ETag" not found`}
+
+	var diags diag.Diagnostics
+	if reportExtractFailures(&diags, types.BoolNull(), failures) {
+		t.Error("reportExtractFailures() = true without fail_on_extract_error")
+	}
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("reportExtractFailures() diags = %v, want one warning", diags)
+	}
+
+	diags = nil
+	if !reportExtractFailures(&diags, types.BoolValue(true), failures) {
+		t.Error("reportExtractFailures() = false with fail_on_extract_error")
+	}
+	if !diags.HasError() {
+		t.Errorf("reportExtractFailures() diags = %v, want an error", diags)
+	}
+
+	diags = nil
+	if reportExtractFailures(&diags, types.BoolValue(true), nil) || len(diags) != 0 {
+		t.Errorf("reportExtractFailures() without failures added %v", diags)
+	}
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// failureWebhookTimeout bounds the notification so a slow webhook can't stall the apply
+const failureWebhookTimeout = 10 * time.Second
+
+// failureNotification is the JSON document posted to on_failure_webhook. Text carries a one-line
+// summary so the document can be sent to Slack incoming webhooks as is.
+type failureNotification struct {
+	Text       string `json:"text"`
+	Timestamp  string `json:"timestamp"`
+	Resource   string `json:"resource,omitempty"`
+	Operation  string `json:"operation,omitempty"`
+	Method     string `json:"method"`
+	URL        string `json:"url"`
+	StatusCode int64  `json:"status_code"`
+	Error      string `json:"error"`
+	Attempts   int64  `json:"attempts"`
+}
+
+// validateFailureWebhook checks the on_failure_webhook URL
+func validateFailureWebhook(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("on_failure_webhook must be an absolute http or https URL, got %q", value)
+	}
+	return nil
+}
+
+// notifyFailure posts a summary of a request that ultimately failed to on_failure_webhook, if one
+// is configured. Callers invoke it once they have decided the request failed, i.e. after
+// treat_as_success, on_conflict and treat_status_as_success had a chance to accept the response.
+// URLs and errors are redacted the same way as in the audit log. Delivery failures are only
+// logged so they never mask the request error.
+func notifyFailure(ctx context.Context, req *http.Request, config *ProviderConfig, result *ResponseResult, requestErr error) {
+	if config == nil || config.FailureWebhook == "" {
+		return
+	}
+
+	attempts := int64(0)
+	if result != nil && len(result.AttemptHistory) > 0 {
+		attempts = result.AttemptHistory[len(result.AttemptHistory)-1].Attempt
+	}
+
+	notification := failureNotification{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Resource:  config.AuditResource,
+		Operation: config.AuditOperation,
+		Method:    req.Method,
+		URL:       config.redact(req.URL.String()),
+		Error:     config.redact(requestErr.Error()),
+		Attempts:  attempts,
+	}
+	if result != nil {
+		notification.StatusCode = result.StatusCode
+	}
+	source := "httpx request"
+	if notification.Resource != "" {
+		source = notification.Resource
+		if notification.Operation != "" {
+			source += " " + notification.Operation
+		}
+	}
+	notification.Text = fmt.Sprintf("%s failed after %d attempt(s): %s %s: %s",
+		source, attempts, notification.Method, notification.URL, notification.Error)
+
+	if err := postFailureNotification(ctx, config, notification); err != nil {
+		tflog.Warn(ctx, "Failed to send failure notification", map[string]interface{}{"error": config.redact(err.Error())})
+	}
+}
+
+// postFailureNotification sends notification, even when ctx was cancelled by the failure itself.
+// The webhook is reached through the same proxy, CA bundle and client certificate as the requests,
+// but is never recorded to or replayed from cassettes.
+func postFailureNotification(ctx context.Context, config *ProviderConfig, notification failureNotification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.ToConfigProviderConfig()
+	cfg.RecordMode = ""
+	httpClient, err := client.NewHTTPClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), failureWebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.FailureWebhook, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// The webhook URL is often a secret, so leave it out of the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
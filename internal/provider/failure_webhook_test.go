@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureWebhook(t *testing.T) {
+	var notifications []failureNotification
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification failureNotification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&notification))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		notifications = append(notifications, notification)
+	}))
+	defer webhook.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/conflict/deploy" {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	providerConfig := &ProviderConfig{
+		TimeoutMs:            5000,
+		MaxResponseBodyBytes: 1024,
+		RedactQueryParams:    []string{"api_key"},
+		FailureWebhook:       webhook.URL,
+	}
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	retryType := objectType.AttributeTypes["retry"].(tftypes.Object)
+	retryUntilType := objectType.AttributeTypes["retry_until"].(tftypes.Object)
+	treatType := objectType.AttributeTypes["treat_as_success"].(tftypes.Object)
+	statusCodes := func(codes ...int) tftypes.Value {
+		values := make([]tftypes.Value, len(codes))
+		for i, code := range codes {
+			values[i] = tftypes.NewValue(tftypes.Number, code)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, values)
+	}
+	// execute creates an httpx_request that POSTs to target, returning the create error
+	execute := func(target string, attributes map[string]tftypes.Value) error {
+		plan := map[string]tftypes.Value{
+			"url":    tftypes.NewValue(tftypes.String, target+"/deploy?api_key=secret"),
+			"method": tftypes.NewValue(tftypes.String, http.MethodGet),
+			"retry": nullObject(retryType, map[string]tftypes.Value{
+				"attempts":     tftypes.NewValue(tftypes.Number, 3),
+				"min_delay_ms": tftypes.NewValue(tftypes.Number, 1),
+				"max_delay_ms": tftypes.NewValue(tftypes.Number, 1),
+				"backoff":      tftypes.NewValue(tftypes.String, "fixed"),
+			}),
+		}
+		for name, value := range attributes {
+			plan[name] = value
+		}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+		(&HttpxRequestResource{config: providerConfig}).Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: nullObject(objectType, plan)}}, &resp)
+		if resp.Diagnostics.HasError() {
+			return errors.New(resp.Diagnostics.Errors()[0].Detail())
+		}
+		return nil
+	}
+
+	t.Run("notifies when the request fails", func(t *testing.T) {
+		notifications = nil
+		assert.Error(t, execute(closed.URL, nil))
+		if assert.Len(t, notifications, 1) {
+			notification := notifications[0]
+			assert.Equal(t, "httpx_request", notification.Resource)
+			assert.Equal(t, "create", notification.Operation)
+			assert.Equal(t, http.MethodGet, notification.Method)
+			assert.NotContains(t, notification.URL, "secret")
+			assert.Equal(t, int64(0), notification.StatusCode)
+			assert.Equal(t, int64(3), notification.Attempts)
+			assert.Contains(t, notification.Error, "connection refused")
+			assert.Contains(t, notification.Text, "httpx_request create failed after 3 attempt(s)")
+		}
+	})
+
+	t.Run("no notification on success", func(t *testing.T) {
+		notifications = nil
+		assert.NoError(t, execute(server.URL, nil))
+		assert.Empty(t, notifications)
+	})
+
+	t.Run("webhook errors don't change the request error", func(t *testing.T) {
+		providerConfig.FailureWebhook = "http://127.0.0.1:1/hook"
+		defer func() { providerConfig.FailureWebhook = webhook.URL }()
+		err := execute(closed.URL, nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+
+	t.Run("no notification for an accepted conflict", func(t *testing.T) {
+		// Polling for a 200 exhausts the attempts, then treat_as_success accepts the 409
+		notifications = nil
+		retryUntil := nullObject(retryUntilType, map[string]tftypes.Value{
+			"status_codes": statusCodes(200),
+			"interval_ms":  tftypes.NewValue(tftypes.Number, 1),
+		})
+		assert.NoError(t, execute(server.URL+"/conflict", map[string]tftypes.Value{
+			"retry_until":      retryUntil,
+			"treat_as_success": nullObject(treatType, map[string]tftypes.Value{"status_codes": statusCodes(409)}),
+		}))
+		assert.Empty(t, notifications)
+
+		// Without treat_as_success the same response is reported
+		assert.Error(t, execute(server.URL+"/conflict", map[string]tftypes.Value{"retry_until": retryUntil}))
+		assert.Len(t, notifications, 1)
+	})
+
+	t.Run("uses the provider proxy", func(t *testing.T) {
+		var proxied []string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = append(proxied, r.URL.String())
+		}))
+		defer proxy.Close()
+		providerConfig.FailureWebhook = "http://hooks.example.com/deploy-failed"
+		providerConfig.ProxyUrl = &proxy.URL
+		defer func() {
+			providerConfig.FailureWebhook = webhook.URL
+			providerConfig.ProxyUrl = nil
+		}()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, closed.URL+"/deploy", nil)
+		assert.NoError(t, err)
+		notifyFailure(context.Background(), req, providerConfig, nil, errors.New("connection refused"))
+		assert.Equal(t, []string{"http://hooks.example.com/deploy-failed"}, proxied)
+	})
+}
+
+func TestValidateFailureWebhook(t *testing.T) {
+	assert.NoError(t, validateFailureWebhook("https://hooks.slack.com/services/T000/B000/XXXX"))
+	assert.Error(t, validateFailureWebhook("hooks.slack.com/services"))
+	assert.Error(t, validateFailureWebhook("ftp://example.com/hook"))
+}
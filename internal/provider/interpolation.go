@@ -3,62 +3,176 @@ package provider
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/davidshato/terraform-provider-httpx/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// responseBodyExcerptBytes is the length of ${self.response_body_excerpt}
+const responseBodyExcerptBytes = 256
+
 // InterpolationContext holds state values available for template expansion
 type InterpolationContext struct {
-	ID           string            // self.id
-	Outputs      map[string]string // self.outputs.KEY
-	ResponseBody string            // self.response_body
-	StatusCode   int64             // self.status_code
+	ID              string            // self.id
+	Outputs         map[string]string // self.outputs.KEY
+	ResponseBody    string            // self.response_body
+	StatusCode      int64             // self.status_code
+	ResponseHeaders map[string]string // self.response_headers.NAME
+
+	// Set only when rendering expect.error_message
+	UnsatisfiedConditions []string // self.unsatisfied_conditions
+
+	// Set only when expanding depends_on_outputs
+	Shared map[string]map[string]string // shared.CONTEXT.OUTPUT
 }
 
-// InterpolateString replaces ${self.KEY} patterns with values from state context
+// InterpolateString replaces ${...} template expressions with values from state context
 // Supported patterns:
 //   - ${self.id}
 //   - ${self.outputs.KEY}
+//   - ${self.response_headers.NAME} (case-insensitive)
 //   - ${self.response_body}
+//   - ${self.response_body_excerpt} (first 256 bytes of the body)
 //   - ${self.status_code}
+//   - ${self.unsatisfied_conditions} (expect.error_message only)
+//   - ${shared.CONTEXT.OUTPUT} (depends_on_outputs only)
+//   - ${func(args...)} for the helper functions in template_functions.go
+//
+// Expressions are expanded in a single pass, so substituted values are never re-interpolated.
+// Expressions that are not recognized (e.g. ${var.name}) are left unchanged.
 func InterpolateString(ctx context.Context, text string, interpolCtx *InterpolationContext) (string, error) {
 	if text == "" || interpolCtx == nil {
 		return text, nil
 	}
 
-	result := text
-	var lastErr error
+	var result strings.Builder
+	rest := text
 
-	// Pattern: ${self.outputs.KEY}
-	outputsRegex := regexp.MustCompile(`\$\{self\.outputs\.([a-zA-Z0-9_]+)\}`)
-	result = outputsRegex.ReplaceAllStringFunc(result, func(match string) string {
-		submatches := outputsRegex.FindStringSubmatch(match)
-		if len(submatches) < 2 {
-			return match
+	for {
+		start := strings.Index(rest, "${")
+		if start == -1 {
+			result.WriteString(rest)
+			break
 		}
-		key := submatches[1]
-		if val, ok := interpolCtx.Outputs[key]; ok {
-			tflog.Trace(ctx, fmt.Sprintf("Interpolated ${self.outputs.%s} -> %s", key, val))
-			return val
+		result.WriteString(rest[:start])
+
+		end := findTemplateEnd(rest, start+2)
+		if end == -1 {
+			// Unterminated expression, keep the remainder as-is
+			result.WriteString(rest[start:])
+			break
+		}
+
+		match := rest[start : end+1]
+		expr := strings.TrimSpace(rest[start+2 : end])
+		value, handled, err := evaluateTemplateExpression(ctx, expr, interpolCtx)
+		if err != nil {
+			return "", err
+		}
+		if handled {
+			tflog.Trace(ctx, fmt.Sprintf("Interpolated %s -> %s", match, value))
+			result.WriteString(value)
+		} else {
+			result.WriteString(match)
 		}
-		lastErr = fmt.Errorf("output key not found: %s", key)
-		return match
-	})
 
-	if lastErr != nil {
-		return "", lastErr
+		rest = rest[end+1:]
 	}
 
-	// Pattern: ${self.id}
-	result = strings.ReplaceAll(result, "${self.id}", interpolCtx.ID)
-	if strings.Contains(text, "${self.id}") {
-		tflog.Trace(ctx, fmt.Sprintf("Interpolated ${self.id} -> %s", interpolCtx.ID))
+	return result.String(), nil
+}
+
+// findTemplateEnd returns the index of the closing brace of a template expression
+// starting at pos, ignoring braces inside quoted string literals
+func findTemplateEnd(text string, pos int) int {
+	inQuote := false
+	for i := pos; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if inQuote {
+				i++ // Skip escaped character
+			}
+		case '"':
+			inQuote = !inQuote
+		case '}':
+			if !inQuote {
+				return i
+			}
+		}
 	}
+	return -1
+}
 
-	return result, nil
+// evaluateTemplateExpression evaluates a single expression (without the surrounding ${ }).
+// Returns handled=false for expressions this engine does not recognize.
+func evaluateTemplateExpression(ctx context.Context, expr string, interpolCtx *InterpolationContext) (string, bool, error) {
+	// String literal
+	if strings.HasPrefix(expr, "\"") {
+		value, err := strconv.Unquote(expr)
+		if err != nil {
+			return "", false, fmt.Errorf("invalid string literal %s: %w", expr, err)
+		}
+		return value, true, nil
+	}
+
+	// Function call
+	if name, args, ok := parseTemplateFunctionCall(expr); ok {
+		argValues := make([]string, 0, len(args))
+		for _, arg := range args {
+			value, handled, err := evaluateTemplateExpression(ctx, arg, interpolCtx)
+			if err != nil {
+				return "", false, err
+			}
+			if !handled {
+				return "", false, fmt.Errorf("unsupported argument %q in call to %s()", arg, name)
+			}
+			argValues = append(argValues, value)
+		}
+		value, err := callTemplateFunction(name, argValues)
+		if err != nil {
+			return "", false, err
+		}
+		return value, true, nil
+	}
+
+	// self.* references
+	switch {
+	case expr == "self.id":
+		return interpolCtx.ID, true, nil
+	case expr == "self.status_code":
+		return strconv.FormatInt(interpolCtx.StatusCode, 10), true, nil
+	case expr == "self.response_body":
+		return interpolCtx.ResponseBody, true, nil
+	case expr == "self.response_body_excerpt":
+		return utils.TruncateString(interpolCtx.ResponseBody, responseBodyExcerptBytes), true, nil
+	case expr == "self.unsatisfied_conditions":
+		return strings.Join(interpolCtx.UnsatisfiedConditions, "; "), true, nil
+	case strings.HasPrefix(expr, "self.outputs."):
+		key := strings.TrimPrefix(expr, "self.outputs.")
+		if val, ok := interpolCtx.Outputs[key]; ok {
+			return val, true, nil
+		}
+		return "", false, fmt.Errorf("output key not found: %s", key)
+	case strings.HasPrefix(expr, "self.response_headers."):
+		name := strings.TrimPrefix(expr, "self.response_headers.")
+		for k, v := range interpolCtx.ResponseHeaders {
+			if strings.EqualFold(k, name) {
+				return v, true, nil
+			}
+		}
+		return "", false, fmt.Errorf("response header not found: %s", name)
+	case strings.HasPrefix(expr, "shared.") && interpolCtx.Shared != nil:
+		name, output, _ := strings.Cut(strings.TrimPrefix(expr, "shared."), ".")
+		if val, ok := interpolCtx.Shared[name][output]; ok {
+			return val, true, nil
+		}
+		return "", false, fmt.Errorf("shared output %s.%s is not available: the resource with shared_context = %q must be created or updated earlier in the same apply, or before a previous apply of this resource", name, output, name)
+	}
+
+	return "", false, nil
 }
 
 // InterpolateStringValue applies interpolation to a Terraform StringValue
@@ -117,9 +231,10 @@ func InterpolateHeaderBlocks(ctx context.Context, blocks []HeaderBlockModel, int
 // BuildInterpolationContextFromState creates an InterpolationContext from resource state
 func BuildInterpolationContextFromState(ctx context.Context, state *HttpxRequestResourceModel) (*InterpolationContext, error) {
 	interpolCtx := &InterpolationContext{
-		ID:         state.Id.ValueString(),
-		Outputs:    make(map[string]string),
-		StatusCode: state.StatusCode.ValueInt64(),
+		ID:              state.Id.ValueString(),
+		Outputs:         make(map[string]string),
+		StatusCode:      state.StatusCode.ValueInt64(),
+		ResponseHeaders: make(map[string]string),
 	}
 
 	// Extract outputs from state
@@ -134,6 +249,18 @@ func BuildInterpolationContextFromState(ctx context.Context, state *HttpxRequest
 		}
 	}
 
+	// Extract response headers from state
+	if !state.ResponseHeaders.IsNull() && !state.ResponseHeaders.IsUnknown() {
+		headersMap := make(map[string]types.String)
+		diags := state.ResponseHeaders.ElementsAs(ctx, &headersMap, false)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to parse response headers from state")
+		}
+		for key, val := range headersMap {
+			interpolCtx.ResponseHeaders[key] = val.ValueString()
+		}
+	}
+
 	// Extract response body if available
 	if !state.ResponseBody.IsNull() {
 		interpolCtx.ResponseBody = state.ResponseBody.ValueString()
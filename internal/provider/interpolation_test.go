@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -77,6 +78,49 @@ func TestInterpolateString(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "interpolate self.status_code and self.response_body",
+			text: `{"previous_status": ${self.status_code}, "previous": ${self.response_body}}`,
+			interpolCtx: &InterpolationContext{
+				ID:           "res-1",
+				Outputs:      make(map[string]string),
+				StatusCode:   201,
+				ResponseBody: `{"id":"abc"}`,
+			},
+			expected: `{"previous_status": 201, "previous": {"id":"abc"}}`,
+		},
+		{
+			name: "response body is not re-interpolated",
+			text: "${self.response_body}",
+			interpolCtx: &InterpolationContext{
+				ID:           "res-1",
+				Outputs:      make(map[string]string),
+				ResponseBody: "literal ${self.id}",
+			},
+			expected: "literal ${self.id}",
+		},
+		{
+			name: "interpolate self.response_headers.NAME case-insensitively",
+			text: "https://api.example.com${self.response_headers.location}",
+			interpolCtx: &InterpolationContext{
+				ID:      "res-1",
+				Outputs: make(map[string]string),
+				ResponseHeaders: map[string]string{
+					"Location": "/things/42",
+				},
+			},
+			expected: "https://api.example.com/things/42",
+		},
+		{
+			name: "missing response header",
+			text: "${self.response_headers.X-Missing}",
+			interpolCtx: &InterpolationContext{
+				ID:              "res-1",
+				Outputs:         make(map[string]string),
+				ResponseHeaders: make(map[string]string),
+			},
+			expectError: true,
+		},
 		{
 			name:        "nil context",
 			text:        "${self.id}",
@@ -310,6 +354,9 @@ func TestBuildInterpolationContextFromState(t *testing.T) {
 					"org_id":  types.StringValue("org-789"),
 				}),
 				ResponseBody: types.StringValue(`{"key":"value"}`),
+				ResponseHeaders: types.MapValueMust(types.StringType, map[string]attr.Value{
+					"Etag": types.StringValue(`"v1"`),
+				}),
 			},
 			expectID: "resource-123",
 			expectOut: map[string]string{
@@ -343,3 +390,28 @@ func TestBuildInterpolationContextFromState(t *testing.T) {
 	}
 }
 
+func TestBuildInterpolationContextFromState_ResponseFields(t *testing.T) {
+	ctx := context.Background()
+
+	state := &HttpxRequestResourceModel{
+		Id:           types.StringValue("resource-123"),
+		StatusCode:   types.Int64Value(201),
+		ResponseBody: types.StringValue(`{"key":"value"}`),
+		ResponseHeaders: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Etag": types.StringValue(`"v1"`),
+		}),
+	}
+
+	result, err := BuildInterpolationContextFromState(ctx, state)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(201), result.StatusCode)
+	assert.Equal(t, `{"key":"value"}`, result.ResponseBody)
+	assert.Equal(t, map[string]string{"Etag": `"v1"`}, result.ResponseHeaders)
+}
+
+func TestInterpolateString_ResponseBodyExcerpt(t *testing.T) {
+	body := strings.Repeat("x", responseBodyExcerptBytes+10)
+	value, err := InterpolateString(context.Background(), "${self.response_body_excerpt}", &InterpolationContext{ResponseBody: body})
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("x", responseBodyExcerptBytes)+"... [TRUNCATED]", value)
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/itchyny/gojq"
+)
+
+// evaluateJq runs a jq expression against a JSON body and returns all of its outputs
+func evaluateJq(ctx context.Context, body string, expr string) ([]interface{}, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression %q: %w", expr, err)
+	}
+
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression %q: %w", expr, err)
+	}
+
+	input, err := decodeJSON(body)
+	if err != nil {
+		return nil, fmt.Errorf("response body is not valid JSON: %w", err)
+	}
+
+	var outputs []interface{}
+	iter := code.RunWithContext(ctx, input)
+	for {
+		value, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, isErr := value.(error); isErr {
+			var haltErr *gojq.HaltError
+			if errors.As(err, &haltErr) && haltErr.Value() == nil {
+				break
+			}
+			return nil, fmt.Errorf("jq expression %q failed: %w", expr, err)
+		}
+		outputs = append(outputs, value)
+	}
+
+	return outputs, nil
+}
+
+// evaluateJqValue runs a jq expression and returns a single value:
+// the only output as-is, multiple outputs as an array, or nil when there are none
+func evaluateJqValue(ctx context.Context, body string, expr string) (interface{}, error) {
+	outputs, err := evaluateJq(ctx, body, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(outputs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return outputs[0], nil
+	default:
+		return outputs, nil
+	}
+}
+
+// evaluateJqCondition reports whether a jq expression holds: every output must be
+// truthy (anything but false and null) and there must be at least one output
+func evaluateJqCondition(ctx context.Context, body string, expr string) (bool, error) {
+	outputs, err := evaluateJq(ctx, body, expr)
+	if err != nil {
+		return false, err
+	}
+
+	if len(outputs) == 0 {
+		return false, nil
+	}
+	for _, output := range outputs {
+		if output == nil || output == false {
+			return false, nil
+		}
+	}
+	return true, nil
+}
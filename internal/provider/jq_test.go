@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const jqTestBody = `{"status": "ready", "items": [{"id": "a", "ok": true}, {"id": "b", "ok": false}]}`
+
+func TestEvaluateJqValue(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		body        string
+		expr        string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "projection and join",
+			body:     jqTestBody,
+			expr:     `.items | map(.id) | join(",")`,
+			expected: "a,b",
+		},
+		{
+			name:     "filter",
+			body:     jqTestBody,
+			expr:     `[.items[] | select(.ok) | .id]`,
+			expected: `["a"]`,
+		},
+		{
+			name:     "multiple outputs become an array",
+			body:     jqTestBody,
+			expr:     `.items[].id`,
+			expected: `["a","b"]`,
+		},
+		{
+			name:     "no outputs",
+			body:     jqTestBody,
+			expr:     `empty`,
+			expected: "",
+		},
+		{
+			name:        "invalid expression",
+			body:        jqTestBody,
+			expr:        `.items |`,
+			expectError: true,
+		},
+		{
+			name:        "runtime error",
+			body:        jqTestBody,
+			expr:        `.status | tonumber`,
+			expectError: true,
+		},
+		{
+			name:        "body is not JSON",
+			body:        "plain text",
+			expr:        `.status`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := evaluateJqValue(ctx, tt.body, tt.expr)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, formatExtractedValue(value))
+		})
+	}
+}
+
+func TestEvaluateJqCondition(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected bool
+	}{
+		{name: "true comparison", expr: `.status == "ready"`, expected: true},
+		{name: "false comparison", expr: `.status == "pending"`, expected: false},
+		{name: "truthy value", expr: `.items[0].id`, expected: true},
+		{name: "null value", expr: `.missing`, expected: false},
+		{name: "all outputs must be truthy", expr: `.items[].ok`, expected: false},
+		{name: "no outputs", expr: `empty`, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holds, err := evaluateJqCondition(ctx, jqTestBody, tt.expr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, holds)
+		})
+	}
+}
+
+func TestJqIntegration(t *testing.T) {
+	ctx := context.Background()
+	result := &ResponseResult{StatusCode: 200, Body: jqTestBody}
+
+	outputs, err := ExtractValues(ctx, result, []ExtractBlockModel{
+		{Name: types.StringValue("ids"), Jq: types.StringValue(`.items | map(.id) | join(",")`)},
+		{Name: types.StringValue("bad"), Jq: types.StringValue(`.items |`)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ids": "a,b", "bad": ""}, outputs)
+
+	lists := ExtractListValues(ctx, result, []ExtractBlockModel{
+		{Name: types.StringValue("ok_ids"), Jq: types.StringValue(`[.items[] | select(.ok) | .id]`)},
+		{Name: types.StringValue("all_ids"), Jq: types.StringValue(`.items[].id`)},
+	})
+	assert.Equal(t, map[string][]string{"ok_ids": {"a"}, "all_ids": {"a", "b"}}, lists)
+
+	satisfied, unsatisfied := (&RetryUntilConfig{Jq: `.status == "ready"`}).EvaluateRetryUntil(ctx, result)
+	assert.True(t, satisfied)
+	assert.Empty(t, unsatisfied)
+
+	satisfied, unsatisfied = (&RetryUntilConfig{Jq: `.items | all(.ok)`}).EvaluateRetryUntil(ctx, result)
+	assert.False(t, satisfied)
+	assert.Len(t, unsatisfied, 1)
+
+	assert.NoError(t, ValidateExpectations(ctx, result, &ExpectModel{Jq: types.StringValue(`.items | length == 2`)}))
+	assert.Error(t, ValidateExpectations(ctx, result, &ExpectModel{Jq: types.StringValue(`.items | length == 3`)}))
+}
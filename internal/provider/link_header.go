@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parseLinkHeader parses an RFC 8288 Link header into a map of relation type to target URL.
+// Links with several space-separated relation types are stored under each of them, and the
+// first link wins when a relation type appears more than once.
+func parseLinkHeader(header string) map[string]string {
+	links := make(map[string]string)
+
+	for _, link := range splitLinkValues(header) {
+		link = strings.TrimSpace(link)
+		if !strings.HasPrefix(link, "<") {
+			continue
+		}
+		end := strings.Index(link, ">")
+		if end == -1 {
+			continue
+		}
+		target := link[1:end]
+
+		for _, param := range strings.Split(link[end+1:], ";") {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+				continue
+			}
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+			for _, rel := range strings.Fields(value) {
+				rel = strings.ToLower(rel)
+				if _, exists := links[rel]; !exists {
+					links[rel] = target
+				}
+			}
+		}
+	}
+
+	return links
+}
+
+// splitLinkValues splits a Link header on the commas that separate links, ignoring
+// commas inside <...> targets and quoted parameter values
+func splitLinkValues(header string) []string {
+	var values []string
+	inTarget, inQuotes := false, false
+	start := 0
+
+	for i, c := range header {
+		switch {
+		case c == '<' && !inQuotes:
+			inTarget = true
+		case c == '>' && !inQuotes:
+			inTarget = false
+		case c == '"' && !inTarget:
+			inQuotes = !inQuotes
+		case c == ',' && !inTarget && !inQuotes:
+			values = append(values, header[start:i])
+			start = i + 1
+		}
+	}
+	return append(values, header[start:])
+}
+
+// responseLinks parses the Link response header, resolving relative targets against the effective URL
+func responseLinks(result *ResponseResult) map[string]string {
+	links := parseLinkHeader(result.Headers["Link"])
+
+	base, err := url.Parse(result.EffectiveURL)
+	if err != nil || result.EffectiveURL == "" {
+		return links
+	}
+	for rel, target := range links {
+		if resolved, err := base.Parse(target); err == nil {
+			links[rel] = resolved.String()
+		}
+	}
+	return links
+}
+
+// ResponseLinksValue converts the Link response header into the response_links attribute value
+func ResponseLinksValue(result *ResponseResult) types.Map {
+	elements := make(map[string]attr.Value)
+	for rel, target := range responseLinks(result) {
+		elements[rel] = types.StringValue(target)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	links := parseLinkHeader(`<https://api.example.com/items?page=2>; rel="next", <https://api.example.com/items?page=5>; rel="last", <https://api.example.com/a,b>; rel="alternate related"`)
+	assert.Equal(t, "https://api.example.com/items?page=2", links["next"])
+	assert.Equal(t, "https://api.example.com/items?page=5", links["last"])
+	assert.Equal(t, "https://api.example.com/a,b", links["alternate"])
+	assert.Equal(t, "https://api.example.com/a,b", links["related"])
+	assert.Empty(t, parseLinkHeader(""))
+}
+
+func TestResponseLinks(t *testing.T) {
+	result := &ResponseResult{
+		EffectiveURL: "https://api.example.com/v1/items?page=1",
+		Headers:      map[string]string{"Link": `</v1/items?page=2>; rel="next", <https://cdn.example.com/items.csv>; rel="Alternate"`},
+	}
+
+	links := ResponseLinksValue(result).Elements()
+	assert.Equal(t, types.StringValue("https://api.example.com/v1/items?page=2"), links["next"])
+	assert.Equal(t, types.StringValue("https://cdn.example.com/items.csv"), links["alternate"])
+
+	outputs, err := ExtractValues(context.Background(), result, []ExtractBlockModel{
+		{Name: types.StringValue("next_page"), LinkRel: types.StringValue("Next")},
+		{Name: types.StringValue("prev_page"), LinkRel: types.StringValue("prev")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/v1/items?page=2", outputs["next_page"])
+	assert.Equal(t, "", outputs["prev_page"])
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// metadataAttributes only affect how results are stored or refreshed, or what happens on
+// destroy, not the request itself, so changing them rewrites state without sending the request
+// again
+var metadataAttributes = map[string]bool{
+	"read_mode":             true,
+	"refresh_interval":      true,
+	"store_response_body":   true,
+	"response_sensitive":    true,
+	"fail_on_extract_error": true,
+	"redact_headers":        true,
+	"capture_transcript":    true,
+	"timeouts":              true,
+	"on_destroy":            true,
+}
+
+// metadataOnlyChange reports whether plan differs from state only in metadataAttributes, or in
+// moving the deprecated headers attribute to request_headers without changing its value.
+// Computed-only attributes are ignored as they are unknown in the plan of any update.
+func metadataOnlyChange(plan tfsdk.Plan, state tfsdk.State) bool {
+	s, ok := plan.Schema.(schema.Schema)
+	if !ok || plan.Raw.IsNull() || state.Raw.IsNull() {
+		return false
+	}
+	var planAttrs, stateAttrs map[string]tftypes.Value
+	if err := plan.Raw.As(&planAttrs); err != nil {
+		return false
+	}
+	if err := state.Raw.As(&stateAttrs); err != nil {
+		return false
+	}
+
+	// Moving headers to request_headers with the same value doesn't change the request
+	foldHeaderAlias(planAttrs)
+	foldHeaderAlias(stateAttrs)
+
+	for name, attribute := range s.Attributes {
+		if metadataAttributes[name] || attribute.IsComputed() && !attribute.IsOptional() && !attribute.IsRequired() {
+			continue
+		}
+		if !planAttrs[name].Equal(stateAttrs[name]) {
+			return false
+		}
+	}
+	for name := range s.Blocks {
+		if !metadataAttributes[name] && !planAttrs[name].Equal(stateAttrs[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// foldHeaderAlias replaces request_headers with the deprecated headers when only headers is set,
+// and clears headers, so configurations using either name compare equal
+func foldHeaderAlias(attrs map[string]tftypes.Value) {
+	headers, ok := attrs["headers"]
+	if !ok || headers.IsNull() {
+		return
+	}
+	if attrs["request_headers"].IsNull() {
+		attrs["request_headers"] = headers
+	}
+	attrs["headers"] = tftypes.NewValue(headers.Type(), nil)
+}
+
+// applyMetadataChange returns the prior state with the metadata attributes of plan applied
+func applyMetadataChange(plan HttpxRequestResourceModel, state HttpxRequestResourceModel) HttpxRequestResourceModel {
+	state.ReadMode = plan.ReadMode
+	state.RefreshInterval = plan.RefreshInterval
+	state.StoreResponseBody = plan.StoreResponseBody
+	state.ResponseSensitive = plan.ResponseSensitive
+	state.FailOnExtractError = plan.FailOnExtractError
+	state.RedactHeaders = plan.RedactHeaders
+	state.CaptureTranscript = plan.CaptureTranscript
+	state.Timeouts = plan.Timeouts
+	state.Headers = plan.Headers
+	state.RequestHeaders = plan.RequestHeaders
+	state.OnDestroy = plan.OnDestroy
+
+	// A body that is no longer stored is dropped; one that now should be is only available
+	// after the next request
+	if !storesResponseBody(plan) {
+		state.ResponseBody = types.StringNull()
+		state.ResponseBodyJson = types.DynamicNull()
+	}
+	// Likewise a transcript that is no longer captured is dropped
+	if plan.CaptureTranscript.IsNull() || plan.CaptureTranscript.IsUnknown() || !plan.CaptureTranscript.ValueBool() {
+		state.Transcript = types.StringNull()
+	}
+	return state
+}
+
+// storesResponseBody reports whether response_body is kept in state: store_response_body when
+// set, otherwise true unless extract blocks are present
+func storesResponseBody(model HttpxRequestResourceModel) bool {
+	if !model.StoreResponseBody.IsNull() && !model.StoreResponseBody.IsUnknown() {
+		return model.StoreResponseBody.ValueBool()
+	}
+	return len(model.ExtractBlocks) == 0
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataOnlyChange(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	nullAttributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		nullAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	var base HttpxRequestResourceModel
+	assert.False(t, tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nullAttributes)}.Get(ctx, &base).HasError())
+
+	stored := base
+	stored.Url = types.StringValue("https://api.example.com/deployments")
+	stored.Method = types.StringValue("POST")
+	stored.Id = types.StringValue("abc123")
+	stored.StatusCode = types.Int64Value(201)
+	stored.ResponseBody = types.StringValue(`{"id":"d-1"}`)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	assert.False(t, state.Set(ctx, &stored).HasError())
+
+	plan := func(change func(model *HttpxRequestResourceModel)) tfsdk.Plan {
+		model := stored
+		// Computed attributes are unknown in the plan of an update
+		model.StatusCode = types.Int64Unknown()
+		model.ResponseBody = types.StringUnknown()
+		change(&model)
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+		assert.False(t, plan.Set(ctx, &model).HasError())
+		return plan
+	}
+
+	storeOff := plan(func(model *HttpxRequestResourceModel) {
+		model.StoreResponseBody = types.BoolValue(false)
+		model.ReadMode = types.StringValue("refresh")
+		model.Timeouts = timeouts.Value{Object: types.ObjectValueMust(model.Timeouts.AttributeTypes(ctx), map[string]attr.Value{
+			"create": types.StringValue("5m"), "read": types.StringNull(), "update": types.StringNull(), "delete": types.StringNull(),
+		})}
+	})
+	assert.True(t, metadataOnlyChange(storeOff, state))
+	assert.False(t, metadataOnlyChange(plan(func(model *HttpxRequestResourceModel) {
+		model.StoreResponseBody = types.BoolValue(false)
+		model.Url = types.StringValue("https://api.example.com/v2/deployments")
+	}), state))
+	assert.False(t, metadataOnlyChange(storeOff, tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}))
+
+	// Moving headers to request_headers with the same value doesn't re-send the request
+	headers := types.MapValueMust(types.StringType, map[string]attr.Value{"X-Team": types.StringValue("platform")})
+	stored.Headers = headers
+	assert.False(t, state.Set(ctx, &stored).HasError())
+	moved := plan(func(model *HttpxRequestResourceModel) {
+		model.Headers = types.MapNull(types.StringType)
+		model.RequestHeaders = headers
+	})
+	assert.True(t, metadataOnlyChange(moved, state))
+	assert.False(t, metadataOnlyChange(plan(func(model *HttpxRequestResourceModel) {
+		model.Headers = types.MapNull(types.StringType)
+		model.RequestHeaders = types.MapValueMust(types.StringType, map[string]attr.Value{"X-Team": types.StringValue("payments")})
+	}), state))
+	var movedModel HttpxRequestResourceModel
+	assert.False(t, moved.Get(ctx, &movedModel).HasError())
+	merged := applyMetadataChange(movedModel, stored)
+	assert.True(t, merged.Headers.IsNull())
+	assert.Equal(t, headers, merged.RequestHeaders)
+	stored.Headers = types.MapNull(types.StringType)
+	assert.False(t, state.Set(ctx, &stored).HasError())
+
+	var planned HttpxRequestResourceModel
+	assert.False(t, storeOff.Get(ctx, &planned).HasError())
+	merged = applyMetadataChange(planned, stored)
+	assert.Equal(t, int64(201), merged.StatusCode.ValueInt64())
+	assert.Equal(t, "refresh", merged.ReadMode.ValueString())
+	assert.True(t, merged.ResponseBody.IsNull())
+}
+
+func TestUpdateOnDestroyOnlyChange(t *testing.T) {
+	ctx := context.Background()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	onDestroyType := objectType.AttributeTypes["on_destroy"].(tftypes.Object)
+	model := func(destroyURL string, failOnExtractError bool, status tftypes.Value) tftypes.Value {
+		return nullObject(objectType, map[string]tftypes.Value{
+			"id":                    tftypes.NewValue(tftypes.String, "abc123"),
+			"url":                   tftypes.NewValue(tftypes.String, server.URL+"/deployments"),
+			"method":                tftypes.NewValue(tftypes.String, "POST"),
+			"fail_on_extract_error": tftypes.NewValue(tftypes.Bool, failOnExtractError),
+			"status_code":           status,
+			"on_destroy": nullObject(onDestroyType, map[string]tftypes.Value{
+				"method": tftypes.NewValue(tftypes.String, "DELETE"),
+				"url":    tftypes.NewValue(tftypes.String, destroyURL),
+			}),
+		})
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: model(server.URL+"/deployments/${self.id}", false, tftypes.NewValue(tftypes.Number, 201))}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: model(server.URL+"/v2/deployments/${self.id}", true, tftypes.NewValue(tftypes.Number, tftypes.UnknownValue))}
+	assert.True(t, metadataOnlyChange(plan, state))
+
+	r := &HttpxRequestResource{config: &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.Equal(t, 0, requests)
+
+	var updated HttpxRequestResourceModel
+	assert.False(t, resp.State.Get(ctx, &updated).HasError())
+	assert.Equal(t, int64(201), updated.StatusCode.ValueInt64())
+	assert.True(t, updated.FailOnExtractError.ValueBool())
+	assert.Equal(t, server.URL+"/v2/deployments/${self.id}", updated.OnDestroy.Url.ValueString())
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// standardMethods are the methods defined by RFC 9110 and RFC 5789
+var standardMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// resolveMethod returns the method to send: GET when method is empty, the canonical upper-case
+// form of a standard method, or method itself when allowCustom permits a non-standard one
+func resolveMethod(method string, allowCustom bool) (string, error) {
+	if method == "" {
+		return http.MethodGet, nil
+	}
+	for _, standard := range standardMethods {
+		if strings.EqualFold(method, standard) {
+			return standard, nil
+		}
+	}
+
+	if !allowCustom {
+		return "", fmt.Errorf("unsupported HTTP method %q: expected one of %s, or set allow_custom_methods = true",
+			method, strings.Join(standardMethods, ", "))
+	}
+	if !isMethodToken(method) {
+		return "", fmt.Errorf("invalid HTTP method %q: methods must be an RFC 9110 token", method)
+	}
+	return method, nil
+}
+
+// isMethodToken reports whether s is an RFC 9110 token
+func isMethodToken(s string) bool {
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
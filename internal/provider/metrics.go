@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// applyMetrics collects request metrics for the whole provider process. Every provider
+// configuration that enables a metrics summary shares it, so the summary covers the apply.
+var applyMetrics = newMetricsRecorder()
+
+// metricsRecorder aggregates attempts per host and remembers where to report them at shutdown
+type metricsRecorder struct {
+	mu    sync.Mutex
+	hosts map[string]*hostMetrics
+	paths map[string]bool
+	log   bool
+}
+
+type hostMetrics struct {
+	requests   int64
+	retries    int64
+	failures   int64
+	durationMs []int64
+}
+
+// metricsSummary is the report written at provider shutdown
+type metricsSummary struct {
+	TotalRequests int64                         `json:"total_requests"`
+	Retries       int64                         `json:"retries"`
+	Failures      int64                         `json:"failures"`
+	Hosts         map[string]hostMetricsSummary `json:"hosts"`
+}
+
+type hostMetricsSummary struct {
+	Requests     int64 `json:"requests"`
+	Retries      int64 `json:"retries"`
+	Failures     int64 `json:"failures"`
+	P95LatencyMs int64 `json:"p95_latency_ms"`
+}
+
+func newMetricsRecorder() *metricsRecorder {
+	return &metricsRecorder{hosts: make(map[string]*hostMetrics), paths: make(map[string]bool)}
+}
+
+// enable registers a summary destination: a file path, the plugin log, or both
+func (m *metricsRecorder) enable(path string, logSummary bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if path != "" {
+		m.paths[path] = true
+	}
+	m.log = m.log || logSummary
+}
+
+// record adds a finished attempt. Attempts after the first count as retries, and attempts
+// that failed to complete or returned a 5xx status count as failures.
+func (m *metricsRecorder) record(req *http.Request, record AttemptRecord) {
+	if m == nil {
+		return
+	}
+
+	host := strings.ToLower(req.URL.Host)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hm, ok := m.hosts[host]
+	if !ok {
+		hm = &hostMetrics{}
+		m.hosts[host] = hm
+	}
+	hm.requests++
+	if record.Attempt > 1 {
+		hm.retries++
+	}
+	if record.Error != "" || record.StatusCode == 0 || record.StatusCode >= 500 {
+		hm.failures++
+	}
+	hm.durationMs = append(hm.durationMs, record.DurationMs)
+}
+
+// summary aggregates the recorded attempts
+func (m *metricsRecorder) summary() metricsSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	summary := metricsSummary{Hosts: make(map[string]hostMetricsSummary, len(m.hosts))}
+	for host, hm := range m.hosts {
+		summary.TotalRequests += hm.requests
+		summary.Retries += hm.retries
+		summary.Failures += hm.failures
+		summary.Hosts[host] = hostMetricsSummary{
+			Requests:     hm.requests,
+			Retries:      hm.retries,
+			Failures:     hm.failures,
+			P95LatencyMs: percentile(hm.durationMs, 95),
+		}
+	}
+	return summary
+}
+
+// percentile returns the nearest-rank percentile of values
+func percentile(values []int64, p int) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// flush writes the summary to every registered destination
+func (m *metricsRecorder) flush() error {
+	m.mu.Lock()
+	paths := make([]string, 0, len(m.paths))
+	for path := range m.paths {
+		paths = append(paths, path)
+	}
+	logSummary := m.log
+	m.mu.Unlock()
+
+	if len(paths) == 0 && !logSummary {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(m.summary(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if logSummary {
+		log.Printf("[INFO] httpx metrics summary: %s", data)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+			return fmt.Errorf("failed to write metrics summary to %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// FlushMetricsSummary writes the apply-level metrics summary, if enabled in any provider
+// configuration. It is called once the provider server has shut down.
+func FlushMetricsSummary() error {
+	return applyMetrics.flush()
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsRecorder(t *testing.T) {
+	metrics := newMetricsRecorder()
+	api, _ := http.NewRequest(http.MethodGet, "https://API.example.com/items", nil)
+	auth, _ := http.NewRequest(http.MethodPost, "https://auth.example.com/token", nil)
+
+	for i := int64(1); i <= 20; i++ {
+		metrics.record(api, AttemptRecord{Attempt: 1, StatusCode: 200, DurationMs: i * 10})
+	}
+	metrics.record(auth, AttemptRecord{Attempt: 1, StatusCode: 503, DurationMs: 100})
+	metrics.record(auth, AttemptRecord{Attempt: 2, Error: "connection reset", DurationMs: 5})
+	metrics.record(auth, AttemptRecord{Attempt: 3, StatusCode: 200, DurationMs: 50})
+
+	summary := metrics.summary()
+	assert.Equal(t, int64(23), summary.TotalRequests)
+	assert.Equal(t, int64(2), summary.Retries)
+	assert.Equal(t, int64(2), summary.Failures)
+	assert.Equal(t, hostMetricsSummary{Requests: 20, P95LatencyMs: 190}, summary.Hosts["api.example.com"])
+	assert.Equal(t, hostMetricsSummary{Requests: 3, Retries: 2, Failures: 2, P95LatencyMs: 100}, summary.Hosts["auth.example.com"])
+}
+
+func TestMetricsRecorder_Flush(t *testing.T) {
+	metrics := newMetricsRecorder()
+	assert.NoError(t, metrics.flush(), "flush without destinations is a no-op")
+
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	metrics.enable(path, false)
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com", nil)
+	metrics.record(req, AttemptRecord{Attempt: 1, StatusCode: 200, DurationMs: 12})
+	assert.NoError(t, metrics.flush())
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var summary metricsSummary
+	assert.NoError(t, json.Unmarshal(data, &summary))
+	assert.Equal(t, int64(1), summary.TotalRequests)
+	assert.Equal(t, int64(12), summary.Hosts["api.example.com"].P95LatencyMs)
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -8,65 +9,166 @@ import (
 // Used by both root request and on_destroy block
 type RequestConfigModel struct {
 	Url                types.String `tfsdk:"url"`
+	PathParams         types.Map    `tfsdk:"path_params"`
 	Method             types.String `tfsdk:"method"`
+	AllowCustomMethods types.Bool   `tfsdk:"allow_custom_methods"`
 	Headers            types.Map    `tfsdk:"headers"`
+	RequestHeaders     types.Map    `tfsdk:"request_headers"`
 	Query              types.Map    `tfsdk:"query"`
+	Cookies            types.Map    `tfsdk:"cookies"`
+	PreserveHeaderCase types.Bool   `tfsdk:"preserve_header_case"`
 	Body               types.String `tfsdk:"body"`
 	BodyJson           types.String `tfsdk:"body_json"`
+	BodyObject         types.Dynamic `tfsdk:"body_object"`
 	BodyFile           types.String `tfsdk:"body_file"`
+	AutoContentDigest  types.String `tfsdk:"auto_content_digest"`
 	BearerToken        types.String `tfsdk:"bearer_token"`
 	TimeoutMs          types.Int64  `tfsdk:"timeout_ms"`
+	ConnectTimeoutMs   types.Int64  `tfsdk:"connect_timeout_ms"`
+	TlsHandshakeTimeoutMs types.Int64 `tfsdk:"tls_handshake_timeout_ms"`
+	ResponseHeaderTimeoutMs types.Int64 `tfsdk:"response_header_timeout_ms"`
+	PinnedSpkiSha256 types.List `tfsdk:"pinned_spki_sha256"`
 	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
 	ProxyUrl           types.String `tfsdk:"proxy_url"`
 	ResponseSensitive  types.Bool   `tfsdk:"response_sensitive"`
 	StoreResponseBody  types.Bool   `tfsdk:"store_response_body"`
+	MaxResponseBodyBytes types.Int64  `tfsdk:"max_response_body_bytes"`
+	OnBodyOverflow       types.String `tfsdk:"on_body_overflow"`
+	RedactHeaders        types.List   `tfsdk:"redact_headers"`
+	NonceHeader          types.String `tfsdk:"nonce_header"`
+	NonceScope           types.String `tfsdk:"nonce_scope"`
+
+	// Destroy-only settings (ignored outside on_destroy)
+	RefreshBeforeDestroy types.Bool   `tfsdk:"refresh_before_destroy"`
+	FailureMode          types.String `tfsdk:"failure_mode"`
+	TreatStatusAsSuccess types.List   `tfsdk:"treat_status_as_success"`
 
 	// Blocks
 	HeaderBlocks  []HeaderBlockModel       `tfsdk:"header"`
 	BasicAuth     *ResourceBasicAuthModel  `tfsdk:"basic_auth"`
 	Retry         *RetryModel              `tfsdk:"retry"`
 	RetryUntil    *RetryUntilModel         `tfsdk:"retry_until"`
+	AbortOn       *AbortOnModel            `tfsdk:"abort_on"`
 	Expect        *ExpectModel             `tfsdk:"expect"`
+	PreRequestCommand   *CommandHookModel `tfsdk:"pre_request_command"`
+	PostResponseCommand *CommandHookModel `tfsdk:"post_response_command"`
 	ExtractBlocks []ExtractBlockModel      `tfsdk:"extract"`
 }
 
 // HttpxRequestResourceModel represents the resource state
 type HttpxRequestResourceModel struct {
 	Id                types.String `tfsdk:"id"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	LastResponseAt    types.String `tfsdk:"last_response_at"`
+	RequestPreview    types.String `tfsdk:"request_preview"`
+	EffectiveRequestUrl types.String `tfsdk:"effective_request_url"`
+	RequestFingerprint  types.String `tfsdk:"request_fingerprint"`
 	ReadMode          types.String `tfsdk:"read_mode"`
+	RefreshInterval   types.String `tfsdk:"refresh_interval"`
 	StatusCode        types.Int64  `tfsdk:"status_code"`
+	StatusText        types.String `tfsdk:"status_text"`
+	Protocol          types.String `tfsdk:"protocol"`
+	RemoteAddr        types.String `tfsdk:"remote_addr"`
+	TlsVersion        types.String `tfsdk:"tls_version"`
+	TlsCipherSuite    types.String `tfsdk:"tls_cipher_suite"`
+	PeerCertSha256    types.String `tfsdk:"peer_cert_sha256"`
 	ResponseHeaders   types.Map    `tfsdk:"response_headers"`
+	ResponseCookies   types.Map    `tfsdk:"response_cookies"`
+	ResponseLinks     types.Map    `tfsdk:"response_links"`
+	EffectiveUrl      types.String `tfsdk:"effective_url"`
+	RedirectChain     types.List   `tfsdk:"redirect_chain"`
 	ResponseBody      types.String `tfsdk:"response_body"`
+	ResponseBodyJson  types.Dynamic `tfsdk:"response_body_json"`
+	ResponseBodyFileSha256 types.String `tfsdk:"response_body_file_sha256"`
 	Outputs           types.Map    `tfsdk:"outputs"`
+	OutputsLists      types.Map    `tfsdk:"outputs_lists"`
+	PrivateOutputs    types.List   `tfsdk:"private_outputs"`
+	SharedContext     types.String `tfsdk:"shared_context"`
+	DependsOnOutputs  types.Map    `tfsdk:"depends_on_outputs"`
+	FailOnExtractError types.Bool  `tfsdk:"fail_on_extract_error"`
 	LastAttemptCount  types.Int64  `tfsdk:"last_attempt_count"`
 	LastError         types.String `tfsdk:"last_error"`
+	ErrorResponseBody types.String `tfsdk:"error_response_body"`
+	AttemptHistory    types.List   `tfsdk:"attempt_history"`
+	Transcript        types.String `tfsdk:"transcript"`
+	Nonce             types.String `tfsdk:"nonce"`
+	RequestHeadersSent types.Map   `tfsdk:"request_headers_sent"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
 
 	// Root request configuration (flattened from RequestConfigModel)
 	Url                types.String `tfsdk:"url"`
+	PathParams         types.Map    `tfsdk:"path_params"`
 	Method             types.String `tfsdk:"method"`
+	AllowCustomMethods types.Bool   `tfsdk:"allow_custom_methods"`
 	Headers            types.Map    `tfsdk:"headers"`
+	RequestHeaders     types.Map    `tfsdk:"request_headers"`
 	Query              types.Map    `tfsdk:"query"`
+	Cookies            types.Map    `tfsdk:"cookies"`
+	PreserveHeaderCase types.Bool   `tfsdk:"preserve_header_case"`
 	Body               types.String `tfsdk:"body"`
 	BodyJson           types.String `tfsdk:"body_json"`
+	BodyObject         types.Dynamic `tfsdk:"body_object"`
 	BodyFile           types.String `tfsdk:"body_file"`
+	AutoContentDigest  types.String `tfsdk:"auto_content_digest"`
 	BearerToken        types.String `tfsdk:"bearer_token"`
 	TimeoutMs          types.Int64  `tfsdk:"timeout_ms"`
+	ConnectTimeoutMs   types.Int64  `tfsdk:"connect_timeout_ms"`
+	TlsHandshakeTimeoutMs types.Int64 `tfsdk:"tls_handshake_timeout_ms"`
+	ResponseHeaderTimeoutMs types.Int64 `tfsdk:"response_header_timeout_ms"`
+	PinnedSpkiSha256 types.List `tfsdk:"pinned_spki_sha256"`
 	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
 	ProxyUrl           types.String `tfsdk:"proxy_url"`
 	ResponseSensitive  types.Bool   `tfsdk:"response_sensitive"`
 	StoreResponseBody  types.Bool   `tfsdk:"store_response_body"`
+	MaxResponseBodyBytes types.Int64  `tfsdk:"max_response_body_bytes"`
+	OnBodyOverflow       types.String `tfsdk:"on_body_overflow"`
+	RedactHeaders        types.List   `tfsdk:"redact_headers"`
+	NonceHeader          types.String `tfsdk:"nonce_header"`
+	NonceScope           types.String `tfsdk:"nonce_scope"`
+	CaptureTranscript    types.Bool   `tfsdk:"capture_transcript"`
+	ResponseBodyFile     types.String `tfsdk:"response_body_file"`
+	Range                types.String `tfsdk:"range"`
+	TransferEncoding     types.String `tfsdk:"transfer_encoding"`
+	Resume               types.Bool   `tfsdk:"resume"`
+	ResponseHeaderNames  types.String `tfsdk:"response_header_names"`
+	IgnoreResponseHeaders types.List  `tfsdk:"ignore_response_headers"`
+	NormalizeResponseBody types.Bool  `tfsdk:"normalize_response_body"`
+	IgnoreBodyPaths      types.List   `tfsdk:"ignore_body_paths"`
 
 	// Root request blocks
 	HeaderBlocks  []HeaderBlockModel       `tfsdk:"header"`
 	BasicAuth     *ResourceBasicAuthModel  `tfsdk:"basic_auth"`
 	Retry         *RetryModel              `tfsdk:"retry"`
 	RetryUntil    *RetryUntilModel         `tfsdk:"retry_until"`
+	AbortOn       *AbortOnModel            `tfsdk:"abort_on"`
 	Expect        *ExpectModel             `tfsdk:"expect"`
+	PreRequestCommand   *CommandHookModel `tfsdk:"pre_request_command"`
+	PostResponseCommand *CommandHookModel `tfsdk:"post_response_command"`
 	ExtractBlocks []ExtractBlockModel      `tfsdk:"extract"`
+	TreatAsSuccess *TreatAsSuccessModel   `tfsdk:"treat_as_success"`
+	OnConflict     *OnConflictModel       `tfsdk:"on_conflict"`
 
 	// Destroy configuration
 	OnDestroy *RequestConfigModel `tfsdk:"on_destroy"`
-	Timeouts  *TimeoutsModel      `tfsdk:"timeouts"`
+	Timeouts  timeouts.Value      `tfsdk:"timeouts"`
+}
+
+// TreatAsSuccessModel represents error responses accepted as success
+type TreatAsSuccessModel struct {
+	StatusCodes    types.List   `tfsdk:"status_codes"`
+	JsonPathEquals types.Map    `tfsdk:"json_path_equals"`
+	FollowUpUrl    types.String `tfsdk:"follow_up_url"`
+}
+
+// OnConflictModel represents the alternate request made when the request conflicts
+type OnConflictModel struct {
+	StatusCodes    types.List   `tfsdk:"status_codes"`
+	JsonPathEquals types.Map    `tfsdk:"json_path_equals"`
+	Url            types.String `tfsdk:"url"`
+	Method         types.String `tfsdk:"method"`
+	RequestHeaders types.Map    `tfsdk:"request_headers"`
+	Query          types.Map    `tfsdk:"query"`
+	Body           types.String `tfsdk:"body"`
 }
 
 // HeaderBlockModel represents a repeated header block
@@ -88,38 +190,161 @@ type RetryModel struct {
 	MaxDelayMs          types.Int64   `tfsdk:"max_delay_ms"`
 	Backoff             types.String   `tfsdk:"backoff"`
 	Jitter              types.Bool    `tfsdk:"jitter"`
+	JitterMode          types.String   `tfsdk:"jitter_mode"`
 	RetryOnStatusCodes  types.List    `tfsdk:"retry_on_status_codes"`
+	RetryOnStatusClasses types.List   `tfsdk:"retry_on_status_classes"`
 	RespectRetryAfter   types.Bool    `tfsdk:"respect_retry_after"`
+	RetryOnErrors       types.List    `tfsdk:"retry_on_errors"`
+	SafeMethodsOnly     types.Bool    `tfsdk:"safe_methods_only"`
+	StatusDelayOverrides types.Map     `tfsdk:"status_delay_overrides"`
 }
 
 // RetryUntilModel represents conditional retry configuration
 type RetryUntilModel struct {
 	StatusCodes     types.List    `tfsdk:"status_codes"`
+	StatusClasses   types.List    `tfsdk:"status_classes"`
 	JsonPathEquals  types.Map     `tfsdk:"json_path_equals"`
 	HeaderEquals    types.Map     `tfsdk:"header_equals"`
+	HeaderEqualsIgnoreCase types.Bool `tfsdk:"header_equals_ignore_case"`
 	BodyRegex       types.String  `tfsdk:"body_regex"`
+	Jq              types.String  `tfsdk:"jq"`
+	IntervalMs      types.Int64   `tfsdk:"interval_ms"`
+	InitialDelayMs  types.Int64   `tfsdk:"initial_delay_ms"`
+	ConsecutiveSuccesses types.Int64 `tfsdk:"consecutive_successes"`
+	Match           types.String  `tfsdk:"match"`
+
+	// Blocks
+	Conditions []RetryUntilConditionModel `tfsdk:"condition"`
+}
+
+// RetryUntilConditionModel represents a condition group within retry_until
+type RetryUntilConditionModel struct {
+	Match          types.String `tfsdk:"match"`
+	StatusCodes    types.List   `tfsdk:"status_codes"`
+	StatusClasses  types.List   `tfsdk:"status_classes"`
+	JsonPathEquals types.Map    `tfsdk:"json_path_equals"`
+	HeaderEquals   types.Map    `tfsdk:"header_equals"`
+	BodyRegex      types.String `tfsdk:"body_regex"`
+	Jq             types.String `tfsdk:"jq"`
+}
+
+// AbortOnModel represents conditions that stop retrying early
+type AbortOnModel struct {
+	StatusCodes    types.List   `tfsdk:"status_codes"`
+	JsonPathEquals types.Map    `tfsdk:"json_path_equals"`
+	BodyRegex      types.String `tfsdk:"body_regex"`
 }
 
 // ExpectModel represents response expectations
 type ExpectModel struct {
 	StatusCodes     types.List    `tfsdk:"status_codes"`
+	StatusClasses   types.List    `tfsdk:"status_classes"`
 	JsonPathExists  types.List    `tfsdk:"json_path_exists"`
 	JsonPathEquals  types.Map     `tfsdk:"json_path_equals"`
 	HeaderPresent   types.List    `tfsdk:"header_present"`
+	Jq              types.String  `tfsdk:"jq"`
+	BodySha256      types.String  `tfsdk:"body_sha256"`
+	ContentLength   types.Int64   `tfsdk:"content_length"`
+	ContentType     types.String  `tfsdk:"content_type"`
+	TlsCertMinDaysValid types.Int64 `tfsdk:"tls_cert_min_days_valid"`
+	Severity        types.String  `tfsdk:"severity"`
+	ErrorMessage    types.String  `tfsdk:"error_message"`
+	Match           types.String  `tfsdk:"match"`
+
+	// Blocks
+	Conditions []ExpectConditionModel `tfsdk:"condition"`
+}
+
+// ExpectConditionModel represents a condition group within expect
+type ExpectConditionModel struct {
+	Match         types.String `tfsdk:"match"`
+	StatusCodes   types.List   `tfsdk:"status_codes"`
+	StatusClasses types.List   `tfsdk:"status_classes"`
+	HeaderPresent types.List   `tfsdk:"header_present"`
+	Jq            types.String `tfsdk:"jq"`
+	ContentType   types.String `tfsdk:"content_type"`
+}
+
+// CommandHookModel represents a pre_request_command or post_response_command block
+type CommandHookModel struct {
+	Command   types.List  `tfsdk:"command"`
+	TimeoutMs types.Int64 `tfsdk:"timeout_ms"`
 }
 
 // ExtractBlockModel represents an extract block
 type ExtractBlockModel struct {
 	Name     types.String `tfsdk:"name"`
 	JsonPath types.String `tfsdk:"json_path"`
+	ForEachPath types.String `tfsdk:"for_each_path"`
+	Jq       types.String `tfsdk:"jq"`
 	Header   types.String `tfsdk:"header"`
+	Cookie   types.String `tfsdk:"cookie"`
+	LinkRel  types.String `tfsdk:"link_rel"`
+}
+
+// AttemptHistoryModel represents one entry of the attempt_history computed attribute
+type AttemptHistoryModel struct {
+	Attempt               types.Int64  `tfsdk:"attempt"`
+	StatusCode            types.Int64  `tfsdk:"status_code"`
+	DurationMs            types.Int64  `tfsdk:"duration_ms"`
+	Error                 types.String `tfsdk:"error"`
+	ConditionsMet         types.Bool   `tfsdk:"conditions_met"`
+	UnsatisfiedConditions types.List   `tfsdk:"unsatisfied_conditions"`
+}
+
+// ResponseCookieModel represents one entry of the response_cookies computed attribute
+type ResponseCookieModel struct {
+	Value    types.String `tfsdk:"value"`
+	Domain   types.String `tfsdk:"domain"`
+	Path     types.String `tfsdk:"path"`
+	Expires  types.String `tfsdk:"expires"`
+	MaxAge   types.Int64  `tfsdk:"max_age"`
+	Secure   types.Bool   `tfsdk:"secure"`
+	HttpOnly types.Bool   `tfsdk:"http_only"`
+	SameSite types.String `tfsdk:"same_site"`
+}
+
+// RedirectHopModel represents one entry of the redirect_chain computed attribute
+type RedirectHopModel struct {
+	Url        types.String `tfsdk:"url"`
+	StatusCode types.Int64  `tfsdk:"status_code"`
+	Location   types.String `tfsdk:"location"`
+}
+
+
+// HttpxBatchResourceModel represents the httpx_batch resource state
+type HttpxBatchResourceModel struct {
+	Id             types.String        `tfsdk:"id"`
+	Requests       []BatchRequestModel `tfsdk:"request"`
+	StatusCodes    types.Map           `tfsdk:"status_codes"`
+	ResponseBodies types.Map           `tfsdk:"response_bodies"`
 }
 
-// TimeoutsModel represents timeout configuration
-type TimeoutsModel struct {
-	Create types.String `tfsdk:"create"`
-	Read   types.String `tfsdk:"read"`
-	Update types.String `tfsdk:"update"`
-	Delete types.String `tfsdk:"delete"`
+// BatchRequestModel represents one request block of httpx_batch
+type BatchRequestModel struct {
+	Name        types.String        `tfsdk:"name"`
+	Url         types.String        `tfsdk:"url"`
+	Method      types.String        `tfsdk:"method"`
+	Headers     types.Map           `tfsdk:"headers"`
+	Query       types.Map           `tfsdk:"query"`
+	Body        types.String        `tfsdk:"body"`
+	BearerToken types.String        `tfsdk:"bearer_token"`
+	When        *BatchWhenModel     `tfsdk:"when"`
+	Rollback    *BatchRollbackModel `tfsdk:"rollback"`
 }
 
+// BatchWhenModel represents the condition on the previous response that a batch request runs under
+type BatchWhenModel struct {
+	JsonPath  types.String `tfsdk:"json_path"`
+	Equals    types.String `tfsdk:"equals"`
+	NotEquals types.String `tfsdk:"not_equals"`
+}
+
+// BatchRollbackModel represents the compensation request undoing a batch request
+type BatchRollbackModel struct {
+	Url     types.String `tfsdk:"url"`
+	Method  types.String `tfsdk:"method"`
+	Headers types.Map    `tfsdk:"headers"`
+	Query   types.Map    `tfsdk:"query"`
+	Body    types.String `tfsdk:"body"`
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Nonce scopes for nonce_scope
+const (
+	nonceScopeAttempt = "attempt"
+	nonceScopeRequest = "request"
+)
+
+// WithNonce returns a copy of the provider config that sends a generated nonce in the
+// nonce_header header. With the "request" scope one value is shared by every attempt of the
+// execution, with "attempt" (the default) each attempt gets a fresh value.
+func (p *ProviderConfig) WithNonce(header types.String, scope types.String) (*ProviderConfig, error) {
+	cfg := *p
+	cfg.NonceHeader = ""
+	cfg.Nonce = ""
+
+	if header.IsNull() || header.IsUnknown() || header.ValueString() == "" {
+		return &cfg, nil
+	}
+	cfg.NonceHeader = header.ValueString()
+
+	switch scope.ValueString() {
+	case "", nonceScopeAttempt:
+	case nonceScopeRequest:
+		nonce, err := generateNonce()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Nonce = nonce
+	default:
+		return nil, fmt.Errorf("nonce_scope must be 'attempt' or 'request', got %q", scope.ValueString())
+	}
+	return &cfg, nil
+}
+
+// generateNonce returns 128 random bits, hex encoded
+func generateNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// applyNonce sets the nonce header on req for the next attempt and returns the value sent
+func applyNonce(req *http.Request, config *ProviderConfig) (string, error) {
+	if config == nil || config.NonceHeader == "" {
+		return "", nil
+	}
+
+	nonce := config.Nonce
+	if nonce == "" {
+		var err error
+		if nonce, err = generateNonce(); err != nil {
+			return "", err
+		}
+	}
+	req.Header.Set(config.NonceHeader, nonce)
+	return nonce, nil
+}
+
+// NonceValue returns the nonce sent with the last recorded attempt as the nonce attribute value
+func NonceValue(records []AttemptRecord) types.String {
+	if len(records) == 0 || records[len(records)-1].Nonce == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(records[len(records)-1].Nonce)
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonceHeader(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("X-Nonce"))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}
+	retryConfig := &RetryConfig{Attempts: 3, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed", RetryOnStatusCodes: []int64{503}}
+	execute := func(scope types.String) *ResponseResult {
+		seen = nil
+		cfg, err := providerConfig.WithNonce(types.StringValue("X-Nonce"), scope)
+		assert.NoError(t, err)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, nil)
+		assert.NoError(t, err)
+		result, _ := ExecuteRequestWithRetry(context.Background(), req, cfg, retryConfig, nil, nil)
+		return result
+	}
+
+	t.Run("attempt scope", func(t *testing.T) {
+		result := execute(types.StringNull())
+		if assert.Len(t, seen, 3) {
+			assert.Len(t, seen[0], 32)
+			assert.NotEqual(t, seen[0], seen[1])
+			assert.NotEqual(t, seen[1], seen[2])
+			assert.Equal(t, seen[2], NonceValue(result.AttemptHistory).ValueString())
+		}
+	})
+
+	t.Run("request scope", func(t *testing.T) {
+		result := execute(types.StringValue("request"))
+		if assert.Len(t, seen, 3) {
+			assert.Equal(t, seen[0], seen[1])
+			assert.Equal(t, seen[1], seen[2])
+			assert.Equal(t, seen[0], NonceValue(result.AttemptHistory).ValueString())
+		}
+	})
+
+	_, err := providerConfig.WithNonce(types.StringValue("X-Nonce"), types.StringValue("create"))
+	assert.ErrorContains(t, err, "nonce_scope must be")
+
+	cfg, err := providerConfig.WithNonce(types.StringNull(), types.StringValue("request"))
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.NonceHeader)
+	assert.True(t, NonceValue(nil).IsNull())
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NormalizeResponseBody prepares a response body for storage in state. When normalize is true
+// or ignore_body_paths is set, JSON bodies are re-serialized compactly with sorted keys after
+// removing the ignored paths. Bodies that are not JSON are returned unchanged.
+func NormalizeResponseBody(ctx context.Context, body string, normalize types.Bool, ignorePaths types.List) (string, error) {
+	var paths []string
+	if !ignorePaths.IsNull() && !ignorePaths.IsUnknown() {
+		converted, err := ConvertTerraformList(ctx, ignorePaths, func(v interface{}) (string, error) {
+			if strVal, ok := v.(types.String); ok {
+				return strVal.ValueString(), nil
+			}
+			return "", fmt.Errorf("expected string, got %T", v)
+		})
+		if err != nil {
+			return body, fmt.Errorf("invalid ignore_body_paths: %w", err)
+		}
+		paths = converted
+	}
+
+	if !normalize.ValueBool() && len(paths) == 0 {
+		return body, nil
+	}
+
+	data, err := decodeJSON(body)
+	if err != nil {
+		return body, nil
+	}
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		data = deleteJsonPath(data, strings.Split(path, "."))
+	}
+
+	normalized, err := json.Marshal(data)
+	if err != nil {
+		return body, fmt.Errorf("failed to normalize response body: %w", err)
+	}
+	return string(normalized), nil
+}
+
+// deleteJsonPath removes the value at a dot-path from decoded JSON. Segments use the same
+// syntax as json_path ("items[0].id") and additionally accept [*] to match every array element.
+// Missing paths are ignored.
+func deleteJsonPath(data interface{}, parts []string) interface{} {
+	if len(parts) == 0 {
+		return data
+	}
+
+	key, index, hasIndex := splitPathSegment(parts[0])
+	last := len(parts) == 1
+
+	if !hasIndex {
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return data
+		}
+		if last {
+			delete(object, key)
+		} else if child, exists := object[key]; exists {
+			object[key] = deleteJsonPath(child, parts[1:])
+		}
+		return data
+	}
+
+	// Navigate to the array first when the segment has a key
+	if key != "" {
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return data
+		}
+		child, exists := object[key]
+		if !exists {
+			return data
+		}
+		object[key] = deleteFromArray(child, index, parts[1:], last)
+		return data
+	}
+	return deleteFromArray(data, index, parts[1:], last)
+}
+
+// deleteFromArray applies deleteJsonPath to array elements selected by index ("*" selects all)
+func deleteFromArray(data interface{}, index string, rest []string, last bool) interface{} {
+	array, ok := data.([]interface{})
+	if !ok {
+		return data
+	}
+
+	if index == "*" {
+		if last {
+			return []interface{}{}
+		}
+		for i := range array {
+			array[i] = deleteJsonPath(array[i], rest)
+		}
+		return array
+	}
+
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= len(array) {
+		return data
+	}
+	if last {
+		return append(array[:i:i], array[i+1:]...)
+	}
+	array[i] = deleteJsonPath(array[i], rest)
+	return array
+}
+
+// splitPathSegment splits "items[0]" into its key and index
+func splitPathSegment(segment string) (string, string, bool) {
+	open := strings.Index(segment, "[")
+	if open == -1 || !strings.HasSuffix(segment, "]") {
+		return segment, "", false
+	}
+	return segment[:open], segment[open+1 : len(segment)-1], true
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeResponseBody(t *testing.T) {
+	ctx := context.Background()
+	body := `{"name": "svc", "id": 12345678901234567890, "meta": {"request_id": "r-1", "region": "eu"}, "items": [{"id": "a", "updated_at": "t1"}, {"id": "b", "updated_at": "t2"}]}`
+
+	paths := func(values ...string) types.List {
+		elems := make([]attr.Value, 0, len(values))
+		for _, v := range values {
+			elems = append(elems, types.StringValue(v))
+		}
+		return types.ListValueMust(types.StringType, elems)
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		normalize   types.Bool
+		ignorePaths types.List
+		expected    string
+	}{
+		{
+			name:        "unchanged by default",
+			body:        body,
+			normalize:   types.BoolNull(),
+			ignorePaths: types.ListNull(types.StringType),
+			expected:    body,
+		},
+		{
+			name:        "sorted keys",
+			body:        `{"b": 1, "a": {"d": true, "c": null}}`,
+			normalize:   types.BoolValue(true),
+			ignorePaths: types.ListNull(types.StringType),
+			expected:    `{"a":{"c":null,"d":true},"b":1}`,
+		},
+		{
+			name:        "ignored paths",
+			body:        body,
+			normalize:   types.BoolNull(),
+			ignorePaths: paths("meta.request_id", "items[*].updated_at", "missing.path"),
+			expected:    `{"id":12345678901234567890,"items":[{"id":"a"},{"id":"b"}],"meta":{"region":"eu"},"name":"svc"}`,
+		},
+		{
+			name:        "array element",
+			body:        `{"items": ["a", "b", "c"]}`,
+			normalize:   types.BoolNull(),
+			ignorePaths: paths("items[1]"),
+			expected:    `{"items":["a","c"]}`,
+		},
+		{
+			name:        "not JSON",
+			body:        "plain text",
+			normalize:   types.BoolValue(true),
+			ignorePaths: paths("id"),
+			expected:    "plain text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeResponseBody(ctx, tt.body, tt.normalize, tt.ignorePaths)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
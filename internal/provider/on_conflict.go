@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// defaultConflictStatusCodes are the statuses on_conflict matches when status_codes is unset
+var defaultConflictStatusCodes = []int64{http.StatusConflict}
+
+// applyOnConflict executes the on_conflict request when result matches it, so the resource can
+// get an object that already exists instead of failing to create it. The alternate request's
+// response replaces result and feeds extraction. Results that don't match are returned
+// unchanged with accepted = false.
+func applyOnConflict(ctx context.Context, conflict *OnConflictModel, httpReq *http.Request, execConfig *ProviderConfig, retryConfig *RetryConfig, result *ResponseResult, execErr error) (*ResponseResult, bool, error) {
+	if conflict == nil {
+		return result, false, execErr
+	}
+	matched, err := responseMatches(ctx, "on_conflict", conflict.StatusCodes, defaultConflictStatusCodes, conflict.JsonPathEquals, result)
+	if err != nil {
+		return result, false, err
+	}
+	if !matched {
+		return result, false, execErr
+	}
+
+	if conflict.Url.IsNull() || conflict.Url.IsUnknown() || conflict.Url.ValueString() == "" {
+		return result, true, fmt.Errorf("on_conflict.url is required")
+	}
+	method := http.MethodGet
+	if !conflict.Method.IsNull() && !conflict.Method.IsUnknown() && conflict.Method.ValueString() != "" {
+		method = strings.ToUpper(conflict.Method.ValueString())
+	}
+	headers, err := ConvertTerraformMap(ctx, conflict.RequestHeaders)
+	if err != nil {
+		return result, true, fmt.Errorf("invalid on_conflict.request_headers: %w", err)
+	}
+	query, err := ConvertTerraformMap(ctx, conflict.Query)
+	if err != nil {
+		return result, true, fmt.Errorf("invalid on_conflict.query: %w", err)
+	}
+
+	alternate, err := followUpRequest(ctx, httpReq, execConfig, retryConfig, result, method, conflict.Url.ValueString(), headers, query, conflict.Body.ValueString())
+	if err != nil {
+		return alternate, true, fmt.Errorf("on_conflict %w", err)
+	}
+	return alternate, true, nil
+}
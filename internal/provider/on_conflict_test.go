@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyOnConflict(t *testing.T) {
+	var lookup *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error": "name taken"}`))
+		case r.URL.Path == "/items" && r.URL.Query().Get("name") == "example":
+			lookup = r
+			_, _ = w.Write([]byte(`{"id": "abc", "name": "example"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	execConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}
+	retryConfig := &RetryConfig{Attempts: 1}
+	create := func() (*http.Request, *ResponseResult) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/items", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("Content-Type", "application/json")
+		result, err := ExecuteRequestWithRetry(ctx, req, execConfig, retryConfig, nil, nil)
+		assert.NoError(t, err)
+		return req, result
+	}
+	conflict := &OnConflictModel{
+		StatusCodes:    types.ListNull(types.Int64Type),
+		JsonPathEquals: types.MapNull(types.StringType),
+		Url:            types.StringValue("/items"),
+		Method:         types.StringNull(),
+		RequestHeaders: types.MapValueMust(types.StringType, map[string]attr.Value{"Accept": types.StringValue("application/json")}),
+		Query:          types.MapValueMust(types.StringType, map[string]attr.Value{"name": types.StringValue("example")}),
+		Body:           types.StringNull(),
+	}
+
+	// A 409 is a conflict by default, and the lookup's response replaces it
+	req, result := create()
+	got, accepted, err := applyOnConflict(ctx, conflict, req, execConfig, retryConfig, result, nil)
+	assert.True(t, accepted)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200), got.StatusCode)
+	assert.Equal(t, `{"id": "abc", "name": "example"}`, got.Body)
+	if assert.NotNil(t, lookup) {
+		assert.Equal(t, http.MethodGet, lookup.Method)
+		assert.Equal(t, "Bearer token", lookup.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", lookup.Header.Get("Accept"))
+		assert.Empty(t, lookup.Header.Get("Content-Type"))
+	}
+
+	// Other statuses are left alone
+	other := *conflict
+	other.StatusCodes = types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(412)})
+	req, result = create()
+	got, accepted, err = applyOnConflict(ctx, &other, req, execConfig, retryConfig, result, nil)
+	assert.False(t, accepted)
+	assert.NoError(t, err)
+	assert.Same(t, result, got)
+
+	other = *conflict
+	other.Query = types.MapNull(types.StringType)
+	req, result = create()
+	_, accepted, err = applyOnConflict(ctx, &other, req, execConfig, retryConfig, result, nil)
+	assert.True(t, accepted)
+	assert.EqualError(t, err, "on_conflict GET returned status 404")
+
+	// treat_as_success is checked first
+	req, result = create()
+	model := &HttpxRequestResourceModel{
+		TreatAsSuccess: &TreatAsSuccessModel{StatusCodes: types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(409)})},
+		OnConflict:     conflict,
+	}
+	got, accepted, err = acceptErrorResponse(ctx, model, req, execConfig, retryConfig, result, nil)
+	assert.True(t, accepted)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(409), got.StatusCode)
+}
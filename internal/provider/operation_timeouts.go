@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Operation timeouts applied when the timeouts block doesn't set one. They bound the request
+// including retries, so they are generous enough for retry_until polling.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// validateTimeouts rejects timeouts that aren't positive, so they fail the plan like the
+// unparseable durations the timeouts block's own validators reject
+func validateTimeouts(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, operation := range []string{"create", "read", "update", "delete"} {
+		p := path.Root("timeouts").AtName(operation)
+		var value types.String
+		if getDiags := config.GetAttribute(ctx, p, &value); getDiags.HasError() {
+			diags.Append(getDiags...)
+			return diags
+		}
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		timeout, err := time.ParseDuration(value.ValueString())
+		if err == nil && timeout <= 0 {
+			diags.AddAttributeError(p, "Invalid timeout", fmt.Sprintf("timeouts.%s must be positive, got %q", operation, value.ValueString()))
+		}
+	}
+	return diags
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTimeouts(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	timeoutsType := objectType.AttributeTypes["timeouts"].(tftypes.Object)
+
+	validate := func(durations map[string]string) []string {
+		set := map[string]tftypes.Value{}
+		if durations != nil {
+			values := map[string]tftypes.Value{}
+			for name := range timeoutsType.AttributeTypes {
+				duration, ok := durations[name]
+				if !ok {
+					values[name] = tftypes.NewValue(tftypes.String, nil)
+					continue
+				}
+				values[name] = tftypes.NewValue(tftypes.String, duration)
+			}
+			set["timeouts"] = tftypes.NewValue(timeoutsType, values)
+		}
+		config := tfsdk.Config{Schema: schemaResp.Schema, Raw: nullObject(objectType, set)}
+		var details []string
+		for _, d := range validateTimeouts(ctx, config) {
+			details = append(details, d.Detail())
+		}
+		return details
+	}
+
+	assert.Empty(t, validate(nil))
+	assert.Empty(t, validate(map[string]string{"create": "45m", "update": "90s"}))
+	// Unparseable durations are left to the timeouts block's validators
+	assert.Empty(t, validate(map[string]string{"delete": "soon"}))
+	assert.Equal(t, []string{`timeouts.create must be positive, got "0s"`, `timeouts.read must be positive, got "-5m"`},
+		validate(map[string]string{"create": "0s", "read": "-5m"}))
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Pagination modes for paginate.mode
+const (
+	paginateModeLinkHeader = "link_header"
+	paginateModeJsonCursor = "json_cursor"
+	paginateModePageParam  = "page_param"
+)
+
+// Pagination defaults
+const (
+	defaultPaginateMaxPages    = 10
+	defaultPaginateCursorParam = "cursor"
+	defaultPaginatePageParam   = "page"
+)
+
+// paginationConfig is the resolved form of a paginate block
+type paginationConfig struct {
+	mode        string
+	maxPages    int64
+	itemPath    string
+	cursorPath  string
+	cursorParam string
+	pageParam   string
+}
+
+// buildPaginationConfig validates a paginate block and applies defaults
+func buildPaginationConfig(paginate *PaginateModel) (*paginationConfig, error) {
+	cfg := &paginationConfig{
+		mode:        paginate.Mode.ValueString(),
+		maxPages:    defaultPaginateMaxPages,
+		itemPath:    paginate.ItemPath.ValueString(),
+		cursorPath:  paginate.CursorPath.ValueString(),
+		cursorParam: defaultPaginateCursorParam,
+		pageParam:   defaultPaginatePageParam,
+	}
+
+	if !paginate.MaxPages.IsNull() && !paginate.MaxPages.IsUnknown() {
+		cfg.maxPages = paginate.MaxPages.ValueInt64()
+		if cfg.maxPages < 1 {
+			return nil, fmt.Errorf("max_pages must be at least 1, got %d", cfg.maxPages)
+		}
+	}
+	if !paginate.CursorParam.IsNull() && paginate.CursorParam.ValueString() != "" {
+		cfg.cursorParam = paginate.CursorParam.ValueString()
+	}
+	if !paginate.PageParam.IsNull() && paginate.PageParam.ValueString() != "" {
+		cfg.pageParam = paginate.PageParam.ValueString()
+	}
+
+	switch cfg.mode {
+	case paginateModeLinkHeader, paginateModePageParam:
+	case paginateModeJsonCursor:
+		if cfg.cursorPath == "" {
+			return nil, fmt.Errorf("cursor_path is required when mode is %q", paginateModeJsonCursor)
+		}
+	default:
+		return nil, fmt.Errorf("mode must be 'link_header', 'json_cursor' or 'page_param', got %q", cfg.mode)
+	}
+
+	return cfg, nil
+}
+
+// CollectPaginatedItems follows pagination starting from the first response and returns the
+// items of every page merged into a single JSON array. execute sends a follow-up page request.
+func CollectPaginatedItems(ctx context.Context, firstReq *http.Request, first *ResponseResult, paginate *PaginateModel, execute func(*http.Request) (*ResponseResult, error)) (string, error) {
+	cfg, err := buildPaginationConfig(paginate)
+	if err != nil {
+		return "", err
+	}
+
+	items, err := pageItems(first.Body, cfg.itemPath)
+	if err != nil {
+		return "", fmt.Errorf("page 1: %w", err)
+	}
+
+	currentReq, current := firstReq, first
+	lastCount := len(items)
+	startPage := int64(1)
+	if value := firstReq.URL.Query().Get(cfg.pageParam); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			startPage = parsed
+		}
+	}
+
+	for page := int64(2); page <= cfg.maxPages; page++ {
+		var nextURL *url.URL
+		switch cfg.mode {
+		case paginateModeLinkHeader:
+			next, ok := parseLinkHeader(current.Headers["Link"])["next"]
+			if !ok {
+				break
+			}
+			nextURL, err = currentReq.URL.Parse(next)
+			if err != nil {
+				return "", fmt.Errorf("page %d: invalid next link %q: %w", page, next, err)
+			}
+		case paginateModeJsonCursor:
+			cursor := nextCursor(current.Body, cfg.cursorPath)
+			if cursor == "" {
+				break
+			}
+			nextURL = withQueryParam(firstReq.URL, cfg.cursorParam, cursor)
+		case paginateModePageParam:
+			if lastCount == 0 {
+				break
+			}
+			nextURL = withQueryParam(firstReq.URL, cfg.pageParam, strconv.FormatInt(startPage+page-1, 10))
+		}
+		if nextURL == nil {
+			break
+		}
+
+		nextReq, err := cloneRequestWithURL(firstReq, nextURL)
+		if err != nil {
+			return "", fmt.Errorf("page %d: %w", page, err)
+		}
+
+		tflog.Debug(ctx, "Fetching next page", map[string]interface{}{
+			"page": page,
+		})
+
+		result, err := execute(nextReq)
+		if err != nil {
+			return "", fmt.Errorf("page %d: %w", page, err)
+		}
+		if result.StatusCode >= 400 {
+			return "", fmt.Errorf("page %d: unexpected status code %d", page, result.StatusCode)
+		}
+
+		pageItemsList, err := pageItems(result.Body, cfg.itemPath)
+		if err != nil {
+			return "", fmt.Errorf("page %d: %w", page, err)
+		}
+		items = append(items, pageItemsList...)
+		currentReq, current, lastCount = nextReq, result, len(pageItemsList)
+	}
+
+	merged, err := json.Marshal(items)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode paginated items: %w", err)
+	}
+	return string(merged), nil
+}
+
+// pageItems returns the array at itemPath in a JSON body (the body itself when itemPath is empty)
+func pageItems(body string, itemPath string) ([]interface{}, error) {
+	data, err := decodeJSON(body)
+	if err != nil {
+		return nil, fmt.Errorf("response body is not valid JSON: %w", err)
+	}
+
+	if itemPath != "" {
+		data, err = evaluateJsonPath(data, itemPath)
+		if err != nil {
+			return nil, fmt.Errorf("item_path %q: %w", itemPath, err)
+		}
+	}
+
+	switch items := data.(type) {
+	case []interface{}:
+		return items, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("item_path %q does not resolve to an array", itemPath)
+	}
+}
+
+// nextCursor returns the cursor at cursorPath, or "" when the last page was reached
+func nextCursor(body string, cursorPath string) string {
+	data, err := decodeJSON(body)
+	if err != nil {
+		return ""
+	}
+	value, err := evaluateJsonPath(data, cursorPath)
+	if err != nil || value == nil {
+		return ""
+	}
+	if b, ok := value.(bool); ok && !b {
+		return ""
+	}
+	return formatExtractedValue(value)
+}
+
+// withQueryParam returns a copy of u with the query parameter set
+func withQueryParam(u *url.URL, name string, value string) *url.URL {
+	next := *u
+	query := next.Query()
+	query.Set(name, value)
+	next.RawQuery = query.Encode()
+	return &next
+}
+
+// cloneRequestWithURL copies a request for a different URL, replaying its body if it has one
+func cloneRequestWithURL(req *http.Request, u *url.URL) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	next.URL = u
+	if req.Host == "" || req.Host == req.URL.Host {
+		next.Host = u.Host
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy request body: %w", err)
+		}
+		next.Body = body
+	}
+	return next, nil
+}
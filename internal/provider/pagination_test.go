@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectPaginatedItems(t *testing.T) {
+	// Three pages of two items each: [1,2], [3,4], [5,6]
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		switch r.URL.Path {
+		case "/link":
+			if p := r.URL.Query().Get("p"); p != "" {
+				page, _ = strconv.Atoi(p)
+			}
+			if page < 3 {
+				w.Header().Set("Link", fmt.Sprintf(`</link?p=%d>; rel="next", </link?p=1>; rel="first"`, page+1))
+			}
+			_, _ = fmt.Fprintf(w, `{"data":{"items":[%d,%d]}}`, page*2-1, page*2)
+		case "/cursor":
+			if c := r.URL.Query().Get("after"); c != "" {
+				page, _ = strconv.Atoi(c)
+			}
+			next := "null"
+			if page < 3 {
+				next = strconv.Quote(strconv.Itoa(page + 1))
+			}
+			_, _ = fmt.Fprintf(w, `{"items":[%d,%d],"next":%s}`, page*2-1, page*2, next)
+		case "/page":
+			page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+			if page > 3 {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `[%d,%d]`, page*2-1, page*2)
+		}
+	}))
+	defer server.Close()
+
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576}
+	execute := func(req *http.Request) (*ResponseResult, error) {
+		return ExecuteRequest(context.Background(), req, providerConfig)
+	}
+	collect := func(t *testing.T, url string, paginate *PaginateModel) (string, error) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+		assert.NoError(t, err)
+		first, err := execute(req)
+		assert.NoError(t, err)
+		return CollectPaginatedItems(context.Background(), req, first, paginate, execute)
+	}
+
+	t.Run("link_header", func(t *testing.T) {
+		items, err := collect(t, server.URL+"/link", &PaginateModel{Mode: types.StringValue("link_header"), ItemPath: types.StringValue("data.items")})
+		assert.NoError(t, err)
+		assert.Equal(t, `[1,2,3,4,5,6]`, items)
+	})
+
+	t.Run("json_cursor", func(t *testing.T) {
+		items, err := collect(t, server.URL+"/cursor", &PaginateModel{
+			Mode:        types.StringValue("json_cursor"),
+			ItemPath:    types.StringValue("items"),
+			CursorPath:  types.StringValue("next"),
+			CursorParam: types.StringValue("after"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `[1,2,3,4,5,6]`, items)
+	})
+
+	t.Run("page_param stops at the first empty page", func(t *testing.T) {
+		items, err := collect(t, server.URL+"/page?page=1", &PaginateModel{Mode: types.StringValue("page_param")})
+		assert.NoError(t, err)
+		assert.Equal(t, `[1,2,3,4,5,6]`, items)
+	})
+
+	t.Run("max_pages", func(t *testing.T) {
+		items, err := collect(t, server.URL+"/page?page=1", &PaginateModel{Mode: types.StringValue("page_param"), MaxPages: types.Int64Value(2)})
+		assert.NoError(t, err)
+		assert.Equal(t, `[1,2,3,4]`, items)
+	})
+
+	t.Run("item_path must resolve to an array", func(t *testing.T) {
+		_, err := collect(t, server.URL+"/link", &PaginateModel{Mode: types.StringValue("link_header"), ItemPath: types.StringValue("data")})
+		assert.ErrorContains(t, err, "does not resolve to an array")
+	})
+}
+
+func TestBuildPaginationConfig(t *testing.T) {
+	_, err := buildPaginationConfig(&PaginateModel{Mode: types.StringValue("offset")})
+	assert.ErrorContains(t, err, "mode must be")
+
+	_, err = buildPaginationConfig(&PaginateModel{Mode: types.StringValue("json_cursor")})
+	assert.ErrorContains(t, err, "cursor_path is required")
+
+	_, err = buildPaginationConfig(&PaginateModel{Mode: types.StringValue("link_header"), MaxPages: types.Int64Value(0)})
+	assert.ErrorContains(t, err, "max_pages must be at least 1")
+
+	cfg, err := buildPaginationConfig(&PaginateModel{Mode: types.StringValue("page_param")})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(defaultPaginateMaxPages), cfg.maxPages)
+	assert.Equal(t, "page", cfg.pageParam)
+}
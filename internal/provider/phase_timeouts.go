@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// WithPhaseTimeouts returns a copy of the provider config with per-resource connect, TLS
+// handshake and response header timeouts applied. Unset values keep the provider setting.
+func (p *ProviderConfig) WithPhaseTimeouts(connect types.Int64, tlsHandshake types.Int64, responseHeader types.Int64) (*ProviderConfig, error) {
+	cfg := *p
+	for _, timeout := range []struct {
+		name   string
+		value  types.Int64
+		target *int64
+	}{
+		{"connect_timeout_ms", connect, &cfg.ConnectTimeoutMs},
+		{"tls_handshake_timeout_ms", tlsHandshake, &cfg.TLSHandshakeTimeoutMs},
+		{"response_header_timeout_ms", responseHeader, &cfg.ResponseHeaderTimeoutMs},
+	} {
+		if timeout.value.IsNull() || timeout.value.IsUnknown() {
+			continue
+		}
+		if err := validatePhaseTimeout(timeout.name, timeout.value.ValueInt64()); err != nil {
+			return nil, err
+		}
+		*timeout.target = timeout.value.ValueInt64()
+	}
+	return &cfg, nil
+}
+
+// validatePhaseTimeout checks a connect, TLS handshake or response header timeout
+func validatePhaseTimeout(name string, value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("%s must be positive, got %d", name, value)
+	}
+	return nil
+}
+
+// int64OrZero returns *value, or 0 when it is not set
+func int64OrZero(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
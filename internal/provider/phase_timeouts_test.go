@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderConfig_WithPhaseTimeouts(t *testing.T) {
+	base := &ProviderConfig{ConnectTimeoutMs: 1000, TLSHandshakeTimeoutMs: 2000}
+
+	cfg, err := base.WithPhaseTimeouts(types.Int64Null(), types.Int64Value(500), types.Int64Value(3000))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), cfg.ConnectTimeoutMs)
+	assert.Equal(t, int64(500), cfg.TLSHandshakeTimeoutMs)
+	assert.Equal(t, int64(3000), cfg.ResponseHeaderTimeoutMs)
+	assert.Equal(t, int64(2000), base.TLSHandshakeTimeoutMs, "provider config is not modified")
+
+	_, err = base.WithPhaseTimeouts(types.Int64Value(0), types.Int64Null(), types.Int64Null())
+	assert.ErrorContains(t, err, "connect_timeout_ms must be positive")
+
+	assert.Equal(t, int64(0), base.ToConfigProviderConfig().ResponseHeaderTimeoutMs)
+	assert.Equal(t, int64(3000), cfg.ToConfigProviderConfig().ResponseHeaderTimeoutMs)
+}
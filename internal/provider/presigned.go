@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// errPresignedURLExpired is returned when a presigned URL has expired before the request is sent
+var errPresignedURLExpired = errors.New("presigned URL has expired")
+
+// presignedURLExpiry returns when a presigned URL stops being valid and the parameters that
+// said so. ok is false when the URL carries no recognizable expiry parameters; values that
+// don't parse are left for the server to reject.
+// Recognized forms:
+//   - AWS SigV4: X-Amz-Date plus X-Amz-Expires seconds
+//   - Google Cloud Storage V4: X-Goog-Date plus X-Goog-Expires seconds
+//   - Azure SAS: se (signed expiry), or ExpiresOn
+//   - AWS SigV2 and CloudFront: Expires as Unix seconds
+func presignedURLExpiry(u *url.URL) (expiry time.Time, source string, ok bool) {
+	q := u.Query()
+
+	for _, prefix := range []string{"X-Amz-", "X-Goog-"} {
+		date, expires := q.Get(prefix+"Date"), q.Get(prefix+"Expires")
+		if date == "" || expires == "" {
+			continue
+		}
+		signed, err := time.Parse("20060102T150405Z", date)
+		if err != nil {
+			continue
+		}
+		seconds, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil || seconds < 0 {
+			continue
+		}
+		return signed.Add(time.Duration(seconds) * time.Second), prefix + "Date + " + prefix + "Expires", true
+	}
+
+	for _, name := range []string{"se", "ExpiresOn"} {
+		value := q.Get(name)
+		if value == "" || (name == "se" && q.Get("sig") == "") {
+			continue
+		}
+		if expiry, ok := parseSASTime(value); ok {
+			return expiry, name, true
+		}
+	}
+
+	if value := q.Get("Expires"); value != "" && (q.Get("Signature") != "" || q.Get("AWSAccessKeyId") != "") {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC(), "Expires", true
+		}
+	}
+
+	return time.Time{}, "", false
+}
+
+// parseSASTime parses an Azure SAS timestamp, which may omit seconds
+func parseSASTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// checkPresignedURL fails when u is a presigned URL that has already expired at now, so the
+// request fails with a clear message instead of a 403 from inside the retry loop
+func checkPresignedURL(u *url.URL, now time.Time) error {
+	expiry, source, ok := presignedURLExpiry(u)
+	if !ok || now.Before(expiry) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s://%s%s expired at %s (from %s), %s ago; generate a new URL",
+		errPresignedURLExpired, u.Scheme, u.Host, u.Path, expiry.UTC().Format(time.RFC3339), source,
+		now.Sub(expiry).Truncate(time.Second))
+}
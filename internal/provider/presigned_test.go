@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresignedURLExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		url     string
+		ok      bool
+		expired bool
+	}{
+		{"sigv4 valid", "https://bucket.s3.amazonaws.com/key?X-Amz-Date=20260301T113000Z&X-Amz-Expires=3600&X-Amz-Signature=abc", true, false},
+		{"sigv4 expired", "https://bucket.s3.amazonaws.com/key?X-Amz-Date=20260301T100000Z&X-Amz-Expires=3600&X-Amz-Signature=abc", true, true},
+		{"gcs expired", "https://storage.googleapis.com/b/o?X-Goog-Date=20260228T120000Z&X-Goog-Expires=600", true, true},
+		{"azure sas expired", "https://acct.blob.core.windows.net/c/b?sv=2022-11-02&se=2026-03-01T11:00Z&sig=abc", true, true},
+		{"azure sas valid", "https://acct.blob.core.windows.net/c/b?se=2026-03-02T00:00:00Z&sig=abc", true, false},
+		{"se without signature", "https://example.com/search?se=2020-01-01", false, false},
+		{"ExpiresOn expired", "https://example.com/file?ExpiresOn=2026-02-01T00:00:00Z", true, true},
+		{"sigv2 expired", "https://bucket.s3.amazonaws.com/key?AWSAccessKeyId=AKIA&Expires=1700000000&Signature=abc", true, true},
+		{"Expires without signature", "https://example.com/cache?Expires=1700000000", false, false},
+		{"unparseable date", "https://bucket.s3.amazonaws.com/key?X-Amz-Date=yesterday&X-Amz-Expires=3600", false, false},
+		{"plain url", "https://api.example.com/items?page=2", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			assert.NoError(t, err)
+
+			_, _, ok := presignedURLExpiry(u)
+			assert.Equal(t, tt.ok, ok)
+
+			err = checkPresignedURL(u, now)
+			assert.Equal(t, tt.expired, errors.Is(err, errPresignedURLExpired))
+		})
+	}
+}
+
+func TestBuildRequest_ExpiredPresignedURL(t *testing.T) {
+	_, err := BuildRequest(context.Background(), &RequestConfig{
+		Url:    "https://bucket.s3.amazonaws.com/key?X-Amz-Date=20200101T000000Z&X-Amz-Expires=900&X-Amz-Signature=s3cr3t",
+		Method: "GET",
+	})
+	assert.ErrorIs(t, err, errPresignedURLExpired)
+	assert.Contains(t, err.Error(), "https://bucket.s3.amazonaws.com/key expired at 2020-01-01T00:15:00Z")
+	assert.NotContains(t, err.Error(), "s3cr3t")
+}
+
+func TestReadKeepsStateWhenPresignedURLExpired(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	nullAttributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		nullAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	var model HttpxRequestResourceModel
+	assert.False(t, tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nullAttributes)}.Get(ctx, &model).HasError())
+	model.Url = types.StringValue("https://bucket.s3.amazonaws.com/key?X-Amz-Date=20200101T000000Z&X-Amz-Expires=900&X-Amz-Signature=abc")
+	model.Method = types.StringValue("GET")
+	model.ReadMode = types.StringValue("refresh")
+	model.Id = types.StringValue("abc123")
+	model.StatusCode = types.Int64Value(200)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	assert.False(t, state.Set(ctx, &model).HasError())
+
+	r := &HttpxRequestResource{config: &ProviderConfig{}}
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.Equal(t, 1, resp.Diagnostics.WarningsCount())
+	var refreshed HttpxRequestResourceModel
+	assert.False(t, resp.State.Get(ctx, &refreshed).HasError())
+	assert.Equal(t, int64(200), refreshed.StatusCode.ValueInt64())
+	assert.Equal(t, "abc123", refreshed.Id.ValueString())
+}
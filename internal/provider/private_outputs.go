@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// privateOutputsKey is the private state key holding a resource's private_outputs
+const privateOutputsKey = "private_outputs"
+
+// sharedOutputsKey is the private state key holding the shared outputs a resource's
+// depends_on_outputs last resolved to
+const sharedOutputsKey = "shared_outputs"
+
+// privateStateReader and privateStateWriter are the parts of the framework's private state used
+// here, implemented by the Private fields of resource requests and responses
+type privateStateReader interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateWriter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// sharedContext holds the private_outputs published by resources during this provider run,
+// keyed by shared_context name, for depends_on_outputs of later resources
+type sharedContext struct {
+	mu     sync.Mutex
+	values map[string]map[string]string
+}
+
+func newSharedContext() *sharedContext {
+	return &sharedContext{values: make(map[string]map[string]string)}
+}
+
+func (s *sharedContext) publish(name string, outputs map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[name] = outputs
+}
+
+// snapshot copies the published outputs for interpolation
+func (s *sharedContext) snapshot() map[string]map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make(map[string]map[string]string, len(s.values))
+	for name, outputs := range s.values {
+		values[name] = outputs
+	}
+	return values
+}
+
+// storePrivateOutputs moves the outputs named in private_outputs out of outputs into private
+// state, and publishes them under shared_context. Values are encrypted with state_encryption_key
+// when it is set.
+func storePrivateOutputs(ctx context.Context, config *ProviderConfig, model *HttpxRequestResourceModel, outputs map[string]attr.Value, private privateStateWriter) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if model.PrivateOutputs.IsNull() || model.PrivateOutputs.IsUnknown() {
+		return diags
+	}
+
+	names, err := ConvertTerraformList(ctx, model.PrivateOutputs, func(v interface{}) (string, error) {
+		if strVal, ok := v.(types.String); ok {
+			return strVal.ValueString(), nil
+		}
+		return "", fmt.Errorf("expected string, got %T", v)
+	})
+	if err != nil {
+		diags.AddError("Invalid private_outputs", err.Error())
+		return diags
+	}
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		if value, ok := outputs[name]; ok {
+			if strVal, ok := value.(types.String); ok {
+				values[name] = strVal.ValueString()
+			}
+			delete(outputs, name)
+		}
+	}
+
+	data, err := encodePrivateValue(config, values)
+	if err != nil {
+		diags.AddError("Failed to store private_outputs", err.Error())
+		return diags
+	}
+	diags.Append(private.SetKey(ctx, privateOutputsKey, data)...)
+
+	publishPrivateOutputs(config, model, values)
+	return diags
+}
+
+// loadPrivateOutputs publishes the private_outputs kept in private state, for refreshes that
+// don't re-execute the request
+func loadPrivateOutputs(ctx context.Context, config *ProviderConfig, model *HttpxRequestResourceModel, private privateStateReader) diag.Diagnostics {
+	values, diags := readPrivateOutputs(ctx, config, private)
+	if values != nil {
+		publishPrivateOutputs(config, model, values)
+	}
+	return diags
+}
+
+// readPrivateOutputs returns the private_outputs kept in private state, or nil when there are none
+func readPrivateOutputs(ctx context.Context, config *ProviderConfig, private privateStateReader) (map[string]string, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, privateOutputsKey)
+	if diags.HasError() || len(data) == 0 {
+		return nil, diags
+	}
+
+	values := make(map[string]string)
+	if err := decodePrivateValue(config, data, &values); err != nil {
+		diags.AddError("Failed to read private_outputs", err.Error())
+		return nil, diags
+	}
+	return values, diags
+}
+
+// addPrivateOutputs adds the private_outputs kept in private state to the outputs of interpolCtx,
+// so on_destroy templates can reference them like any other output. Outputs already in
+// interpolCtx, e.g. from a pre-destroy refresh, take precedence.
+func addPrivateOutputs(ctx context.Context, config *ProviderConfig, interpolCtx *InterpolationContext, private privateStateReader) diag.Diagnostics {
+	values, diags := readPrivateOutputs(ctx, config, private)
+	for name, value := range values {
+		if _, ok := interpolCtx.Outputs[name]; !ok {
+			interpolCtx.Outputs[name] = value
+		}
+	}
+	return diags
+}
+
+// encodePrivateValue marshals value for private state, encrypted with state_encryption_key when
+// it is set
+func encodePrivateValue(config *ProviderConfig, value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil || config == nil || config.StateCipher == nil {
+		return data, err
+	}
+	return json.Marshal(config.StateCipher.encrypt(string(data)))
+}
+
+// decodePrivateValue unmarshals data stored by encodePrivateValue into value
+func decodePrivateValue(config *ProviderConfig, data []byte, value interface{}) error {
+	var encrypted string
+	if json.Unmarshal(data, &encrypted) == nil {
+		if config == nil || config.StateCipher == nil {
+			return fmt.Errorf("the value was encrypted, but state_encryption_key is not set")
+		}
+		plain, err := config.StateCipher.decrypt(encrypted)
+		if err != nil {
+			return err
+		}
+		data = []byte(plain)
+	}
+	return json.Unmarshal(data, value)
+}
+
+func publishPrivateOutputs(config *ProviderConfig, model *HttpxRequestResourceModel, values map[string]string) {
+	if config == nil || config.SharedContext == nil || model.SharedContext.IsNull() || model.SharedContext.IsUnknown() {
+		return
+	}
+	config.SharedContext.publish(model.SharedContext.ValueString(), values)
+}
+
+// applyDependsOnOutputs sets the depends_on_outputs headers on req, expanding
+// ${shared.<shared_context>.<output>} references to outputs published by other resources. Outputs
+// not published in this provider run come from the copy kept in private, since a producer that
+// doesn't change isn't applied again. It returns the shared outputs referenced, for
+// storeSharedOutputs.
+func applyDependsOnOutputs(ctx context.Context, req *http.Request, dependsOn types.Map, config *ProviderConfig, private privateStateReader) (map[string]map[string]string, error) {
+	if dependsOn.IsNull() || dependsOn.IsUnknown() {
+		return nil, nil
+	}
+	headers, err := ConvertTerraformMap(ctx, dependsOn)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := resolveSharedOutputs(ctx, headers, config, private)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, err := InterpolateMap(ctx, headers, &InterpolationContext{Shared: shared})
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range expanded {
+		req.Header.Set(name, value)
+	}
+	return shared, nil
+}
+
+// resolveSharedOutputs returns the outputs of the shared contexts referenced by headers, those
+// published in this provider run taking precedence over the copy in private
+func resolveSharedOutputs(ctx context.Context, headers map[string]string, config *ProviderConfig, private privateStateReader) (map[string]map[string]string, error) {
+	available := map[string]map[string]string{}
+	if private != nil {
+		data, diags := private.GetKey(ctx, sharedOutputsKey)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to read shared outputs from private state")
+		}
+		if len(data) > 0 {
+			if err := decodePrivateValue(config, data, &available); err != nil {
+				return nil, fmt.Errorf("failed to read shared outputs from private state: %w", err)
+			}
+		}
+	}
+	if config != nil && config.SharedContext != nil {
+		for name, outputs := range config.SharedContext.snapshot() {
+			available[name] = outputs
+		}
+	}
+
+	shared := map[string]map[string]string{}
+	for name, outputs := range available {
+		for _, value := range headers {
+			if strings.Contains(value, "${shared."+name+".") {
+				shared[name] = outputs
+				break
+			}
+		}
+	}
+	return shared, nil
+}
+
+// storeSharedOutputs keeps the shared outputs returned by applyDependsOnOutputs in private state,
+// encrypted with state_encryption_key when it is set
+func storeSharedOutputs(ctx context.Context, config *ProviderConfig, shared map[string]map[string]string, private privateStateWriter) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(shared) == 0 {
+		return diags
+	}
+	data, err := encodePrivateValue(config, shared)
+	if err != nil {
+		diags.AddError("Failed to store shared outputs", err.Error())
+		return diags
+	}
+	diags.Append(private.SetKey(ctx, sharedOutputsKey, data)...)
+	return diags
+}
+
+// planSharedOutputs keeps the shared outputs dependsOn resolves to at plan time in the planned
+// private state, for an update in an apply where the producer doesn't change and so doesn't
+// publish them
+func planSharedOutputs(ctx context.Context, config *ProviderConfig, dependsOn types.Map, private privateStateReader, planned privateStateWriter) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if dependsOn.IsNull() || dependsOn.IsUnknown() {
+		return diags
+	}
+	headers, err := ConvertTerraformMap(ctx, dependsOn)
+	if err != nil {
+		diags.AddAttributeError(path.Root("depends_on_outputs"), "Invalid depends_on_outputs", err.Error())
+		return diags
+	}
+	shared, err := resolveSharedOutputs(ctx, headers, config, private)
+	if err != nil {
+		diags.AddAttributeError(path.Root("depends_on_outputs"), "Invalid depends_on_outputs", err.Error())
+		return diags
+	}
+	return storeSharedOutputs(ctx, config, shared, planned)
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePrivateState stands in for the framework's private state
+type fakePrivateState map[string][]byte
+
+func (f fakePrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return f[key], nil
+}
+
+func (f fakePrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	f[key] = value
+	return nil
+}
+
+func TestPrivateOutputs(t *testing.T) {
+	ctx := context.Background()
+	c, err := newStateCipher(testStateEncryptionKey)
+	assert.NoError(t, err)
+
+	for name, cipher := range map[string]*stateCipher{"plain": nil, "encrypted": c} {
+		t.Run(name, func(t *testing.T) {
+			producer := &ProviderConfig{SharedContext: newSharedContext(), StateCipher: cipher}
+			model := &HttpxRequestResourceModel{
+				PrivateOutputs: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("token")}),
+				SharedContext:  types.StringValue("login"),
+			}
+			outputs := map[string]attr.Value{"token": types.StringValue("s3cr3t"), "user": types.StringValue("admin")}
+			private := fakePrivateState{}
+
+			assert.False(t, storePrivateOutputs(ctx, producer, model, outputs, private).HasError())
+			assert.NotContains(t, outputs, "token")
+			assert.Contains(t, outputs, "user")
+			if cipher != nil {
+				assert.NotContains(t, string(private[privateOutputsKey]), "s3cr3t")
+			}
+
+			// A later run only has private state to go on
+			consumer := &ProviderConfig{SharedContext: newSharedContext(), StateCipher: cipher}
+			assert.False(t, loadPrivateOutputs(ctx, consumer, model, private).HasError())
+			for _, config := range []*ProviderConfig{producer, consumer} {
+				req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/items", nil)
+				dependsOn := types.MapValueMust(types.StringType, map[string]attr.Value{"Authorization": types.StringValue("Bearer ${shared.login.token}")})
+				_, err := applyDependsOnOutputs(ctx, req, dependsOn, config, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, "Bearer s3cr3t", req.Header.Get("Authorization"))
+			}
+		})
+	}
+}
+
+func TestApplyDependsOnOutputs_Missing(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/items", nil)
+	dependsOn := types.MapValueMust(types.StringType, map[string]attr.Value{"Authorization": types.StringValue("Bearer ${shared.login.token}")})
+	_, err := applyDependsOnOutputs(context.Background(), req, dependsOn, &ProviderConfig{SharedContext: newSharedContext()}, fakePrivateState{})
+	assert.ErrorContains(t, err, `shared_context = "login"`)
+}
+
+func TestApplyDependsOnOutputs_PrivateStateFallback(t *testing.T) {
+	ctx := context.Background()
+	c, err := newStateCipher(testStateEncryptionKey)
+	assert.NoError(t, err)
+	dependsOn := types.MapValueMust(types.StringType, map[string]attr.Value{"Authorization": types.StringValue("Bearer ${shared.login.token}")})
+
+	// The run that planned or created the consumer had the producer's outputs
+	planRun := &ProviderConfig{SharedContext: newSharedContext(), StateCipher: c}
+	planRun.SharedContext.publish("login", map[string]string{"token": "s3cr3t"})
+	planRun.SharedContext.publish("other", map[string]string{"token": "unrelated"})
+	private := fakePrivateState{}
+	assert.False(t, planSharedOutputs(ctx, planRun, dependsOn, fakePrivateState{}, private).HasError())
+	assert.NotContains(t, string(private[sharedOutputsKey]), "s3cr3t")
+
+	// An apply where the producer doesn't change never publishes them
+	applyRun := &ProviderConfig{SharedContext: newSharedContext(), StateCipher: c}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/items", nil)
+	shared, err := applyDependsOnOutputs(ctx, req, dependsOn, applyRun, private)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer s3cr3t", req.Header.Get("Authorization"))
+	assert.Equal(t, map[string]map[string]string{"login": {"token": "s3cr3t"}}, shared)
+
+	// Outputs published in the run take precedence over the copy
+	applyRun.SharedContext.publish("login", map[string]string{"token": "rotated"})
+	req, _ = http.NewRequest(http.MethodGet, "https://api.example.com/items", nil)
+	_, err = applyDependsOnOutputs(ctx, req, dependsOn, applyRun, private)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer rotated", req.Header.Get("Authorization"))
+}
+
+func TestAddPrivateOutputs(t *testing.T) {
+	ctx := context.Background()
+	c, err := newStateCipher(testStateEncryptionKey)
+	assert.NoError(t, err)
+	config := &ProviderConfig{SharedContext: newSharedContext(), StateCipher: c}
+	model := &HttpxRequestResourceModel{
+		Id:             types.StringValue("res-123"),
+		PrivateOutputs: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("token"), types.StringValue("id")}),
+	}
+	outputs := map[string]attr.Value{"token": types.StringValue("s3cr3t"), "id": types.StringValue("i-1"), "user": types.StringValue("admin")}
+	private := fakePrivateState{}
+	assert.False(t, storePrivateOutputs(ctx, config, model, outputs, private).HasError())
+	model.Outputs = types.MapValueMust(types.StringType, outputs)
+
+	// Destroy sees the state's outputs plus the private ones, with refreshed values taking precedence
+	interpolCtx, err := BuildInterpolationContextFromState(ctx, model)
+	assert.NoError(t, err)
+	interpolCtx.Outputs["id"] = "i-2"
+	assert.False(t, addPrivateOutputs(ctx, config, interpolCtx, private).HasError())
+	expanded, err := InterpolateString(ctx, "https://api.example.com/items/${self.outputs.id}?user=${self.outputs.user}&token=${self.outputs.token}", interpolCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/items/i-2?user=admin&token=s3cr3t", expanded)
+
+	// Nothing is added without private outputs
+	interpolCtx, err = BuildInterpolationContextFromState(ctx, model)
+	assert.NoError(t, err)
+	assert.False(t, addPrivateOutputs(ctx, config, interpolCtx, fakePrivateState{}).HasError())
+	assert.NotContains(t, interpolCtx.Outputs, "token")
+}
@@ -2,9 +2,16 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"regexp"
 
+	"github.com/davidshato/terraform-provider-httpx/internal/client"
 	"github.com/davidshato/terraform-provider-httpx/internal/config"
+	"github.com/davidshato/terraform-provider-httpx/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -13,24 +20,85 @@ import (
 )
 
 var _ provider.Provider = &HttpxProvider{}
+var _ provider.ProviderWithEphemeralResources = &HttpxProvider{}
 
 type HttpxProvider struct {
 	version string
 }
 
 type HttpxProviderModel struct {
-	DefaultHeaders       map[string]string `tfsdk:"default_headers"`
-	BasicAuth            *BasicAuthModel   `tfsdk:"basic_auth"`
-	BearerToken          *string           `tfsdk:"bearer_token"`
-	TimeoutMs            *int64            `tfsdk:"timeout_ms"`
-	InsecureSkipVerify   *bool             `tfsdk:"insecure_skip_verify"`
-	ProxyUrl             *string           `tfsdk:"proxy_url"`
-	CaCertPem            *string           `tfsdk:"ca_cert_pem"`
-	ClientCertPem        *string           `tfsdk:"client_cert_pem"`
-	ClientKeyPem         *string           `tfsdk:"client_key_pem"`
-	RedactHeaders        []string          `tfsdk:"redact_headers"`
-	MaxResponseBodyBytes *int64            `tfsdk:"max_response_body_bytes"`
-	Debug                *bool             `tfsdk:"debug"`
+	DefaultHeaders       map[string]string            `tfsdk:"default_headers"`
+	BasicAuth            *BasicAuthModel              `tfsdk:"basic_auth"`
+	BearerToken          *string                      `tfsdk:"bearer_token"`
+	TimeoutMs            *int64                       `tfsdk:"timeout_ms"`
+	InsecureSkipVerify   *bool                        `tfsdk:"insecure_skip_verify"`
+	ProxyUrl             *string                      `tfsdk:"proxy_url"`
+	CaCertPem            *string                      `tfsdk:"ca_cert_pem"`
+	ClientCertPem        *string                      `tfsdk:"client_cert_pem"`
+	ClientKeyPem         *string                      `tfsdk:"client_key_pem"`
+	RedactHeaders        []string                     `tfsdk:"redact_headers"`
+	RedactQueryParams    []string                     `tfsdk:"redact_query_params"`
+	RedactPatterns       []string                     `tfsdk:"redact_patterns"`
+	MaxResponseBodyBytes *int64                       `tfsdk:"max_response_body_bytes"`
+	Debug                *bool                        `tfsdk:"debug"`
+	DryRun               *bool                        `tfsdk:"dry_run"`
+	RecordMode           *string                      `tfsdk:"record_mode"`
+	CassetteDir          *string                      `tfsdk:"cassette_dir"`
+	MockMode             *bool                        `tfsdk:"mock_mode"`
+	MockResponse         map[string]MockResponseModel `tfsdk:"mock_response"`
+	MaxConcurrency       *int64                       `tfsdk:"max_concurrency"`
+	SerializePerHost     *bool                        `tfsdk:"serialize_per_host"`
+	AuditLogPath         *string                      `tfsdk:"audit_log_path"`
+	OnFailureWebhook     *string                      `tfsdk:"on_failure_webhook"`
+	MetricsSummaryPath   *string                      `tfsdk:"metrics_summary_path"`
+	LogMetricsSummary    *bool                        `tfsdk:"log_metrics_summary"`
+	FaultInjection       *FaultInjectionModel         `tfsdk:"fault_injection"`
+
+	ConnectTimeoutMs        *int64 `tfsdk:"connect_timeout_ms"`
+	TLSHandshakeTimeoutMs   *int64 `tfsdk:"tls_handshake_timeout_ms"`
+	ResponseHeaderTimeoutMs *int64 `tfsdk:"response_header_timeout_ms"`
+
+	DNSServers             []string `tfsdk:"dns_servers"`
+	DNSTimeoutMs           *int64   `tfsdk:"dns_timeout_ms"`
+	IPFamily               *string  `tfsdk:"ip_family"`
+	LocalAddress           *string  `tfsdk:"local_address"`
+	TCPKeepAliveIntervalMs *int64   `tfsdk:"tcp_keepalive_interval_ms"`
+
+	ProxyFromEnvironment *bool             `tfsdk:"proxy_from_environment"`
+	ProxyConnectHeaders  map[string]string `tfsdk:"proxy_connect_headers"`
+
+	CaBundleFile *string `tfsdk:"ca_bundle_file"`
+	CaBundleDir  *string `tfsdk:"ca_bundle_dir"`
+
+	AppendSystemCAs  *bool    `tfsdk:"append_system_cas"`
+	PinnedSPKISha256 []string `tfsdk:"pinned_spki_sha256"`
+
+	ClientPKCS12File     *string `tfsdk:"client_pkcs12_file"`
+	ClientPKCS12Password *string `tfsdk:"client_pkcs12_password"`
+	ClientKeyPassphrase  *string `tfsdk:"client_key_passphrase"`
+
+	StateEncryptionKey *string `tfsdk:"state_encryption_key"`
+
+	DataSourceCacheDir *string `tfsdk:"data_source_cache_dir"`
+}
+
+type MockResponseModel struct {
+	StatusCode *int64            `tfsdk:"status_code"`
+	Body       *string           `tfsdk:"body"`
+	Headers    map[string]string `tfsdk:"headers"`
+}
+
+type FaultInjectionModel struct {
+	FailFirstAttempts *int64                      `tfsdk:"fail_first_attempts"`
+	StatusCode        *int64                      `tfsdk:"status_code"`
+	LatencyMs         *int64                      `tfsdk:"latency_ms"`
+	HostLatency       map[string]HostLatencyModel `tfsdk:"host_latency"`
+}
+
+type HostLatencyModel struct {
+	MinMs       *int64   `tfsdk:"min_ms"`
+	MaxMs       *int64   `tfsdk:"max_ms"`
+	Probability *float64 `tfsdk:"probability"`
 }
 
 type BasicAuthModel struct {
@@ -68,6 +136,39 @@ func (p *HttpxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "Request timeout in milliseconds",
 			},
+			"connect_timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time allowed to establish a TCP connection, in milliseconds (default: bounded only by timeout_ms)",
+			},
+			"tls_handshake_timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time allowed for the TLS handshake, in milliseconds (default: bounded only by timeout_ms)",
+			},
+			"response_header_timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time allowed between sending the request and receiving the response headers, in milliseconds; reading the body is not limited by it (default: bounded only by timeout_ms)",
+			},
+			"dns_servers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "DNS servers to resolve host names with instead of the system resolver, as IP addresses with an optional port (default port 53), e.g. [\"10.0.0.2\", \"10.0.0.3:5353\"]. Servers are tried in turn.",
+			},
+			"dns_timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time allowed to resolve a host name, in milliseconds (default: bounded only by timeout_ms)",
+			},
+			"ip_family": schema.StringAttribute{
+				Optional:    true,
+				Description: "Address family to connect over: 'any' (default) lets dual-stack hosts use either, 'ipv4' or 'ipv6' only resolves and dials addresses of that family",
+			},
+			"local_address": schema.StringAttribute{
+				Optional:    true,
+				Description: "Source IP address to connect from, for multi-homed hosts where egress depends on the interface used (default: chosen by the system)",
+			},
+			"tcp_keepalive_interval_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Idle time before TCP keepalive probes start, and the interval between them, in milliseconds. Lower it below the idle timeout of NATs and firewalls on the path so long retry_until polls and slow responses don't lose their connection mid-attempt (default: Go's 15 seconds)",
+			},
 			"insecure_skip_verify": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Skip TLS certificate verification",
@@ -76,6 +177,56 @@ func (p *HttpxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "Proxy URL",
 			},
+			"proxy_from_environment": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Pick the proxy from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables (and their lowercase forms), so hosts listed in NO_PROXY are reached directly. Cannot be combined with proxy_url (default: false)",
+			},
+			"proxy_connect_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Extra headers sent on the CONNECT request when tunneling HTTPS requests through the proxy, e.g. for proxies that authorize tunnels with custom headers besides Proxy-Authorization",
+			},
+			"ca_bundle_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM file with one or more CA certificates to trust, combined with ca_cert_pem and ca_bundle_dir. The file is read again for every request, so an updated bundle is picked up during a run",
+			},
+			"ca_bundle_dir": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a directory of PEM CA certificates to trust, combined with ca_cert_pem and ca_bundle_file. Files without certificates are skipped, and the directory is read again for every request",
+			},
+			"append_system_cas": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Trust ca_cert_pem, ca_bundle_file and ca_bundle_dir in addition to the system trust store instead of replacing it, so one provider can reach both public and internal endpoints (default: false)",
+			},
+			"pinned_spki_sha256": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Base64 SHA-256 digests of the SubjectPublicKeyInfo of trusted keys, optionally prefixed with \"sha256//\". Connections fail unless a certificate presented by the server has one of these keys, independent of CA trust. Resources can override it",
+			},
+			"client_pkcs12_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PKCS#12 (.p12/.pfx) bundle with the client certificate and private key, as an alternative to client_cert_pem and client_key_pem. Both AES-encrypted bundles (the OpenSSL 3 default) and legacy 3DES/RC2 ones are supported",
+			},
+			"client_pkcs12_password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of client_pkcs12_file",
+			},
+			"client_key_passphrase": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Passphrase of an encrypted client_key_pem, either PKCS#8 (\"ENCRYPTED PRIVATE KEY\", PBKDF2 with AES-CBC) or legacy OpenSSL Proc-Type encryption",
+			},
+			"state_encryption_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Base64 AES key (16, 24 or 32 bytes) used to encrypt response data with AES-GCM before it is stored in state, for backends that aren't encrypted at rest: response_body, error_response_body, transcript, outputs, outputs_lists, outputs_json, the values of response_headers, response_links and response_cookies, and httpx_batch response_bodies. Encrypted values are prefixed with \"httpx-enc:v1:\" and response_body_json is not stored. Encryption is deterministic so unchanged responses don't churn the state, which means equal values can be recognized as equal. Defaults to the " + stateEncryptionKeyEnv + " environment variable",
+			},
+			"data_source_cache_dir": schema.StringAttribute{
+				Optional:    true,
+				Description: "Directory holding the results of data sources with plan_behavior = \"cached\", relative to the working directory (default: \"" + defaultDataSourceCacheDir + "\"). Cached results include response bodies, so keep it out of version control.",
+			},
 			"ca_cert_pem": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
@@ -96,6 +247,16 @@ func (p *HttpxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "Headers to redact in logs and diagnostics",
 			},
+			"redact_query_params": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Query parameters whose values are masked in logs, diagnostics, last_error and request_preview, e.g. [\"api_key\", \"X-Amz-Signature\"]. Names are matched case-insensitively.",
+			},
+			"redact_patterns": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Regular expressions whose matches are masked in logs, diagnostics and last_error, e.g. [\"sk_live_[A-Za-z0-9]+\"]. Header values, Bearer/Basic credentials and JWTs are always masked.",
+			},
 			"max_response_body_bytes": schema.Int64Attribute{
 				Optional:    true,
 				Description: "Maximum response body size in bytes",
@@ -104,6 +265,68 @@ func (p *HttpxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "Enable debug logging",
 			},
+			"dry_run": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Log the requests that would be made instead of sending them. Data sources set response-derived attributes to null; resource creates, updates and destroys fail with \"dry_run: request not sent\" and leave state unchanged",
+			},
+			"record_mode": schema.StringAttribute{
+				Optional:    true,
+				Description: "Record responses to cassette_dir (record) or serve previously recorded responses without network access (replay). Recorded URLs and headers are redacted with redact_headers, redact_query_params and redact_patterns, Set-Cookie and credential headers are left out, and files are readable only by the current user as bodies are stored as is",
+			},
+			"cassette_dir": schema.StringAttribute{
+				Optional:    true,
+				Description: "Directory holding recorded responses, required when record_mode is set",
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of requests in flight at once across all resources and data sources (default: unlimited)",
+			},
+			"serialize_per_host": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Execute requests to the same host one at a time across all resources and data sources",
+			},
+			"audit_log_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "File to append a JSON line to for every request attempt (timestamp, resource, operation, method, URL, status, duration, attempt number). URLs and errors are redacted.",
+			},
+			"on_failure_webhook": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "URL to POST a JSON summary to (resource type, operation, method, URL, status, error, attempts) when a request ultimately fails. The document includes a \"text\" field, so Slack incoming webhooks can be used directly. URLs and errors are redacted. The webhook is reached through the provider's proxy and TLS settings.",
+			},
+			"metrics_summary_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "File to write a JSON summary to when the provider shuts down: total requests, retries, failures and per-host p95 latency",
+			},
+			"log_metrics_summary": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Log the metrics summary when the provider shuts down",
+			},
+			"mock_mode": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Serve responses from mock_response instead of sending requests",
+			},
+			"mock_response": schema.MapNestedAttribute{
+				Optional:    true,
+				Description: "Canned responses used when mock_mode is true, keyed by URL pattern. \"*\" matches any characters and the key may be prefixed with a method, e.g. \"POST https://api.example.com/users/*\"",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"status_code": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Response status code (default: 200)",
+						},
+						"body": schema.StringAttribute{
+							Optional:    true,
+							Description: "Response body",
+						},
+						"headers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Response headers",
+						},
+					},
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"basic_auth": schema.SingleNestedBlock{
@@ -121,6 +344,43 @@ func (p *HttpxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				},
 				Description: "Basic authentication credentials",
 			},
+			"fault_injection": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"fail_first_attempts": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Fail the first N attempts of every request, counted per method and URL",
+					},
+					"status_code": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Status code returned by failed attempts. When unset, failed attempts get a connection error.",
+					},
+					"latency_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Latency added to every attempt in milliseconds",
+					},
+					"host_latency": schema.MapNestedAttribute{
+						Optional:    true,
+						Description: "Extra latency per host, keyed by host pattern such as \"api.example.com\" or \"*.example.com\" (matched with or without port), to rehearse a degraded dependency",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"min_ms": schema.Int64Attribute{
+									Optional:    true,
+									Description: "Minimum added latency in milliseconds (default: 0)",
+								},
+								"max_ms": schema.Int64Attribute{
+									Optional:    true,
+									Description: "Maximum added latency in milliseconds, sampled uniformly from min_ms (default: min_ms)",
+								},
+								"probability": schema.Float64Attribute{
+									Optional:    true,
+									Description: "Fraction of attempts that get the latency, between 0 and 1 (default: 1)",
+								},
+							},
+						},
+					},
+				},
+				Description: "Inject failures and latency into requests, for verifying retry, retry_until and expect configurations in tests",
+			},
 		},
 		Description: "Provider for executing HTTP requests with retry logic and conditional polling",
 	}
@@ -140,6 +400,12 @@ func (p *HttpxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		redactHeaders = []string{"Authorization", "Proxy-Authorization", "X-Api-Key"}
 	}
 
+	redactPatterns, err := utils.CompileRedactPatterns(config.RedactPatterns)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("redact_patterns"), "Invalid redact_patterns", err.Error())
+		return
+	}
+
 	maxResponseBodyBytes := int64(1048576) // 1MB default
 	if config.MaxResponseBodyBytes != nil {
 		maxResponseBodyBytes = *config.MaxResponseBodyBytes
@@ -150,11 +416,194 @@ func (p *HttpxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		timeoutMs = *config.TimeoutMs
 	}
 
+	phaseTimeouts := map[string]*int64{
+		"connect_timeout_ms":         config.ConnectTimeoutMs,
+		"tls_handshake_timeout_ms":   config.TLSHandshakeTimeoutMs,
+		"response_header_timeout_ms": config.ResponseHeaderTimeoutMs,
+	}
+	for name, value := range phaseTimeouts {
+		if value == nil {
+			continue
+		}
+		if err := validatePhaseTimeout(name, *value); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid "+name, err.Error())
+			return
+		}
+	}
+
+	dnsServers, err := client.NormalizeDNSServers(config.DNSServers)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("dns_servers"), "Invalid dns_servers", err.Error())
+		return
+	}
+	if config.DNSTimeoutMs != nil && *config.DNSTimeoutMs <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("dns_timeout_ms"), "Invalid dns_timeout_ms",
+			fmt.Sprintf("dns_timeout_ms must be positive, got %d", *config.DNSTimeoutMs))
+		return
+	}
+
+	ipFamily := client.IPFamilyAny
+	if config.IPFamily != nil {
+		ipFamily = *config.IPFamily
+	}
+	if err := client.ValidateIPFamily(ipFamily); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ip_family"), "Invalid ip_family", err.Error())
+		return
+	}
+	localAddress := ""
+	if config.LocalAddress != nil {
+		localAddress = *config.LocalAddress
+	}
+	if err := client.ValidateLocalAddress(localAddress, ipFamily); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("local_address"), "Invalid local_address", err.Error())
+		return
+	}
+	if config.TCPKeepAliveIntervalMs != nil && *config.TCPKeepAliveIntervalMs <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("tcp_keepalive_interval_ms"), "Invalid tcp_keepalive_interval_ms",
+			fmt.Sprintf("tcp_keepalive_interval_ms must be positive, got %d", *config.TCPKeepAliveIntervalMs))
+		return
+	}
+
+	proxyFromEnvironment := config.ProxyFromEnvironment != nil && *config.ProxyFromEnvironment
+	if proxyFromEnvironment && config.ProxyUrl != nil && *config.ProxyUrl != "" {
+		resp.Diagnostics.AddAttributeError(path.Root("proxy_from_environment"), "Conflicting proxy configuration",
+			"proxy_from_environment cannot be combined with proxy_url")
+		return
+	}
+	if len(config.ProxyConnectHeaders) > 0 && !proxyFromEnvironment && (config.ProxyUrl == nil || *config.ProxyUrl == "") {
+		resp.Diagnostics.AddAttributeWarning(path.Root("proxy_connect_headers"), "proxy_connect_headers has no effect",
+			"proxy_connect_headers is only sent to a proxy; set proxy_url or proxy_from_environment")
+	}
+
+	caBundleFile := ""
+	if config.CaBundleFile != nil {
+		caBundleFile = *config.CaBundleFile
+	}
+	caBundleDir := ""
+	if config.CaBundleDir != nil {
+		caBundleDir = *config.CaBundleDir
+	}
+
+	pinnedSPKI, err := client.NormalizeSPKIPins(config.PinnedSPKISha256)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("pinned_spki_sha256"), "Invalid pinned_spki_sha256", err.Error())
+		return
+	}
+
+	clientPKCS12File := ""
+	if config.ClientPKCS12File != nil {
+		clientPKCS12File = *config.ClientPKCS12File
+	}
+	clientPKCS12Password := ""
+	if config.ClientPKCS12Password != nil {
+		clientPKCS12Password = *config.ClientPKCS12Password
+	}
+	clientKeyPassphrase := ""
+	if config.ClientKeyPassphrase != nil {
+		clientKeyPassphrase = *config.ClientKeyPassphrase
+	}
+	if clientPKCS12File != "" && (config.ClientCertPem != nil || config.ClientKeyPem != nil) {
+		resp.Diagnostics.AddAttributeError(path.Root("client_pkcs12_file"), "Conflicting client certificate configuration",
+			"client_pkcs12_file cannot be combined with client_cert_pem and client_key_pem")
+		return
+	}
+
 	insecureSkipVerify := false
 	if config.InsecureSkipVerify != nil {
 		insecureSkipVerify = *config.InsecureSkipVerify
 	}
 
+	recordMode := ""
+	if config.RecordMode != nil {
+		recordMode = *config.RecordMode
+	}
+	cassetteDir := ""
+	if config.CassetteDir != nil {
+		cassetteDir = *config.CassetteDir
+	}
+	if err := client.ValidateRecordMode(recordMode, cassetteDir); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("record_mode"), "Invalid record_mode configuration", err.Error())
+		return
+	}
+
+	mockResponses, err := buildMockResponses(config.MockResponse)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("mock_response"), "Invalid mock_response configuration", err.Error())
+		return
+	}
+
+	maxConcurrency := 0
+	if config.MaxConcurrency != nil {
+		if *config.MaxConcurrency < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_concurrency"), "Invalid max_concurrency",
+				fmt.Sprintf("max_concurrency must be at least 1, got %d", *config.MaxConcurrency))
+			return
+		}
+		maxConcurrency = int(*config.MaxConcurrency)
+	}
+	serializePerHost := config.SerializePerHost != nil && *config.SerializePerHost
+
+	var limiter *client.RequestLimiter
+	if maxConcurrency > 0 || serializePerHost {
+		limiter = client.NewRequestLimiter(maxConcurrency, serializePerHost)
+	}
+
+	var auditLog *auditLogger
+	if config.AuditLogPath != nil && *config.AuditLogPath != "" {
+		auditLog, err = newAuditLogger(*config.AuditLogPath)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("audit_log_path"), "Invalid audit_log_path", err.Error())
+			return
+		}
+	}
+
+	failureWebhook := ""
+	if config.OnFailureWebhook != nil && *config.OnFailureWebhook != "" {
+		if err := validateFailureWebhook(*config.OnFailureWebhook); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("on_failure_webhook"), "Invalid on_failure_webhook", err.Error())
+			return
+		}
+		failureWebhook = *config.OnFailureWebhook
+	}
+
+	var encryption *stateCipher
+	stateEncryptionKey := os.Getenv(stateEncryptionKeyEnv)
+	if config.StateEncryptionKey != nil {
+		stateEncryptionKey = *config.StateEncryptionKey
+	}
+	if stateEncryptionKey != "" {
+		if encryption, err = newStateCipher(stateEncryptionKey); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("state_encryption_key"), "Invalid state_encryption_key", err.Error())
+			return
+		}
+	}
+
+	dataSourceCacheDir := defaultDataSourceCacheDir
+	if config.DataSourceCacheDir != nil {
+		if *config.DataSourceCacheDir == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("data_source_cache_dir"), "Invalid data_source_cache_dir", "data_source_cache_dir must not be empty")
+			return
+		}
+		dataSourceCacheDir = *config.DataSourceCacheDir
+	}
+
+	var metrics *metricsRecorder
+	metricsSummaryPath := ""
+	if config.MetricsSummaryPath != nil {
+		metricsSummaryPath = *config.MetricsSummaryPath
+	}
+	logMetricsSummary := config.LogMetricsSummary != nil && *config.LogMetricsSummary
+	if metricsSummaryPath != "" || logMetricsSummary {
+		applyMetrics.enable(metricsSummaryPath, logMetricsSummary)
+		metrics = applyMetrics
+	}
+
+	faultInjector, err := buildFaultInjector(config.FaultInjection)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("fault_injection"), "Invalid fault_injection configuration", err.Error())
+		return
+	}
+
 	// Create provider configuration
 	var basicAuthModel *BasicAuthModel
 	if config.BasicAuth != nil {
@@ -175,8 +624,60 @@ func (p *HttpxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		ClientCertPem:        config.ClientCertPem,
 		ClientKeyPem:         config.ClientKeyPem,
 		RedactHeaders:        redactHeaders,
+		RedactQueryParams:    config.RedactQueryParams,
+		RedactPatterns:       redactPatterns,
 		MaxResponseBodyBytes: maxResponseBodyBytes,
 		Debug:                config.Debug != nil && *config.Debug,
+		DryRun:               config.DryRun != nil && *config.DryRun,
+		RecordMode:           recordMode,
+		CassetteDir:          cassetteDir,
+		MockMode:             config.MockMode != nil && *config.MockMode,
+		MockResponses:        mockResponses,
+		Limiter:              limiter,
+		AuditLog:             auditLog,
+		FailureWebhook:       failureWebhook,
+		Metrics:              metrics,
+		FaultInjector:        faultInjector,
+
+		ConnectTimeoutMs:        int64OrZero(config.ConnectTimeoutMs),
+		TLSHandshakeTimeoutMs:   int64OrZero(config.TLSHandshakeTimeoutMs),
+		ResponseHeaderTimeoutMs: int64OrZero(config.ResponseHeaderTimeoutMs),
+
+		DNSServers:             dnsServers,
+		DNSTimeoutMs:           int64OrZero(config.DNSTimeoutMs),
+		IPFamily:               ipFamily,
+		LocalAddress:           localAddress,
+		TCPKeepAliveIntervalMs: int64OrZero(config.TCPKeepAliveIntervalMs),
+
+		ProxyFromEnvironment: proxyFromEnvironment,
+		ProxyConnectHeaders:  config.ProxyConnectHeaders,
+
+		CaBundleFile: caBundleFile,
+		CaBundleDir:  caBundleDir,
+
+		AppendSystemCAs:  config.AppendSystemCAs != nil && *config.AppendSystemCAs,
+		PinnedSPKISha256: pinnedSPKI,
+
+		ClientPKCS12File:     clientPKCS12File,
+		ClientPKCS12Password: clientPKCS12Password,
+		ClientKeyPassphrase:  clientKeyPassphrase,
+
+		StateCipher:   encryption,
+		SharedContext: newSharedContext(),
+
+		DataSourceCacheDir: dataSourceCacheDir,
+	}
+
+	// Check the trust store up front rather than failing every request
+	if _, err := client.NewRootCAs(providerConfig.ToConfigProviderConfig()); err != nil {
+		resp.Diagnostics.AddError("Invalid CA configuration", err.Error())
+		return
+	}
+	if clientPKCS12File != "" || clientKeyPassphrase != "" {
+		if _, err := client.LoadClientCertificate(providerConfig.ToConfigProviderConfig()); err != nil {
+			resp.Diagnostics.AddError("Invalid client certificate configuration", err.Error())
+			return
+		}
 	}
 
 	// Enable debug logging if requested
@@ -186,6 +687,7 @@ func (p *HttpxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 
 	resp.ResourceData = providerConfig
 	resp.DataSourceData = providerConfig
+	resp.EphemeralResourceData = providerConfig
 
 	tflog.Info(ctx, "Provider configured successfully")
 }
@@ -193,12 +695,23 @@ func (p *HttpxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 func (p *HttpxProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewHttpxRequestResource,
+		NewHttpxBatchResource,
 	}
 }
 
 func (p *HttpxProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewHttpxRequestDataSource,
+		NewHttpxRequestsDataSource,
+		NewHttpxHeadDataSource,
+		NewHttpxOptionsDataSource,
+		NewHttpxAssertDataSource,
+	}
+}
+
+func (p *HttpxProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewHttpxRequestEphemeralResource,
 	}
 }
 
@@ -217,8 +730,188 @@ type ProviderConfig struct {
 	ClientCertPem        *string
 	ClientKeyPem         *string
 	RedactHeaders        []string
+	RedactQueryParams    []string
+	RedactPatterns       []*regexp.Regexp
 	MaxResponseBodyBytes int64
+	OnBodyOverflow       string
 	Debug                bool
+	DryRun               bool
+	RecordMode           string
+	CassetteDir          string
+	MockMode             bool
+	MockResponses        map[string]config.MockResponse
+	Limiter              *client.RequestLimiter
+	AuditLog             *auditLogger
+	FailureWebhook       string
+	Metrics              *metricsRecorder
+	FaultInjector        *client.FaultInjector
+	CaptureTranscript    bool
+	NonceHeader          string
+	Nonce                string
+	Resume               *resumeState
+	PreRequestCommand    *commandHook
+	PostResponseCommand  *commandHook
+
+	// Set per request by WithAuditSource
+	AuditResource  string
+	AuditOperation string
+
+	// Per-phase transport timeouts, overridable per resource by WithPhaseTimeouts
+	ConnectTimeoutMs        int64
+	TLSHandshakeTimeoutMs   int64
+	ResponseHeaderTimeoutMs int64
+
+	// Name resolution and dial settings, passed through to the client
+	DNSServers             []string
+	DNSTimeoutMs           int64
+	IPFamily               string
+	LocalAddress           string
+	TCPKeepAliveIntervalMs int64
+
+	// Proxy settings, passed through to the client
+	ProxyFromEnvironment bool
+	ProxyConnectHeaders  map[string]string
+
+	// Server trust settings, passed through to the client
+	CaBundleFile string
+	CaBundleDir  string
+
+	AppendSystemCAs  bool
+	PinnedSPKISha256 []string
+
+	// Client identity settings, passed through to the client
+	ClientPKCS12File     string
+	ClientPKCS12Password string
+	ClientKeyPassphrase  string
+
+	// Encrypts response_body and outputs in state, nil when state_encryption_key is not set
+	StateCipher *stateCipher
+	// private_outputs published by resources in this run, shared by every copy of the config
+	SharedContext *sharedContext
+
+	// Where data sources with plan_behavior = "cached" keep their results
+	DataSourceCacheDir string
+}
+
+// Response body overflow policies
+const (
+	bodyOverflowTruncate = "truncate"
+	bodyOverflowFail     = "fail"
+)
+
+// WithResponseBodyLimit returns a copy of the provider config with per-resource
+// max_response_body_bytes and on_body_overflow settings applied
+func (p *ProviderConfig) WithResponseBodyLimit(maxBytes types.Int64, onOverflow types.String) (*ProviderConfig, error) {
+	cfg := *p
+
+	if !maxBytes.IsNull() && !maxBytes.IsUnknown() {
+		if maxBytes.ValueInt64() <= 0 {
+			return nil, fmt.Errorf("max_response_body_bytes must be greater than 0, got %d", maxBytes.ValueInt64())
+		}
+		cfg.MaxResponseBodyBytes = maxBytes.ValueInt64()
+	}
+
+	if !onOverflow.IsNull() && !onOverflow.IsUnknown() && onOverflow.ValueString() != "" {
+		switch onOverflow.ValueString() {
+		case bodyOverflowTruncate, bodyOverflowFail:
+			cfg.OnBodyOverflow = onOverflow.ValueString()
+		default:
+			return nil, fmt.Errorf("on_body_overflow must be 'truncate' or 'fail', got %q", onOverflow.ValueString())
+		}
+	}
+
+	return &cfg, nil
+}
+
+// WithRedactHeaders returns a copy of the provider config whose redact_headers list is
+// extended with the per-resource redact_headers entries
+func (p *ProviderConfig) WithRedactHeaders(ctx context.Context, headers types.List) (*ProviderConfig, error) {
+	extra, err := ConvertTerraformList(ctx, headers, func(v interface{}) (string, error) {
+		if strVal, ok := v.(types.String); ok {
+			return strVal.ValueString(), nil
+		}
+		return "", fmt.Errorf("expected string, got %T", v)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := *p
+	if len(extra) > 0 {
+		cfg.RedactHeaders = append(append([]string{}, p.RedactHeaders...), extra...)
+	}
+	return &cfg, nil
+}
+
+// redaction returns what to scrub from text derived from requests made with this config
+func (p *ProviderConfig) redaction() utils.Redaction {
+	if p == nil {
+		return utils.Redaction{}
+	}
+	return utils.Redaction{Headers: p.RedactHeaders, QueryParams: p.RedactQueryParams, Patterns: p.RedactPatterns}
+}
+
+// redact scrubs redacted values from text such as a URL before it is logged
+func (p *ProviderConfig) redact(s string) string {
+	return p.redaction().Apply(s)
+}
+
+// buildMockResponses converts mock_response entries, validating status codes
+func buildMockResponses(models map[string]MockResponseModel) (map[string]config.MockResponse, error) {
+	responses := make(map[string]config.MockResponse, len(models))
+	for pattern, mock := range models {
+		response := config.MockResponse{Headers: mock.Headers}
+		if mock.StatusCode != nil {
+			if *mock.StatusCode < 100 || *mock.StatusCode > 599 {
+				return nil, fmt.Errorf("status_code for %q must be between 100 and 599, got %d", pattern, *mock.StatusCode)
+			}
+			response.StatusCode = int(*mock.StatusCode)
+		}
+		if mock.Body != nil {
+			response.Body = *mock.Body
+		}
+		responses[pattern] = response
+	}
+	return responses, nil
+}
+
+// buildFaultInjector converts the fault_injection block, returning nil when it is not configured
+func buildFaultInjector(model *FaultInjectionModel) (*client.FaultInjector, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	var cfg client.FaultInjection
+	if model.FailFirstAttempts != nil {
+		cfg.FailFirstAttempts = int(*model.FailFirstAttempts)
+	}
+	if model.StatusCode != nil {
+		cfg.StatusCode = int(*model.StatusCode)
+	}
+	if model.LatencyMs != nil {
+		cfg.LatencyMs = *model.LatencyMs
+	}
+	if len(model.HostLatency) > 0 {
+		cfg.HostLatency = make(map[string]client.LatencyDistribution, len(model.HostLatency))
+		for pattern, latency := range model.HostLatency {
+			dist := client.LatencyDistribution{Probability: 1}
+			if latency.MinMs != nil {
+				dist.MinMs = *latency.MinMs
+			}
+			dist.MaxMs = dist.MinMs
+			if latency.MaxMs != nil {
+				dist.MaxMs = *latency.MaxMs
+			}
+			if latency.Probability != nil {
+				dist.Probability = *latency.Probability
+			}
+			cfg.HostLatency[pattern] = dist
+		}
+	}
+	if err := client.ValidateFaultInjection(cfg); err != nil {
+		return nil, err
+	}
+	return client.NewFaultInjector(cfg), nil
 }
 
 // ToConfigProviderConfig converts ProviderConfig to config.ProviderConfig
@@ -242,7 +935,36 @@ func (p *ProviderConfig) ToConfigProviderConfig() *config.ProviderConfig {
 		ClientCertPem:        p.ClientCertPem,
 		ClientKeyPem:         p.ClientKeyPem,
 		RedactHeaders:        p.RedactHeaders,
+		RedactQueryParams:    p.RedactQueryParams,
+		RedactPatterns:       p.RedactPatterns,
 		MaxResponseBodyBytes: p.MaxResponseBodyBytes,
 		Debug:                p.Debug,
+		RecordMode:           p.RecordMode,
+		CassetteDir:          p.CassetteDir,
+		MockMode:             p.MockMode,
+		MockResponses:        p.MockResponses,
+
+		ConnectTimeoutMs:        p.ConnectTimeoutMs,
+		TLSHandshakeTimeoutMs:   p.TLSHandshakeTimeoutMs,
+		ResponseHeaderTimeoutMs: p.ResponseHeaderTimeoutMs,
+
+		DNSServers:             p.DNSServers,
+		DNSTimeoutMs:           p.DNSTimeoutMs,
+		IPFamily:               p.IPFamily,
+		LocalAddress:           p.LocalAddress,
+		TCPKeepAliveIntervalMs: p.TCPKeepAliveIntervalMs,
+
+		ProxyFromEnvironment: p.ProxyFromEnvironment,
+		ProxyConnectHeaders:  p.ProxyConnectHeaders,
+
+		CaBundleFile: p.CaBundleFile,
+		CaBundleDir:  p.CaBundleDir,
+
+		AppendSystemCAs:  p.AppendSystemCAs,
+		PinnedSPKISha256: p.PinnedSPKISha256,
+
+		ClientPKCS12File:     p.ClientPKCS12File,
+		ClientPKCS12Password: p.ClientPKCS12Password,
+		ClientKeyPassphrase:  p.ClientKeyPassphrase,
 	}
 }
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// redirectHopAttrTypes describes the object type of redirect_chain elements
+var redirectHopAttrTypes = map[string]attr.Type{
+	"url":         types.StringType,
+	"status_code": types.Int64Type,
+	"location":    types.StringType,
+}
+
+// RedirectChainValue converts followed redirect hops to the redirect_chain list value
+func RedirectChainValue(ctx context.Context, hops []client.RedirectHop) (types.List, diag.Diagnostics) {
+	models := make([]RedirectHopModel, 0, len(hops))
+	for _, hop := range hops {
+		models = append(models, RedirectHopModel{
+			Url:        types.StringValue(hop.URL),
+			StatusCode: types.Int64Value(int64(hop.StatusCode)),
+			Location:   types.StringValue(hop.Location),
+		})
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: redirectHopAttrTypes}, models)
+}
+
+// effectiveURLValue returns the effective_url value (null when no response was received)
+func effectiveURLValue(result *ResponseResult) types.String {
+	if result == nil || result.EffectiveURL == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(result.EffectiveURL)
+}
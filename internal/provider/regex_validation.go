@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resourceRegexAttributes are the resource attributes holding a regular expression
+var resourceRegexAttributes = []path.Path{
+	path.Root("retry_until").AtName("body_regex"),
+	path.Root("abort_on").AtName("body_regex"),
+	path.Root("on_destroy").AtName("retry_until").AtName("body_regex"),
+	path.Root("on_destroy").AtName("abort_on").AtName("body_regex"),
+}
+
+// resourceRetryConditionBlocks are the resource's retry_until condition blocks, whose body_regex
+// attributes hold regular expressions
+var resourceRetryConditionBlocks = []path.Path{
+	path.Root("retry_until").AtName("condition"),
+	path.Root("on_destroy").AtName("retry_until").AtName("condition"),
+}
+
+// dataSourceRegexAttributes are the data source attributes holding a regular expression
+var dataSourceRegexAttributes = []path.Path{
+	path.Root("retry_until").AtName("body_regex"),
+	path.Root("abort_on").AtName("body_regex"),
+}
+
+// dataSourceRetryConditionBlocks are the data source's retry_until condition blocks
+var dataSourceRetryConditionBlocks = []path.Path{
+	path.Root("retry_until").AtName("condition"),
+}
+
+// validateRegexAttributes compiles the regular expressions at paths, in the condition blocks at
+// conditionBlocks and in ignore_response_headers, so an invalid pattern fails the plan instead of
+// every poll attempt going unsatisfied
+func validateRegexAttributes(ctx context.Context, config tfsdk.Config, paths []path.Path, conditionBlocks []path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	paths = append([]path.Path{}, paths...)
+	for _, blocksPath := range conditionBlocks {
+		var conditions []RetryUntilConditionModel
+		diags.Append(config.GetAttribute(ctx, blocksPath, &conditions)...)
+		if diags.HasError() {
+			return diags
+		}
+		for i := range conditions {
+			paths = append(paths, blocksPath.AtListIndex(i).AtName("body_regex"))
+		}
+	}
+
+	for _, p := range paths {
+		var pattern types.String
+		diags.Append(config.GetAttribute(ctx, p, &pattern)...)
+		if diags.HasError() {
+			return diags
+		}
+		if pattern.IsNull() || pattern.IsUnknown() {
+			continue
+		}
+		if _, err := regexp.Compile(pattern.ValueString()); err != nil {
+			diags.AddAttributeError(p, "Invalid regular expression", err.Error())
+		}
+	}
+
+	var ignoreHeaders types.List
+	diags.Append(config.GetAttribute(ctx, path.Root("ignore_response_headers"), &ignoreHeaders)...)
+	if diags.HasError() {
+		return diags
+	}
+	if _, err := compileHeaderPatterns(ctx, ignoreHeaders); err != nil {
+		diags.AddAttributeError(path.Root("ignore_response_headers"), "Invalid regular expression", err.Error())
+	}
+	return diags
+}
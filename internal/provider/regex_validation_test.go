@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// nullObject returns objectType with every attribute null except set
+func nullObject(objectType tftypes.Object, set map[string]tftypes.Value) tftypes.Value {
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range set {
+		attributes[name] = value
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+func TestValidateRegexAttributes(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	retryUntilType := objectType.AttributeTypes["retry_until"].(tftypes.Object)
+	ignoreType := objectType.AttributeTypes["ignore_response_headers"]
+
+	config := func(bodyRegex string, ignore ...string) tfsdk.Config {
+		set := map[string]tftypes.Value{
+			"retry_until": nullObject(retryUntilType, map[string]tftypes.Value{"body_regex": tftypes.NewValue(tftypes.String, bodyRegex)}),
+		}
+		if len(ignore) > 0 {
+			var entries []tftypes.Value
+			for _, entry := range ignore {
+				entries = append(entries, tftypes.NewValue(tftypes.String, entry))
+			}
+			set["ignore_response_headers"] = tftypes.NewValue(ignoreType, entries)
+		}
+		return tfsdk.Config{Schema: schemaResp.Schema, Raw: nullObject(objectType, set)}
+	}
+
+	assert.False(t, validateRegexAttributes(ctx, config(`"status":\s*"ready"`, "X-Request-.*"), resourceRegexAttributes, resourceRetryConditionBlocks).HasError())
+
+	diags := validateRegexAttributes(ctx, config(`"status":\s*("ready"`), resourceRegexAttributes, resourceRetryConditionBlocks)
+	assert.Equal(t, 1, diags.ErrorsCount())
+	assert.Equal(t, "Invalid regular expression", diags.Errors()[0].Summary())
+
+	diags = validateRegexAttributes(ctx, config("ready", "X-[a-"), resourceRegexAttributes, resourceRetryConditionBlocks)
+	assert.Equal(t, 1, diags.ErrorsCount())
+	assert.Contains(t, diags.Errors()[0].Detail(), `invalid header pattern "X-[a-"`)
+
+	// The data source paths resolve against its own schema
+	var dsSchemaResp datasource.SchemaResponse
+	NewHttpxRequestDataSource().Schema(ctx, datasource.SchemaRequest{}, &dsSchemaResp)
+	dsObjectType := dsSchemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	dsConfig := tfsdk.Config{Schema: dsSchemaResp.Schema, Raw: nullObject(dsObjectType, nil)}
+	assert.False(t, validateRegexAttributes(ctx, dsConfig, dataSourceRegexAttributes, dataSourceRetryConditionBlocks).HasError())
+}
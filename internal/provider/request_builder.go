@@ -10,22 +10,38 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// transfer_encoding values
+const (
+	transferEncodingChunked  = "chunked"
+	transferEncodingIdentity = "identity"
+)
+
 // RequestConfig holds the configuration for building an HTTP request
 type RequestConfig struct {
 	Url                string
+	PathParams         map[string]string
 	Method             string
+	AllowCustomMethods bool
 	Headers            map[string]string
 	HeaderBlocks       []HeaderBlockModel
+	PreserveHeaderCase bool
 	Query              map[string]string
+	Cookies            map[string]string
 	Body               types.String
 	BodyJson           types.String
+	BodyObject         types.Dynamic
 	BodyFile           types.String
+	AutoContentDigest  string
+	Range              string
+	TransferEncoding   string
 	BasicAuth          *ResourceBasicAuthModel
 	BearerToken        types.String
 	ProviderDefaults   *ProviderConfig
@@ -33,10 +49,22 @@ type RequestConfig struct {
 
 // BuildRequest constructs an HTTP request from the configuration
 func BuildRequest(ctx context.Context, config *RequestConfig) (*http.Request, error) {
+	method, err := resolveMethod(config.Method, config.AllowCustomMethods)
+	if err != nil {
+		return nil, err
+	}
+
+	// Substitute path parameters
+	rawURL, err := substitutePathParams(config.Url, config.PathParams)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse URL
-	reqURL, err := url.Parse(config.Url)
+	reqURL, err := url.Parse(rawURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid URL: %w", err)
+		// url.Error embeds the raw URL, which may carry secrets
+		return nil, fmt.Errorf("invalid URL: %s", config.ProviderDefaults.redact(err.Error()))
 	}
 
 	// Add query parameters
@@ -48,6 +76,11 @@ func BuildRequest(ctx context.Context, config *RequestConfig) (*http.Request, er
 		reqURL.RawQuery = q.Encode()
 	}
 
+	// Fail early on presigned URLs that have already expired
+	if err := checkPresignedURL(reqURL, time.Now()); err != nil {
+		return nil, err
+	}
+
 	// Determine request body
 	var bodyReader io.Reader
 	contentTypeSet := false
@@ -63,9 +96,12 @@ func BuildRequest(ctx context.Context, config *RequestConfig) (*http.Request, er
 	if !config.BodyFile.IsNull() && !config.BodyFile.IsUnknown() && config.BodyFile.ValueString() != "" {
 		bodyCount++
 	}
+	if !config.BodyObject.IsNull() && !config.BodyObject.IsUnknown() {
+		bodyCount++
+	}
 
 	if bodyCount > 1 {
-		return nil, fmt.Errorf("only one of body, body_json, or body_file can be set")
+		return nil, fmt.Errorf("only one of body, body_json, body_object, or body_file can be set")
 	}
 
 	// Set body
@@ -73,8 +109,8 @@ func BuildRequest(ctx context.Context, config *RequestConfig) (*http.Request, er
 		bodyReader = strings.NewReader(config.Body.ValueString())
 	} else if !config.BodyJson.IsNull() && !config.BodyJson.IsUnknown() && config.BodyJson.ValueString() != "" {
 		// Parse JSON to validate and pretty-print
-		var jsonData interface{}
-		if err := json.Unmarshal([]byte(config.BodyJson.ValueString()), &jsonData); err != nil {
+		jsonData, err := decodeJSON(config.BodyJson.ValueString())
+		if err != nil {
 			return nil, fmt.Errorf("invalid JSON in body_json: %w", err)
 		}
 		jsonBytes, err := json.Marshal(jsonData)
@@ -86,6 +122,16 @@ func BuildRequest(ctx context.Context, config *RequestConfig) (*http.Request, er
 		if config.Headers["Content-Type"] == "" {
 			contentTypeSet = true
 		}
+	} else if !config.BodyObject.IsNull() && !config.BodyObject.IsUnknown() {
+		jsonBytes, err := BodyObjectJSON(ctx, config.BodyObject)
+		if err != nil {
+			return nil, err
+		}
+		bodyReader = bytes.NewReader(jsonBytes)
+		// Set Content-Type if not already set
+		if config.Headers["Content-Type"] == "" {
+			contentTypeSet = true
+		}
 	} else if !config.BodyFile.IsNull() && !config.BodyFile.IsUnknown() && config.BodyFile.ValueString() != "" {
 		filePath := config.BodyFile.ValueString()
 		file, err := os.Open(filePath)
@@ -107,21 +153,46 @@ func BuildRequest(ctx context.Context, config *RequestConfig) (*http.Request, er
 	// Create request
 	var req *http.Request
 	if bodyReader != nil {
-		req, err = http.NewRequestWithContext(ctx, config.Method, reqURL.String(), bodyReader)
+		req, err = http.NewRequestWithContext(ctx, method, reqURL.String(), bodyReader)
 	} else {
-		req, err = http.NewRequestWithContext(ctx, config.Method, reqURL.String(), nil)
+		req, err = http.NewRequestWithContext(ctx, method, reqURL.String(), nil)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	// Override Go's choice between Content-Length and chunked framing
+	switch strings.ToLower(config.TransferEncoding) {
+	case "":
+	case transferEncodingChunked:
+		req.TransferEncoding = []string{transferEncodingChunked}
+	case transferEncodingIdentity:
+		// Buffer a body of unknown length so Go sends Content-Length rather than chunking it
+		if req.Body != nil && req.Body != http.NoBody && req.ContentLength <= 0 {
+			bodyBytes, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read request body: %w", err)
+			}
+			req.ContentLength = int64(len(bodyBytes))
+			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+			req.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(bodyBytes)), nil
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid transfer_encoding %q, expected %q or %q", config.TransferEncoding, transferEncodingChunked, transferEncodingIdentity)
+	}
+
 	// Merge headers: provider defaults first, then resource headers, then header blocks
+	// Headers are merged case-insensitively; names keeps the configured casing of each header
 	headers := make(map[string][]string)
+	names := make(map[string]string)
 
 	// Add provider default headers
 	if config.ProviderDefaults != nil && config.ProviderDefaults.DefaultHeaders != nil {
 		for k, v := range config.ProviderDefaults.DefaultHeaders {
 			headers[strings.ToLower(k)] = []string{v}
+			names[strings.ToLower(k)] = k
 		}
 	}
 
@@ -129,6 +200,7 @@ func BuildRequest(ctx context.Context, config *RequestConfig) (*http.Request, er
 	if config.Headers != nil {
 		for k, v := range config.Headers {
 			headers[strings.ToLower(k)] = []string{v}
+			names[strings.ToLower(k)] = k
 		}
 	}
 
@@ -140,6 +212,7 @@ func BuildRequest(ctx context.Context, config *RequestConfig) (*http.Request, er
 				headers[key] = append(existing, hb.Value.ValueString())
 			} else {
 				headers[key] = []string{hb.Value.ValueString()}
+				names[key] = hb.Name.ValueString()
 			}
 		}
 	}
@@ -147,15 +220,58 @@ func BuildRequest(ctx context.Context, config *RequestConfig) (*http.Request, er
 	// Set Content-Type for JSON if needed
 	if contentTypeSet {
 		headers["content-type"] = []string{"application/json"}
+		names["content-type"] = "Content-Type"
 	}
 
 	// Apply headers to request
 	for k, values := range headers {
+		if config.PreserveHeaderCase {
+			// Direct assignment bypasses canonicalization so the name is sent as configured
+			req.Header[names[k]] = append([]string{}, values...)
+			continue
+		}
 		for _, v := range values {
 			req.Header.Add(k, v)
 		}
 	}
 
+	// The range attribute takes precedence over a Range header
+	if config.Range != "" {
+		if err := validateRange(config.Range); err != nil {
+			return nil, err
+		}
+		req.Header.Set("Range", config.Range)
+	}
+
+	// Add cookies (appended to any Cookie header set explicitly)
+	if len(config.Cookies) > 0 {
+		cookieNames := make([]string, 0, len(config.Cookies))
+		for name := range config.Cookies {
+			cookieNames = append(cookieNames, name)
+		}
+		sort.Strings(cookieNames)
+
+		// Merge into an existing Cookie header, keeping its configured casing
+		cookieKey := "Cookie"
+		if name, ok := names["cookie"]; ok && config.PreserveHeaderCase {
+			cookieKey = name
+		}
+		var pairs []string
+		if existing := req.Header[cookieKey]; len(existing) > 0 {
+			pairs = append(pairs, strings.Join(existing, "; "))
+		}
+
+		for _, name := range cookieNames {
+			cookie := &http.Cookie{Name: name, Value: config.Cookies[name]}
+			if err := cookie.Valid(); err != nil {
+				return nil, fmt.Errorf("invalid cookie %q: %w", name, err)
+			}
+			// String() renders name=value, quoting the value when required
+			pairs = append(pairs, cookie.String())
+		}
+		req.Header[cookieKey] = []string{strings.Join(pairs, "; ")}
+	}
+
 	// Set authentication
 	// config.BasicAuth uses BasicAuthModel from models.go which has types.String fields
 	if config.BasicAuth != nil {
@@ -187,14 +303,51 @@ func BuildRequest(ctx context.Context, config *RequestConfig) (*http.Request, er
 		req.Header.Set("Authorization", "Bearer "+*config.ProviderDefaults.BearerToken)
 	}
 
+	if err := setContentDigest(req, config.AutoContentDigest); err != nil {
+		return nil, err
+	}
+
 	tflog.Debug(ctx, "Built HTTP request", map[string]interface{}{
 		"method": req.Method,
-		"url":    req.URL.String(),
+		"url":    config.ProviderDefaults.redact(req.URL.String()),
 	})
 
 	return req, nil
 }
 
+// substitutePathParams replaces {name} tokens in rawURL with path-escaped values.
+// ${...} interpolation expressions are left untouched, as are tokens without a matching parameter.
+func substitutePathParams(rawURL string, params map[string]string) (string, error) {
+	if len(params) == 0 {
+		return rawURL, nil
+	}
+
+	used := make(map[string]bool, len(params))
+	var b strings.Builder
+	for i := 0; i < len(rawURL); i++ {
+		if rawURL[i] == '{' && (i == 0 || rawURL[i-1] != '$') {
+			if end := strings.IndexByte(rawURL[i+1:], '}'); end >= 0 {
+				name := rawURL[i+1 : i+1+end]
+				if value, ok := params[name]; ok {
+					b.WriteString(url.PathEscape(value))
+					used[name] = true
+					i += end + 1
+					continue
+				}
+			}
+		}
+		b.WriteByte(rawURL[i])
+	}
+
+	for name := range params {
+		if !used[name] {
+			return "", fmt.Errorf("path parameter %q has no matching {%s} token in the URL", name, name)
+		}
+	}
+
+	return b.String(), nil
+}
+
 // ConvertTerraformMap converts a Terraform types.Map to a Go map[string]string
 func ConvertTerraformMap(ctx context.Context, tfMap types.Map) (map[string]string, error) {
 	if tfMap.IsNull() || tfMap.IsUnknown() {
@@ -2,6 +2,11 @@ package provider
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -161,3 +166,345 @@ func TestConvertTerraformList(t *testing.T) {
 	}
 }
 
+
+func TestBuildRequest_Cookies(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		cookies map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "cookies are sorted by name",
+			cookies: map[string]string{"theme": "dark", "session": "abc123"},
+			want:    "session=abc123; theme=dark",
+		},
+		{
+			name:    "values with spaces are quoted",
+			cookies: map[string]string{"greeting": "hello world"},
+			want:    `greeting="hello world"`,
+		},
+		{
+			name:    "appended to explicit cookie header",
+			headers: map[string]string{"Cookie": "existing=1"},
+			cookies: map[string]string{"session": "abc123"},
+			want:    "existing=1; session=abc123",
+		},
+		{
+			name:    "invalid value",
+			cookies: map[string]string{"session": "a;b"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid name",
+			cookies: map[string]string{"bad name": "value"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := BuildRequest(context.Background(), &RequestConfig{
+				Url:     "https://example.com",
+				Method:  "GET",
+				Headers: tt.headers,
+				Cookies: tt.cookies,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := req.Header.Get("Cookie"); got != tt.want {
+				t.Errorf("Cookie header = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildRequest_PreserveHeaderCase(t *testing.T) {
+	headerBlocks := []HeaderBlockModel{
+		{Name: types.StringValue("x-legacy-token"), Value: types.StringValue("a")},
+		{Name: types.StringValue("X-LEGACY-TOKEN"), Value: types.StringValue("b")},
+	}
+	providerDefaults := &ProviderConfig{
+		DefaultHeaders: map[string]string{"x-api-version": "1"},
+	}
+
+	req, err := BuildRequest(context.Background(), &RequestConfig{
+		Url:                "https://example.com",
+		Method:             "POST",
+		Headers:            map[string]string{"SOAPAction": "urn:Get", "X-API-VERSION": "2", "cookie": "existing=1"},
+		HeaderBlocks:       headerBlocks,
+		Cookies:            map[string]string{"session": "abc"},
+		BodyJson:           types.StringValue(`{"a": 1}`),
+		PreserveHeaderCase: true,
+		ProviderDefaults:   providerDefaults,
+	})
+	if err != nil {
+		t.Fatalf("BuildRequest() error = %v", err)
+	}
+
+	expected := map[string][]string{
+		"SOAPAction":     {"urn:Get"},
+		"X-API-VERSION":  {"2"},
+		"x-legacy-token": {"a", "b"},
+		"Content-Type":   {"application/json"},
+		"cookie":         {"existing=1; session=abc"},
+	}
+	for name, values := range expected {
+		got := req.Header[name]
+		if len(got) != len(values) {
+			t.Errorf("header %q = %v, want %v", name, got, values)
+			continue
+		}
+		for i := range values {
+			if got[i] != values[i] {
+				t.Errorf("header %q = %v, want %v", name, got, values)
+			}
+		}
+	}
+	if _, ok := req.Header["Soapaction"]; ok {
+		t.Error("expected SOAPAction not to be canonicalized")
+	}
+
+	// Without the option header names are canonicalized
+	req, err = BuildRequest(context.Background(), &RequestConfig{
+		Url:     "https://example.com",
+		Method:  "GET",
+		Headers: map[string]string{"SOAPAction": "urn:Get"},
+	})
+	if err != nil {
+		t.Fatalf("BuildRequest() error = %v", err)
+	}
+	if got := req.Header["Soapaction"]; len(got) != 1 || got[0] != "urn:Get" {
+		t.Errorf("expected canonical Soapaction header, got %v", req.Header)
+	}
+}
+
+func TestBuildRequest_PathParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		pathParams map[string]string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "simple substitution",
+			url:        "https://example.com/users/{user_id}/posts/{post_id}",
+			pathParams: map[string]string{"user_id": "42", "post_id": "7"},
+			want:       "https://example.com/users/42/posts/7",
+		},
+		{
+			name:       "values are path-escaped",
+			url:        "https://example.com/files/{path}",
+			pathParams: map[string]string{"path": "a/b c"},
+			want:       "https://example.com/files/a%2Fb%20c",
+		},
+		{
+			name:       "interpolation expressions are untouched",
+			url:        "https://example.com/${self.id}/{id}",
+			pathParams: map[string]string{"id": "1"},
+			want:       "https://example.com/$%7Bself.id%7D/1",
+		},
+		{
+			name: "no path params",
+			url:  "https://example.com/users/{user_id}",
+			want: "https://example.com/users/%7Buser_id%7D",
+		},
+		{
+			name:       "unused path param",
+			url:        "https://example.com/users",
+			pathParams: map[string]string{"user_id": "42"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := BuildRequest(context.Background(), &RequestConfig{
+				Url:        tt.url,
+				PathParams: tt.pathParams,
+				Method:     "GET",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := req.URL.String(); got != tt.want {
+				t.Errorf("URL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildRequest_BodyJsonLargeIntegers(t *testing.T) {
+	req, err := BuildRequest(context.Background(), &RequestConfig{
+		Url:      "https://example.com",
+		Method:   "POST",
+		BodyJson: types.StringValue(`{"id": 12345678901234567890, "ratio": 0.25}`),
+	})
+	if err != nil {
+		t.Fatalf("BuildRequest() error = %v", err)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if got, want := string(body), `{"id":12345678901234567890,"ratio":0.25}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestBuildRequest_Method(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		allowCustom bool
+		want        string
+		wantErr     bool
+	}{
+		{name: "defaults to GET", method: "", want: "GET"},
+		{name: "standard method", method: "POST", want: "POST"},
+		{name: "lower case is canonicalized", method: "patch", want: "PATCH"},
+		{name: "typo is rejected", method: "GETT", wantErr: true},
+		{name: "custom method rejected by default", method: "PROPFIND", wantErr: true},
+		{name: "custom method allowed", method: "PROPFIND", allowCustom: true, want: "PROPFIND"},
+		{name: "custom method must be a token", method: "GET ME", allowCustom: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := BuildRequest(context.Background(), &RequestConfig{
+				Url:                "https://example.com",
+				Method:             tt.method,
+				AllowCustomMethods: tt.allowCustom,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.Method != tt.want {
+				t.Errorf("Method = %q, want %q", req.Method, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildRequest_AutoContentDigest(t *testing.T) {
+	bodyFile := filepath.Join(t.TempDir(), "payload.txt")
+	if err := os.WriteFile(bodyFile, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		algorithm string
+		body      types.String
+		bodyFile  types.String
+		headers   map[string]string
+		header    string
+		want      string
+		wantErr   bool
+	}{
+		{name: "sha256", algorithm: "sha256", body: types.StringValue("hello"), header: "Content-Digest", want: "sha-256=:LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=:"},
+		{name: "md5", algorithm: "md5", body: types.StringValue("hello"), header: "Content-MD5", want: "XUFAKrxLKna5cZ2REBfFkg=="},
+		{name: "body_file", algorithm: "sha256", bodyFile: types.StringValue(bodyFile), header: "Content-Digest", want: "sha-256=:LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=:"},
+		{name: "explicit header is kept", algorithm: "md5", body: types.StringValue("hello"), headers: map[string]string{"content-md5": "custom"}, header: "Content-MD5", want: "custom"},
+		{name: "no body", algorithm: "sha256", header: "Content-Digest", want: ""},
+		{name: "unknown algorithm", algorithm: "sha1", body: types.StringValue("hello"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := BuildRequest(context.Background(), &RequestConfig{
+				Url:               "https://example.com",
+				Method:            "PUT",
+				Headers:           tt.headers,
+				Body:              tt.body,
+				BodyJson:          types.StringNull(),
+				BodyObject:        types.DynamicNull(),
+				BodyFile:          tt.bodyFile,
+				AutoContentDigest: tt.algorithm,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := req.Header.Get(tt.header); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.header, got, tt.want)
+			}
+			if req.Body == nil {
+				return
+			}
+			if body, _ := io.ReadAll(req.Body); string(body) != "hello" {
+				t.Errorf("body = %q, want it to be unconsumed", body)
+			}
+		})
+	}
+}
+
+func TestBuildRequest_TransferEncoding(t *testing.T) {
+	type framing struct {
+		transferEncoding []string
+		contentLength    int64
+	}
+	received := make(chan framing, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		received <- framing{transferEncoding: r.TransferEncoding, contentLength: r.ContentLength}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name             string
+		method           string
+		body             string
+		transferEncoding string
+		wantChunked      bool
+		wantLength       int64
+		wantErr          bool
+	}{
+		{name: "Go sends Content-Length for a known body", method: "POST", body: "hello", wantLength: 5},
+		{name: "chunked", method: "POST", body: "hello", transferEncoding: "chunked", wantChunked: true, wantLength: -1},
+		{name: "identity", method: "POST", body: "hello", transferEncoding: "identity", wantLength: 5},
+		{name: "invalid", method: "POST", transferEncoding: "gzip", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := BuildRequest(context.Background(), &RequestConfig{
+				Url:              server.URL,
+				Method:           tt.method,
+				Body:             types.StringValue(tt.body),
+				TransferEncoding: tt.transferEncoding,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if _, err := ExecuteRequest(context.Background(), req, &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}); err != nil {
+				t.Fatalf("ExecuteRequest() error = %v", err)
+			}
+			got := <-received
+			if chunked := len(got.transferEncoding) == 1 && got.transferEncoding[0] == "chunked"; chunked != tt.wantChunked {
+				t.Errorf("TransferEncoding = %v, want chunked %v", got.transferEncoding, tt.wantChunked)
+			}
+			if got.contentLength != tt.wantLength {
+				t.Errorf("ContentLength = %d, want %d", got.contentLength, tt.wantLength)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// requestFingerprintInputs is everything that shapes the root request. Credentials are left
+// out, so rotating a bearer_token or basic_auth password doesn't change the fingerprint.
+type requestFingerprintInputs struct {
+	Method            string            `json:"method"`
+	Url               string            `json:"url"`
+	PathParams        map[string]string `json:"path_params"`
+	Headers           map[string]string `json:"headers"`
+	HeaderBlocks      [][2]string       `json:"header_blocks"`
+	Query             map[string]string `json:"query"`
+	Cookies           map[string]string `json:"cookies"`
+	Body              string            `json:"body"`
+	BodyJson          string            `json:"body_json"`
+	BodyObject        json.RawMessage   `json:"body_object"`
+	BodyFile          string            `json:"body_file"`
+	AutoContentDigest string            `json:"auto_content_digest"`
+	Range             string            `json:"range"`
+}
+
+// requestFingerprint returns the hex SHA-256 of the inputs shaping the root request, so two
+// resources differing only in query or body_json get different fingerprints and IDs
+func requestFingerprint(ctx context.Context, model *HttpxRequestResourceModel) (string, error) {
+	method, err := resolveMethod(model.Method.ValueString(), model.AllowCustomMethods.ValueBool())
+	if err != nil {
+		return "", err
+	}
+	inputs := requestFingerprintInputs{
+		Method:            method,
+		Url:               model.Url.ValueString(),
+		Body:              model.Body.ValueString(),
+		BodyJson:          model.BodyJson.ValueString(),
+		BodyFile:          model.BodyFile.ValueString(),
+		AutoContentDigest: model.AutoContentDigest.ValueString(),
+		Range:             model.Range.ValueString(),
+	}
+	if inputs.PathParams, err = ConvertTerraformMap(ctx, model.PathParams); err != nil {
+		return "", fmt.Errorf("invalid path_params: %w", err)
+	}
+	if inputs.Headers, err = ConvertTerraformMap(ctx, aliasedMap(model.RequestHeaders, model.Headers)); err != nil {
+		return "", fmt.Errorf("invalid headers: %w", err)
+	}
+	if inputs.Query, err = ConvertTerraformMap(ctx, model.Query); err != nil {
+		return "", fmt.Errorf("invalid query: %w", err)
+	}
+	if inputs.Cookies, err = ConvertTerraformMap(ctx, model.Cookies); err != nil {
+		return "", fmt.Errorf("invalid cookies: %w", err)
+	}
+	for _, header := range model.HeaderBlocks {
+		inputs.HeaderBlocks = append(inputs.HeaderBlocks, [2]string{header.Name.ValueString(), header.Value.ValueString()})
+	}
+	if !model.BodyObject.IsNull() && !model.BodyObject.IsUnknown() {
+		if inputs.BodyObject, err = BodyObjectJSON(ctx, model.BodyObject); err != nil {
+			return "", err
+		}
+	}
+
+	// Maps marshal with sorted keys, so equal inputs always hash the same
+	data, err := json.Marshal(inputs)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
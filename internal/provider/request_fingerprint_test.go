@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestFingerprint(t *testing.T) {
+	ctx := context.Background()
+	newModel := func(query map[string]attr.Value, bodyJson string) *HttpxRequestResourceModel {
+		return &HttpxRequestResourceModel{
+			Url:            types.StringValue("https://api.example.com/items"),
+			Method:         types.StringValue("post"),
+			PathParams:     types.MapNull(types.StringType),
+			Headers:        types.MapNull(types.StringType),
+			RequestHeaders: types.MapValueMust(types.StringType, map[string]attr.Value{"Accept": types.StringValue("application/json")}),
+			Query:          types.MapValueMust(types.StringType, query),
+			Cookies:        types.MapNull(types.StringType),
+			BodyJson:       types.StringValue(bodyJson),
+			BodyObject:     types.DynamicNull(),
+			BearerToken:    types.StringValue("token-1"),
+		}
+	}
+	fingerprint := func(model *HttpxRequestResourceModel) string {
+		value, err := requestFingerprint(ctx, model)
+		assert.NoError(t, err)
+		assert.Len(t, value, 64)
+		return value
+	}
+
+	base := fingerprint(newModel(map[string]attr.Value{"page": types.StringValue("1"), "size": types.StringValue("50")}, `{"name":"a"}`))
+	assert.NotEqual(t, base, fingerprint(newModel(map[string]attr.Value{"page": types.StringValue("2"), "size": types.StringValue("50")}, `{"name":"a"}`)))
+	assert.NotEqual(t, base, fingerprint(newModel(map[string]attr.Value{"page": types.StringValue("1"), "size": types.StringValue("50")}, `{"name":"b"}`)))
+
+	// Credentials and the method's spelling don't shape the request
+	rotated := newModel(map[string]attr.Value{"page": types.StringValue("1"), "size": types.StringValue("50")}, `{"name":"a"}`)
+	rotated.BearerToken = types.StringValue("token-2")
+	rotated.Method = types.StringValue("POST")
+	assert.Equal(t, base, fingerprint(rotated))
+
+	// The id is taken from the fingerprint
+	rotated.RequestFingerprint = types.StringValue(base)
+	assert.Equal(t, base[:16], generateResourceID(*rotated))
+}
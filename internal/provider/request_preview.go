@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithModifyPlan = &HttpxRequestResource{}
+
+// ModifyPlan renders a summary of the HTTP call an apply will make into request_preview,
+// so reviewers can see the method, resolved URL, headers and body size before approving
+func (r *HttpxRequestResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to preview on destroy, and no request is made when nothing changes
+	if req.Plan.Raw.IsNull() || (!req.State.Raw.IsNull() && req.Plan.Raw.Equal(req.State.Raw)) {
+		return
+	}
+
+	// Metadata-only changes keep the previous results, as Update won't send the request
+	if metadataOnlyChange(req.Plan, req.State) {
+		var plan, state HttpxRequestResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		merged := applyMetadataChange(plan, state)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &merged)...)
+		return
+	}
+	if r.config == nil {
+		// Provider configuration is not known yet
+		return
+	}
+
+	var model HttpxRequestResourceModel
+	if diags := req.Plan.Get(ctx, &model); diags.HasError() {
+		// Blocks with unknown values can't be previewed yet
+		return
+	}
+	// Method typos would otherwise only surface during apply, or for on_destroy at destroy time
+	validatePlanMethod(model.Method, model.AllowCustomMethods, path.Root("method"), &resp.Diagnostics)
+	if model.OnDestroy != nil {
+		validatePlanMethod(model.OnDestroy.Method, model.OnDestroy.AllowCustomMethods, path.Root("on_destroy").AtName("method"), &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Private state from the plan only reaches Update, where the producer of depends_on_outputs
+	// may not be applied
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(planSharedOutputs(ctx, r.config, model.DependsOnOutputs, req.Private, resp.Private)...)
+	}
+
+	// An already expired presigned URL would only fail with a 403 during apply
+	if !model.Url.IsNull() && !model.Url.IsUnknown() {
+		if u, err := url.Parse(model.Url.ValueString()); err == nil {
+			if err := checkPresignedURL(u, time.Now()); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("url"), "Presigned URL expired", err.Error())
+				return
+			}
+		}
+	}
+	if !requestPreviewInputsKnown(&model) {
+		return
+	}
+
+	preview, effectiveURL, err := buildRequestPreview(ctx, &model, r.config)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to preview request", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("request_preview"), types.StringValue(preview))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_request_url"), types.StringValue(effectiveURL))...)
+	if fingerprint, err := requestFingerprint(ctx, &model); err == nil {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("request_fingerprint"), types.StringValue(fingerprint))...)
+	}
+}
+
+// validatePlanMethod reports an invalid method at attrPath once its value is known
+func validatePlanMethod(method types.String, allowCustom types.Bool, attrPath path.Path, diags *diag.Diagnostics) {
+	if method.IsUnknown() || allowCustom.IsUnknown() {
+		return
+	}
+	if _, err := resolveMethod(method.ValueString(), allowCustom.ValueBool()); err != nil {
+		diags.AddAttributeError(attrPath, "Invalid method", err.Error())
+	}
+}
+
+// ensureRequestPreview fills request_preview, effective_request_url and request_fingerprint
+// during apply when they could not be computed at plan time
+func ensureRequestPreview(ctx context.Context, model *HttpxRequestResourceModel, providerConfig *ProviderConfig) {
+	if model.RequestFingerprint.IsUnknown() {
+		model.RequestFingerprint = types.StringNull()
+		if fingerprint, err := requestFingerprint(ctx, model); err == nil {
+			model.RequestFingerprint = types.StringValue(fingerprint)
+		}
+	}
+	if !model.RequestPreview.IsUnknown() && !model.EffectiveRequestUrl.IsUnknown() {
+		return
+	}
+	preview, effectiveURL, err := buildRequestPreview(ctx, model, providerConfig)
+	if err != nil {
+		model.RequestPreview = types.StringNull()
+		model.EffectiveRequestUrl = types.StringNull()
+		return
+	}
+	model.RequestPreview = types.StringValue(preview)
+	model.EffectiveRequestUrl = types.StringValue(effectiveURL)
+}
+
+// requestPreviewInputsKnown reports whether every input that shapes the request is known
+func requestPreviewInputsKnown(model *HttpxRequestResourceModel) bool {
+	values := []attr.Value{
+		model.Url, model.Method, model.AllowCustomMethods, model.PathParams, model.Headers, model.RequestHeaders, model.Query, model.Cookies,
+		model.Body, model.BodyJson, model.BodyObject, model.BodyFile, model.AutoContentDigest, model.Range, model.BearerToken, model.RedactHeaders,
+	}
+	for _, header := range model.HeaderBlocks {
+		values = append(values, header.Name, header.Value)
+	}
+	if model.BasicAuth != nil {
+		values = append(values, model.BasicAuth.Username, model.BasicAuth.Password)
+	}
+
+	for _, value := range values {
+		if value.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// buildRequestPreview builds the root request and renders it as a redacted summary, along with
+// its redacted effective URL
+func buildRequestPreview(ctx context.Context, model *HttpxRequestResourceModel, providerConfig *ProviderConfig) (string, string, error) {
+	headers, err := ConvertTerraformMap(ctx, aliasedMap(model.RequestHeaders, model.Headers))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid headers: %w", err)
+	}
+	query, err := ConvertTerraformMap(ctx, model.Query)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid query: %w", err)
+	}
+	cookies, err := ConvertTerraformMap(ctx, model.Cookies)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid cookies: %w", err)
+	}
+	pathParams, err := ConvertTerraformMap(ctx, model.PathParams)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid path_params: %w", err)
+	}
+
+	httpReq, err := BuildRequest(ctx, requestConfigFromModel(model, headers, query, cookies, pathParams, providerConfig))
+	if err != nil {
+		return "", "", err
+	}
+
+	reqConfig, err := providerConfig.WithRedactHeaders(ctx, model.RedactHeaders)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid redact_headers: %w", err)
+	}
+
+	return renderRequestSummary(httpReq, reqConfig.redaction()), reqConfig.redact(httpReq.URL.String()), nil
+}
+
+// renderRequestSummary renders the method, URL, sorted headers and body size of a request.
+// Credentials and cookies come from sensitive attributes, so they are always redacted.
+func renderRequestSummary(httpReq *http.Request, redaction utils.Redaction) string {
+	headers := redactRequestHeaders(httpReq, redaction)
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := []string{fmt.Sprintf("%s %s", httpReq.Method, httpReq.URL.String())}
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("%s: %s", name, headers[name]))
+	}
+	if httpReq.ContentLength > 0 {
+		lines = append(lines, fmt.Sprintf("Body: %d bytes", httpReq.ContentLength))
+	} else {
+		lines = append(lines, "Body: none")
+	}
+
+	// Header values are already masked, scrub the rest of the summary
+	redaction.Headers = nil
+	return redaction.Apply(strings.Join(lines, "\n"))
+}
+
+// redactRequestHeaders returns the request headers with repeated values joined and the values
+// of credential headers and redact_headers masked
+func redactRequestHeaders(httpReq *http.Request, redaction utils.Redaction) map[string]string {
+	redactList := append([]string{"Authorization", "Proxy-Authorization", "Cookie"}, redaction.Headers...)
+
+	headers := make(map[string]string, len(httpReq.Header))
+	for name, values := range httpReq.Header {
+		headers[name] = utils.RedactHeaderValue(name, strings.Join(values, ", "), redactList)
+	}
+	return headers
+}
+
+// sentRequestHeaders returns the headers of httpReq as recorded in the attempt history, redacted
+// like request_preview
+func sentRequestHeaders(httpReq *http.Request, redaction utils.Redaction) map[string]string {
+	headers := redactRequestHeaders(httpReq, redaction)
+	redaction.Headers = nil
+	for name, value := range headers {
+		headers[name] = redaction.Apply(value)
+	}
+	return headers
+}
+
+// RequestHeadersSentValue returns the headers sent with the last recorded attempt as the
+// request_headers_sent attribute value
+func RequestHeadersSentValue(records []AttemptRecord) types.Map {
+	if len(records) == 0 || records[len(records)-1].RequestHeaders == nil {
+		return types.MapNull(types.StringType)
+	}
+	elements := make(map[string]attr.Value, len(records[len(records)-1].RequestHeaders))
+	for name, value := range records[len(records)-1].RequestHeaders {
+		elements[name] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
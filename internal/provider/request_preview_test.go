@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRequestPreview(t *testing.T) {
+	ctx := context.Background()
+	model := &HttpxRequestResourceModel{
+		Url:    types.StringValue("https://api.example.com/users/{id}"),
+		Method: types.StringValue("POST"),
+		PathParams: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"id": types.StringValue("42"),
+		}),
+		Headers: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"X-Api-Key":        types.StringValue("secret-key"),
+			"X-Internal-Token": types.StringValue("internal-secret"),
+			"X-Trace":          types.StringValue("abc"),
+		}),
+		Query: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"dry": types.StringValue("true"),
+		}),
+		Cookies:     types.MapNull(types.StringType),
+		Body:        types.StringValue(`{"name":"test"}`),
+		BodyJson:    types.StringNull(),
+		BodyObject:  types.DynamicNull(),
+		BodyFile:    types.StringNull(),
+		BearerToken: types.StringValue("token"),
+		RedactHeaders: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("X-Internal-Token"),
+		}),
+	}
+	providerConfig := &ProviderConfig{RedactHeaders: []string{"X-Api-Key"}}
+
+	preview, _, err := buildRequestPreview(ctx, model, providerConfig)
+	assert.NoError(t, err)
+	assert.Contains(t, preview, "POST https://api.example.com/users/42?dry=true\n")
+	assert.Contains(t, preview, "Authorization: [REDACTED]")
+	assert.Contains(t, preview, "X-Api-Key: [REDACTED]")
+	assert.Contains(t, preview, "X-Internal-Token: [REDACTED]")
+	assert.Contains(t, preview, "X-Trace: abc")
+	assert.Contains(t, preview, "Body: 15 bytes")
+	assert.NotContains(t, preview, "secret-key")
+	assert.NotContains(t, preview, "internal-secret")
+	assert.NotContains(t, preview, "Bearer token")
+	assert.Equal(t, []string{"X-Api-Key"}, providerConfig.RedactHeaders, "provider config must not be modified")
+
+	model.Method = types.StringValue("GET")
+	model.Body = types.StringNull()
+	preview, _, err = buildRequestPreview(ctx, model, providerConfig)
+	assert.NoError(t, err)
+	assert.Contains(t, preview, "Body: none")
+}
+
+func TestRequestPreviewInputsKnown(t *testing.T) {
+	model := &HttpxRequestResourceModel{
+		Url:        types.StringValue("https://api.example.com"),
+		Method:     types.StringValue("GET"),
+		PathParams: types.MapNull(types.StringType),
+		Headers:    types.MapNull(types.StringType),
+		Query:      types.MapNull(types.StringType),
+		Cookies:    types.MapNull(types.StringType),
+		Body:       types.StringNull(),
+		BodyJson:   types.StringNull(),
+		BodyObject: types.DynamicNull(),
+		BodyFile:   types.StringNull(),
+		BasicAuth:  &ResourceBasicAuthModel{Username: types.StringValue("user"), Password: types.StringValue("pass")},
+	}
+	assert.True(t, requestPreviewInputsKnown(model))
+
+	model.BasicAuth.Password = types.StringUnknown()
+	assert.False(t, requestPreviewInputsKnown(model))
+
+	model.BasicAuth = nil
+	model.Url = types.StringUnknown()
+	assert.False(t, requestPreviewInputsKnown(model))
+}
+
+func TestEnsureRequestPreview(t *testing.T) {
+	model := &HttpxRequestResourceModel{
+		Url:            types.StringValue("https://api.example.com"),
+		Method:         types.StringValue("GET"),
+		RequestPreview: types.StringUnknown(),
+	}
+	ensureRequestPreview(context.Background(), model, &ProviderConfig{})
+	assert.Equal(t, "GET https://api.example.com\nBody: none", model.RequestPreview.ValueString())
+
+	model.RequestPreview = types.StringValue("planned")
+	ensureRequestPreview(context.Background(), model, &ProviderConfig{})
+	assert.Equal(t, "planned", model.RequestPreview.ValueString())
+
+	// effective_request_url is filled on its own and masks redacted query parameters
+	model.Url = types.StringValue("https://api.example.com/users/{id}?sig=s3cr3t")
+	model.PathParams = types.MapValueMust(types.StringType, map[string]attr.Value{"id": types.StringValue("42")})
+	model.Query = types.MapValueMust(types.StringType, map[string]attr.Value{"page": types.StringValue("2")})
+	model.EffectiveRequestUrl = types.StringUnknown()
+	ensureRequestPreview(context.Background(), model, &ProviderConfig{RedactQueryParams: []string{"sig"}})
+	assert.Equal(t, "https://api.example.com/users/42?page=2&sig=[REDACTED]", model.EffectiveRequestUrl.ValueString())
+}
+
+func TestRequestHeadersSent(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	token := "abc123"
+	providerConfig := &ProviderConfig{
+		TimeoutMs:            5000,
+		MaxResponseBodyBytes: 1024,
+		DefaultHeaders:       map[string]string{"Accept": "application/json", "X-Team": "platform"},
+		BearerToken:          &token,
+		RedactHeaders:        []string{"X-Api-Key"},
+	}
+	httpReq, err := BuildRequest(context.Background(), &RequestConfig{
+		Url:              server.URL,
+		Method:           "POST",
+		Headers:          map[string]string{"accept": "text/plain", "X-Api-Key": "k3y"},
+		BodyJson:         types.StringValue(`{"a":1}`),
+		ProviderDefaults: providerConfig,
+	})
+	assert.NoError(t, err)
+
+	result, err := ExecuteRequestWithRetry(context.Background(), httpReq, providerConfig, nil, nil, nil)
+	assert.NoError(t, err)
+
+	sent := RequestHeadersSentValue(result.AttemptHistory).Elements()
+	assert.Equal(t, types.StringValue("text/plain"), sent["Accept"], "resource headers override provider defaults")
+	assert.Equal(t, types.StringValue("platform"), sent["X-Team"])
+	assert.Equal(t, types.StringValue("application/json"), sent["Content-Type"])
+	assert.Equal(t, types.StringValue("[REDACTED]"), sent["Authorization"])
+	assert.Equal(t, types.StringValue("[REDACTED]"), sent["X-Api-Key"])
+	assert.Equal(t, "Bearer abc123", received.Get("Authorization"))
+
+	assert.True(t, RequestHeadersSentValue(nil).IsNull())
+}
@@ -0,0 +1,425 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// batchRollbackTimeout bounds the rollback requests of a failed batch together
+const batchRollbackTimeout = 5 * time.Minute
+
+var _ resource.Resource = &HttpxBatchResource{}
+var _ resource.ResourceWithConfigure = &HttpxBatchResource{}
+var _ resource.ResourceWithValidateConfig = &HttpxBatchResource{}
+
+// HttpxBatchResource executes its requests in order at create. When one fails, the rollback
+// requests of those that completed run in reverse order before the apply fails, for APIs without
+// transactions where a partially created set of objects is worse than none.
+type HttpxBatchResource struct {
+	config *ProviderConfig
+}
+
+func NewHttpxBatchResource() resource.Resource {
+	return &HttpxBatchResource{}
+}
+
+func (r *HttpxBatchResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_batch"
+}
+
+func (r *HttpxBatchResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that executes requests in order, skipping those whose when condition on the previous response doesn't match, and, if any fails, runs the rollback requests of the completed ones in reverse order before failing the apply. Changing any request replaces the batch; destroying it only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Resource identifier",
+			},
+			"status_codes": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "HTTP status code of each request that ran, by name",
+			},
+			"response_bodies": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Response body of each request that ran, by name",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request": schema.ListNestedBlock{
+				Description: "Requests to execute, in order. A request fails on an error or a non-2xx response.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Unique name of the request, used as its key in status_codes and response_bodies",
+						},
+						"url": schema.StringAttribute{
+							Required:    true,
+							Description: "The URL to send the request to",
+						},
+						"method": schema.StringAttribute{
+							Optional:    true,
+							Description: "HTTP method (GET, POST, PUT, PATCH, DELETE, etc.; default: GET)",
+						},
+						"headers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Request headers as a map",
+						},
+						"query": schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Query parameters",
+						},
+						"body": schema.StringAttribute{
+							Optional:    true,
+							Description: "Raw request body",
+						},
+						"bearer_token": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Bearer token for authentication",
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"when": schema.SingleNestedBlock{
+							Description: "Run the request only if the response of the last request that ran matches; otherwise it is skipped and has no entry in status_codes or response_bodies. Not allowed on the first request.",
+							Attributes: map[string]schema.Attribute{
+								"json_path": schema.StringAttribute{
+									Optional:    true,
+									Description: "JSON path evaluated against the previous response body (required in the block)",
+								},
+								"equals": schema.StringAttribute{
+									Optional:    true,
+									Description: "Run the request if the value at json_path equals this, compared as JSON when it parses as JSON",
+								},
+								"not_equals": schema.StringAttribute{
+									Optional:    true,
+									Description: "Run the request if the value at json_path differs from this or is missing",
+								},
+							},
+						},
+						"rollback": schema.SingleNestedBlock{
+							Description: "Compensation request undoing this request, run when a later request fails. url, header values and body may reference this request's response with ${self.response_body} and ${self.response_headers.NAME}; the request's own headers are sent along, without credentials when the URL is on another scheme or host.",
+							Attributes: map[string]schema.Attribute{
+								"url": schema.StringAttribute{
+									Optional:    true,
+									Description: "URL of the rollback request, relative URLs resolving against the request's URL (required in the block)",
+								},
+								"method": schema.StringAttribute{
+									Optional:    true,
+									Description: "HTTP method of the rollback request (default: DELETE)",
+								},
+								"headers": schema.MapAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Additional headers for the rollback request",
+								},
+								"query": schema.MapAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Query parameters for the rollback request",
+								},
+								"body": schema.StringAttribute{
+									Optional:    true,
+									Description: "Body of the rollback request",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *HttpxBatchResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ProviderConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *ProviderConfig, got something else",
+		)
+		return
+	}
+
+	r.config = config
+}
+
+func (r *HttpxBatchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var requests []BatchRequestModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("request"), &requests)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool, len(requests))
+	for i, spec := range requests {
+		requestPath := path.Root("request").AtListIndex(i)
+		if !spec.Name.IsNull() && !spec.Name.IsUnknown() {
+			name := spec.Name.ValueString()
+			if seen[name] {
+				resp.Diagnostics.AddAttributeError(requestPath.AtName("name"), "Duplicate request name", fmt.Sprintf("Request name %q is used more than once", name))
+			}
+			seen[name] = true
+		}
+		// Method typos would otherwise only surface during apply, after earlier requests ran
+		validatePlanMethod(spec.Method, types.BoolNull(), requestPath.AtName("method"), &resp.Diagnostics)
+		if spec.When != nil {
+			whenPath := requestPath.AtName("when")
+			switch {
+			case i == 0:
+				resp.Diagnostics.AddAttributeError(whenPath, "Invalid when", "The first request has no previous response to test")
+			case spec.When.JsonPath.IsNull():
+				resp.Diagnostics.AddAttributeError(whenPath.AtName("json_path"), "Missing json_path", "when requires json_path")
+			case spec.When.Equals.IsNull() == spec.When.NotEquals.IsNull():
+				resp.Diagnostics.AddAttributeError(whenPath, "Invalid when", "when requires exactly one of equals or not_equals")
+			}
+		}
+		if spec.Rollback != nil {
+			if spec.Rollback.Url.IsNull() {
+				resp.Diagnostics.AddAttributeError(requestPath.AtName("rollback").AtName("url"), "Missing rollback URL", "rollback requires url")
+			}
+			validatePlanMethod(spec.Rollback.Method, types.BoolNull(), requestPath.AtName("rollback").AtName("method"), &resp.Diagnostics)
+		}
+	}
+}
+
+func (r *HttpxBatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model HttpxBatchResourceModel
+
+	// Read Terraform plan into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.Id = types.StringValue(generateBatchResourceID(model))
+
+	// In dry-run mode the requests are only logged
+	if r.config.DryRun {
+		for _, spec := range model.Requests {
+			httpReq, err := buildBatchRequest(ctx, spec, r.config)
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to build request", fmt.Sprintf("request %q: %s", spec.Name.ValueString(), err))
+				return
+			}
+			logDryRunRequest(ctx, httpReq, r.config)
+		}
+		addDryRunNotSentError(&resp.Diagnostics, "create")
+		return
+	}
+
+	results, err := executeBatch(ctx, model.Requests, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError("Batch failed", err.Error())
+		return
+	}
+
+	setBatchResultValues(&model, results)
+
+	// Encrypt the response bodies when state_encryption_key is set
+	if err := sealResponseState(ctx, r.config, responseStateFields{StringMaps: []*types.Map{&model.ResponseBodies}}); err != nil {
+		resp.Diagnostics.AddError("Failed to encrypt state", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *HttpxBatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The requests ran once at create; there is nothing to refresh
+	var model HttpxBatchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *HttpxBatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every request change replaces the batch, so only the stored results carry over
+	var plan, state HttpxBatchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = state.Id
+	plan.StatusCodes = state.StatusCodes
+	plan.ResponseBodies = state.ResponseBodies
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *HttpxBatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Delete method called - removing httpx_batch from state")
+}
+
+// executeBatch executes requests in order and returns their results, nil for requests skipped by
+// their when condition. When a request fails, the completed requests are rolled back in reverse
+// order and the error describes both the failure and the outcome of each rollback.
+func executeBatch(ctx context.Context, requests []BatchRequestModel, providerConfig *ProviderConfig) ([]*ResponseResult, error) {
+	results := make([]*ResponseResult, len(requests))
+	var completed []BatchRequestModel
+	var httpReqs []*http.Request
+	var completedResults []*ResponseResult
+
+	for i, spec := range requests {
+		name := spec.Name.ValueString()
+		if len(completedResults) > 0 && !batchWhenMatches(ctx, spec.When, completedResults[len(completedResults)-1]) {
+			tflog.Info(ctx, "Skipping batch request, when condition not met", map[string]interface{}{"name": name})
+			continue
+		}
+
+		httpReq, err := buildBatchRequest(ctx, spec, providerConfig)
+		if err == nil {
+			var result *ResponseResult
+			execConfig := providerConfig.WithAuditSource(fmt.Sprintf("httpx_batch[%q]", name), "create")
+			result, err = ExecuteRequestWithRetry(ctx, httpReq, execConfig, nil, nil, nil)
+			if err != nil {
+				notifyFailure(ctx, httpReq, execConfig, result, err)
+			}
+			if err == nil && (result.StatusCode < 200 || result.StatusCode > 299) {
+				err = fmt.Errorf("received status %d", result.StatusCode)
+			}
+			if err == nil {
+				results[i] = result
+				completed = append(completed, spec)
+				httpReqs = append(httpReqs, httpReq)
+				completedResults = append(completedResults, result)
+				continue
+			}
+		}
+
+		messages := []string{fmt.Sprintf("request %q failed: %s", name, err)}
+		messages = append(messages, rollbackBatch(ctx, completed, httpReqs, completedResults, providerConfig)...)
+		return results, fmt.Errorf("%s", strings.Join(messages, "\n"))
+	}
+
+	return results, nil
+}
+
+// batchWhenMatches reports whether a request with condition when runs after previous, the response
+// of the last request that ran. A request without a condition always runs.
+func batchWhenMatches(ctx context.Context, when *BatchWhenModel, previous *ResponseResult) bool {
+	if when == nil {
+		return true
+	}
+	if !when.Equals.IsNull() {
+		return checkJsonPathConditions(ctx, previous.Body, map[string]string{when.JsonPath.ValueString(): when.Equals.ValueString()})
+	}
+	return !checkJsonPathConditions(ctx, previous.Body, map[string]string{when.JsonPath.ValueString(): when.NotEquals.ValueString()})
+}
+
+// rollbackBatch runs the rollback requests of the completed requests in reverse order, continuing
+// past failures so as much as possible is undone, and describes the outcome of each. They run
+// even when ctx was cancelled, e.g. by an interrupted apply, within batchRollbackTimeout.
+func rollbackBatch(ctx context.Context, completed []BatchRequestModel, httpReqs []*http.Request, results []*ResponseResult, providerConfig *ProviderConfig) []string {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), batchRollbackTimeout)
+	defer cancel()
+
+	var messages []string
+	for i := len(completed) - 1; i >= 0; i-- {
+		name := completed[i].Name.ValueString()
+		rollback := completed[i].Rollback
+		if rollback == nil {
+			messages = append(messages, fmt.Sprintf("request %q has no rollback and was left in place", name))
+			continue
+		}
+
+		method := http.MethodDelete
+		if !rollback.Method.IsNull() && !rollback.Method.IsUnknown() && rollback.Method.ValueString() != "" {
+			method = strings.ToUpper(rollback.Method.ValueString())
+		}
+		headers, err := ConvertTerraformMap(ctx, rollback.Headers)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("rollback of %q failed: invalid headers: %s", name, err))
+			continue
+		}
+		query, err := ConvertTerraformMap(ctx, rollback.Query)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("rollback of %q failed: invalid query: %s", name, err))
+			continue
+		}
+
+		rollbackConfig := providerConfig.WithAuditSource(fmt.Sprintf("httpx_batch[%q]", name), "rollback")
+		if _, err := followUpRequest(ctx, httpReqs[i], rollbackConfig, nil, results[i], method, rollback.Url.ValueString(), headers, query, rollback.Body.ValueString()); err != nil {
+			messages = append(messages, fmt.Sprintf("rollback of %q failed: %s", name, err))
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("request %q was rolled back", name))
+	}
+	return messages
+}
+
+// buildBatchRequest builds the HTTP request for one request block
+func buildBatchRequest(ctx context.Context, spec BatchRequestModel, providerConfig *ProviderConfig) (*http.Request, error) {
+	headers, err := ConvertTerraformMap(ctx, spec.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid headers: %w", err)
+	}
+	query, err := ConvertTerraformMap(ctx, spec.Query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	return BuildRequest(ctx, &RequestConfig{
+		Url:              spec.Url.ValueString(),
+		Method:           spec.Method.ValueString(),
+		Headers:          headers,
+		Query:            query,
+		Body:             spec.Body,
+		BearerToken:      spec.BearerToken,
+		ProviderDefaults: providerConfig,
+	})
+}
+
+// setBatchResultValues fills status_codes and response_bodies from the results of the requests
+// that ran
+func setBatchResultValues(model *HttpxBatchResourceModel, results []*ResponseResult) {
+	statusCodes := make(map[string]attr.Value, len(results))
+	responseBodies := make(map[string]attr.Value, len(results))
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		name := model.Requests[i].Name.ValueString()
+		statusCodes[name] = types.Int64Value(result.StatusCode)
+		responseBodies[name] = types.StringValue(result.Body)
+	}
+	model.StatusCodes = types.MapValueMust(types.Int64Type, statusCodes)
+	model.ResponseBodies = types.MapValueMust(types.StringType, responseBodies)
+}
+
+// generateBatchResourceID generates a stable ID from the batch's requests
+func generateBatchResourceID(model HttpxBatchResourceModel) string {
+	parts := make([]string, 0, len(model.Requests))
+	for _, spec := range model.Requests {
+		parts = append(parts, fmt.Sprintf("%s|%s|%s", spec.Name.ValueString(), spec.Method.ValueString(), spec.Url.ValueString()))
+	}
+	hash := sha256.Sum256([]byte("BATCH|" + strings.Join(parts, "|")))
+	return hex.EncodeToString(hash[:])[:16]
+}
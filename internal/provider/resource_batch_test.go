@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteBatch(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		switch {
+		case r.URL.Path == "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/status":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"status":"pending"}`))
+		case r.Method == http.MethodPost:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"` + r.URL.Path[1:] + `-1"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	post := func(name, path string, rollback *BatchRollbackModel) BatchRequestModel {
+		return BatchRequestModel{
+			Name:     types.StringValue(name),
+			Url:      types.StringValue(server.URL + path),
+			Method:   types.StringValue("POST"),
+			Headers:  types.MapNull(types.StringType),
+			Query:    types.MapNull(types.StringType),
+			Rollback: rollback,
+		}
+	}
+	rollback := func(url string) *BatchRollbackModel {
+		return &BatchRollbackModel{Url: types.StringValue(url), Headers: types.MapNull(types.StringType), Query: types.MapNull(types.StringType)}
+	}
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}
+
+	// Every request succeeds, so nothing is rolled back
+	requests := []BatchRequestModel{
+		post("network", "/networks", rollback("/networks/${jsonpath(self.response_body, \"id\")}")),
+		post("subnet", "/subnets", rollback("/subnets/${jsonpath(self.response_body, \"id\")}")),
+	}
+	results, err := executeBatch(context.Background(), requests, providerConfig)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, []string{"POST /networks", "POST /subnets"}, calls)
+
+	model := HttpxBatchResourceModel{Requests: requests}
+	setBatchResultValues(&model, results)
+	assert.Equal(t, types.Int64Value(200), model.StatusCodes.Elements()["subnet"])
+	assert.Equal(t, types.StringValue(`{"id":"subnets-1"}`), model.ResponseBodies.Elements()["subnet"])
+
+	// A failure rolls back the completed requests in reverse order
+	calls = nil
+	requests = []BatchRequestModel{
+		post("network", "/networks", rollback("/networks/${jsonpath(self.response_body, \"id\")}")),
+		post("dns", "/records", nil),
+		post("subnet", "/subnets", rollback("/subnets/${jsonpath(self.response_body, \"id\")}")),
+		post("gateway", "/broken", rollback("/never")),
+	}
+	_, err = executeBatch(context.Background(), requests, providerConfig)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"POST /networks", "POST /records", "POST /subnets", "POST /broken", "DELETE /subnets/subnets-1", "DELETE /networks/networks-1"}, calls)
+	assert.Contains(t, err.Error(), `request "gateway" failed: received status 500`)
+	assert.Contains(t, err.Error(), `request "subnet" was rolled back`)
+	assert.Contains(t, err.Error(), `request "dns" has no rollback and was left in place`)
+
+	// A failed rollback is reported and the remaining rollbacks still run
+	calls = nil
+	requests = []BatchRequestModel{
+		post("network", "/networks", rollback("/networks/${jsonpath(self.response_body, \"id\")}")),
+		post("subnet", "/subnets", rollback("/broken")),
+		post("gateway", "/broken", nil),
+	}
+	_, err = executeBatch(context.Background(), requests, providerConfig)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"POST /networks", "POST /subnets", "POST /broken", "DELETE /broken", "DELETE /networks/networks-1"}, calls)
+	assert.Contains(t, err.Error(), `rollback of "subnet" failed: DELETE returned status 500`)
+	assert.Contains(t, err.Error(), `request "network" was rolled back`)
+
+	// A request whose when condition on the previous response doesn't match is skipped
+	calls = nil
+	requests = []BatchRequestModel{
+		post("status", "/status", nil),
+		post("create", "/create", nil),
+		post("activate", "/activate", nil),
+		post("notify", "/notify", nil),
+	}
+	requests[1].When = &BatchWhenModel{JsonPath: types.StringValue("status"), Equals: types.StringValue("active"), NotEquals: types.StringNull()}
+	requests[2].When = &BatchWhenModel{JsonPath: types.StringValue("status"), Equals: types.StringNull(), NotEquals: types.StringValue("active")}
+	requests[3].When = &BatchWhenModel{JsonPath: types.StringValue("id"), Equals: types.StringValue("activate-1"), NotEquals: types.StringNull()}
+	results, err = executeBatch(context.Background(), requests, providerConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"POST /status", "POST /activate", "POST /notify"}, calls)
+	assert.Nil(t, results[1])
+
+	model = HttpxBatchResourceModel{Requests: requests}
+	setBatchResultValues(&model, results)
+	assert.Len(t, model.StatusCodes.Elements(), 3)
+	assert.NotContains(t, model.StatusCodes.Elements(), "create")
+}
+
+func TestExecuteBatchRollsBackAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/interrupted" {
+			// The apply is interrupted while this request is in flight
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	requests := []BatchRequestModel{
+		{
+			Name:    types.StringValue("network"),
+			Url:     types.StringValue(server.URL + "/networks"),
+			Method:  types.StringValue("POST"),
+			Headers: types.MapNull(types.StringType),
+			Query:   types.MapNull(types.StringType),
+			Rollback: &BatchRollbackModel{
+				Url:     types.StringValue("/networks/1"),
+				Headers: types.MapNull(types.StringType),
+				Query:   types.MapNull(types.StringType),
+			},
+		},
+		{
+			Name:    types.StringValue("subnet"),
+			Url:     types.StringValue(server.URL + "/interrupted"),
+			Method:  types.StringValue("POST"),
+			Headers: types.MapNull(types.StringType),
+			Query:   types.MapNull(types.StringType),
+		},
+	}
+	_, err := executeBatch(ctx, requests, &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `request "network" was rolled back`)
+	assert.Equal(t, []string{"POST /networks", "POST /interrupted", "DELETE /networks/1"}, calls)
+}
+
+func TestBatchValidateConfigMethods(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewHttpxBatchResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	requestType := objectType.AttributeTypes["request"].(tftypes.List).ElementType.(tftypes.Object)
+	rollbackType := requestType.AttributeTypes["rollback"].(tftypes.Object)
+
+	request := nullObject(requestType, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "org"),
+		"url":      tftypes.NewValue(tftypes.String, "https://api.example.com/orgs"),
+		"method":   tftypes.NewValue(tftypes.String, "PSOT"),
+		"rollback": nullObject(rollbackType, map[string]tftypes.Value{"url": tftypes.NewValue(tftypes.String, "/orgs/1"), "method": tftypes.NewValue(tftypes.String, "DELTE")}),
+	})
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: nullObject(objectType, map[string]tftypes.Value{
+		"request": tftypes.NewValue(tftypes.List{ElementType: requestType}, []tftypes.Value{request}),
+	})}
+
+	var resp resource.ValidateConfigResponse
+	NewHttpxBatchResource().(*HttpxBatchResource).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+	assert.Equal(t, 2, resp.Diagnostics.ErrorsCount())
+	for _, d := range resp.Diagnostics.Errors() {
+		assert.Equal(t, "Invalid method", d.Summary())
+	}
+}
+
+func TestBatchResourceSchema(t *testing.T) {
+	var resp resource.SchemaResponse
+	NewHttpxBatchResource().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.False(t, resp.Schema.ValidateImplementation(context.Background()).HasError())
+}
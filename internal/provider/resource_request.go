@@ -4,18 +4,25 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &HttpxRequestResource{}
 var _ resource.ResourceWithConfigure = &HttpxRequestResource{}
+var _ resource.ResourceWithValidateConfig = &HttpxRequestResource{}
 
 type HttpxRequestResource struct {
 	config *ProviderConfig
@@ -29,7 +36,7 @@ func (r *HttpxRequestResource) Metadata(_ context.Context, req resource.Metadata
 	resp.TypeName = req.ProviderTypeName + "_request"
 }
 
-func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *HttpxRequestResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Resource for executing HTTP requests with retry logic and conditional polling",
 		Attributes: map[string]schema.Attribute{
@@ -37,36 +44,92 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 				Computed:    true,
 				Description: "Resource identifier",
 			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC 3339 timestamp of when the resource was created",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_response_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC 3339 timestamp of when the request was last executed",
+			},
+			"request_preview": schema.StringAttribute{
+				Computed:    true,
+				Description: "Summary of the request an apply will make (method, resolved URL, headers with sensitive values redacted and body size), rendered at plan time",
+			},
+			"effective_request_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "Fully resolved request URL after path parameter substitution and query merging, with redacted query parameters masked, rendered at plan time",
+			},
+			"request_fingerprint": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex SHA-256 of every input that shapes the request (method, URL, path_params, headers, query, cookies and body), excluding credentials. The id is derived from it at create.",
+			},
 			"url": schema.StringAttribute{
 				Required:    true,
 				Description: "The URL to make the request to",
 			},
+			"path_params": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Values substituted for {name} tokens in the URL, path-escaped so IDs containing '/' or spaces stay in one segment",
+			},
 			"method": schema.StringAttribute{
-				Required:    true,
-				Description: "HTTP method (GET, POST, PUT, PATCH, DELETE, etc.)",
+				Optional:    true,
+				Description: "HTTP method (GET, POST, PUT, PATCH, DELETE, etc.; default: GET)",
+			},
+			"allow_custom_methods": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Allow methods other than the standard HTTP methods, e.g. PROPFIND or PURGE (default: false)",
 			},
 			"headers": schema.MapAttribute{
+				ElementType:        types.StringType,
+				Optional:           true,
+				DeprecationMessage: headersDeprecationMessage,
+				Description:        "Request headers as a map. Deprecated in favor of request_headers.",
+			},
+			"request_headers": schema.MapAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
-				Description: "Request headers as a map",
+				Description: "Request headers as a map. Replaces headers; repeated or case-sensitive headers still use header blocks.",
 			},
 			"query": schema.MapAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Description: "Query parameters",
 			},
+			"cookies": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Cookies to send, rendered into the Cookie header (values are quoted when needed)",
+			},
+			"preserve_header_case": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Send header names exactly as configured instead of canonicalizing them, for legacy servers with case-sensitive header handling (HTTP/1.x only; HTTP/2 always lowercases)",
+			},
 			"body": schema.StringAttribute{
 				Optional:    true,
 				Description: "Raw request body (mutually exclusive with body_json and body_file)",
 			},
 			"body_json": schema.StringAttribute{
 				Optional:    true,
-				Description: "JSON-encodable object (mutually exclusive with body and body_file)",
+				Description: "JSON-encodable object (mutually exclusive with body, body_object and body_file)",
+			},
+			"body_object": schema.DynamicAttribute{
+				Optional:    true,
+				Description: "Request body written as a native HCL object or list, serialized to JSON with sorted keys (mutually exclusive with body, body_json and body_file)",
 			},
 			"body_file": schema.StringAttribute{
 				Optional:    true,
 				Description: "Path to file to read and send (mutually exclusive with body and body_json)",
 			},
+			"auto_content_digest": schema.StringAttribute{
+				Optional:    true,
+				Description: "Compute a digest of the request body and send it: \"sha256\" sets Content-Digest, \"md5\" sets Content-MD5. An explicitly configured header is kept.",
+			},
 			"bearer_token": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
@@ -76,6 +139,23 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 				Optional:    true,
 				Description: "Request timeout in milliseconds",
 			},
+			"connect_timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time allowed to establish a TCP connection, in milliseconds (overrides the provider setting)",
+			},
+			"tls_handshake_timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time allowed for the TLS handshake, in milliseconds (overrides the provider setting)",
+			},
+			"response_header_timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time allowed between sending the request and receiving the response headers, in milliseconds (overrides the provider setting)",
+			},
+			"pinned_spki_sha256": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Base64 SHA-256 SubjectPublicKeyInfo digests, one of which the server must present (overrides the provider setting)",
+			},
 			"insecure_skip_verify": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Skip TLS certificate verification",
@@ -92,29 +172,220 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 				Optional:    true,
 				Description: "Whether to store response body in state. Defaults to true, but defaults to false if extract blocks are present (unless explicitly set to true).",
 			},
+			"fail_on_extract_error": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Fail the create, update or refresh when an extract block does not resolve (missing JSON path, header, cookie or link) instead of warning and setting its output to \"\" (default: false)",
+			},
+			"max_response_body_bytes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum response body size in bytes for this request. Overrides the provider's max_response_body_bytes.",
+			},
+			"on_body_overflow": schema.StringAttribute{
+				Optional:    true,
+				Description: "What to do when the response body exceeds max_response_body_bytes: 'truncate' (default) keeps the first bytes followed by a truncation marker, 'fail' returns an error instead of a corrupted body.",
+			},
+			"redact_headers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Additional headers to redact in logs and diagnostics for this request, e.g. [\"X-Internal-Token\"]. Appended to the provider's redact_headers.",
+			},
+			"nonce_header": schema.StringAttribute{
+				Optional:    true,
+				Description: "Header that carries a generated anti-replay nonce (128 random bits, hex encoded), e.g. \"X-Nonce\"",
+			},
+			"nonce_scope": schema.StringAttribute{
+				Optional:    true,
+				Description: "When a new nonce is generated: 'attempt' (default) for every attempt including retries, or 'request' for one value shared by all attempts of an execution",
+			},
+			"capture_transcript": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Store a redacted request/response transcript of every attempt (headers and bodies truncated to 2KB) in transcript, for debugging a single resource without enabling debug logging for the provider",
+			},
+			"response_body_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local path to write the response body to, e.g. for a rendered artifact consumed by a later local-exec or archive step. Parent directories are created as needed. Independent of store_response_body.",
+			},
+			"range": schema.StringAttribute{
+				Optional:    true,
+				Description: "Byte range to request, sent as the Range header, e.g. \"bytes=0-1048575\"",
+			},
+			"transfer_encoding": schema.StringAttribute{
+				Optional:    true,
+				Description: "How the request body is framed on HTTP/1.1, instead of Go's automatic choice: 'chunked' always streams it with Transfer-Encoding: chunked, 'identity' always sends Content-Length, buffering the body when its size isn't known up front, for servers that refuse chunked bodies",
+			},
+			"resume": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Resume interrupted downloads on retry by requesting only the bytes not yet received (with If-Range when the response has an ETag or Last-Modified), instead of restarting from byte zero. Only applies to a single \"bytes=start-[end]\" range or no range.",
+			},
+			"response_header_names": schema.StringAttribute{
+				Optional:    true,
+				Description: "How response_headers names are cased: 'canonical' (default) as in Content-Type, or 'lower' as in content-type, so references don't depend on the casing a server or protocol version uses",
+			},
+			"ignore_response_headers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Response headers to leave out of response_headers, e.g. [\"Date\", \"X-Request-Id\", \"Cf-.*\"]. Entries are regular expressions matched case-insensitively against the full header name. Avoids perpetual diffs from volatile headers with read_mode = \"refresh\".",
+			},
+			"normalize_response_body": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Store JSON response bodies re-serialized compactly with sorted keys, so key-order changes don't cause diffs. Non-JSON bodies are stored unchanged.",
+			},
+			"ignore_body_paths": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "JSON paths removed from the response body before it is stored, e.g. [\"meta.request_id\", \"items[*].updated_at\"]. Implies normalize_response_body. Extraction still sees the full body.",
+			},
 			"read_mode": schema.StringAttribute{
 				Optional:    true,
-				Description: "Read behavior: 'none' or 'refresh'",
+				Description: "Read behavior: 'none', 'refresh', or 'refresh_if_older_than' (re-execute only when last_response_at is older than refresh_interval)",
+			},
+			"refresh_interval": schema.StringAttribute{
+				Optional:    true,
+				Description: "Minimum age of last_response_at before a refresh re-executes the request, as a Go duration such as '24h'. Used with read_mode = \"refresh_if_older_than\".",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the request is executed. When false, create and update skip the HTTP call and computed attributes are set to null. A request disabled after it executed keeps its last response, and on_destroy still runs when it is destroyed. Defaults to true.",
 			},
 			"status_code": schema.Int64Attribute{
 				Computed:    true,
 				Description: "HTTP status code",
 			},
+			"status_text": schema.StringAttribute{
+				Computed:    true,
+				Description: "HTTP status text of the final response, e.g. \"OK\" or \"Service Unavailable\"",
+			},
+			"protocol": schema.StringAttribute{
+				Computed:    true,
+				Description: "Protocol negotiated for the final response, e.g. \"HTTP/1.1\" or \"HTTP/2.0\"",
+			},
+			"remote_addr": schema.StringAttribute{
+				Computed:    true,
+				Description: "Remote IP:port the final attempt was sent to (the proxy when one is used), null when no network connection was made",
+			},
+			"tls_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "TLS version negotiated for the final attempt, e.g. \"TLS 1.3\", null for plain HTTP",
+			},
+			"tls_cipher_suite": schema.StringAttribute{
+				Computed:    true,
+				Description: "TLS cipher suite negotiated for the final attempt, e.g. \"TLS_AES_128_GCM_SHA256\", null for plain HTTP",
+			},
+			"peer_cert_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex SHA-256 fingerprint of the server's leaf certificate on the final attempt, null for plain HTTP",
+			},
 			"response_headers": schema.MapAttribute{
 				ElementType: types.StringType,
 				Computed:    true,
 				Description: "Response headers",
 			},
+			"response_cookies": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "Cookies parsed from Set-Cookie response headers, keyed by cookie name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Computed:    true,
+							Description: "Cookie value",
+						},
+						"domain": schema.StringAttribute{
+							Computed:    true,
+							Description: "Domain attribute",
+						},
+						"path": schema.StringAttribute{
+							Computed:    true,
+							Description: "Path attribute",
+						},
+						"expires": schema.StringAttribute{
+							Computed:    true,
+							Description: "Expires attribute in RFC 3339 format",
+						},
+						"max_age": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Max-Age attribute in seconds",
+						},
+						"secure": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the Secure attribute is set",
+						},
+						"http_only": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the HttpOnly attribute is set",
+						},
+						"same_site": schema.StringAttribute{
+							Computed:    true,
+							Description: "SameSite attribute: 'Lax', 'Strict', or 'None'",
+						},
+					},
+				},
+			},
+			"response_links": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Targets of the RFC 8288 Link response header keyed by relation type (e.g. next, prev), resolved against the effective URL",
+			},
+			"effective_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "Final URL of the request after following redirects",
+			},
+			"redirect_chain": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Redirects followed to reach effective_url, in order",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"url": schema.StringAttribute{
+							Computed:    true,
+							Description: "URL that returned the redirect",
+						},
+						"status_code": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Redirect status code",
+						},
+						"location": schema.StringAttribute{
+							Computed:    true,
+							Description: "Resolved URL the redirect pointed to",
+						},
+					},
+				},
+			},
 			"response_body": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   false, // Will be set dynamically based on response_sensitive
 				Description: "Response body",
 			},
+			"response_body_json": schema.DynamicAttribute{
+				Computed:    true,
+				Description: "Response body parsed as JSON for native indexing (null when the body is not JSON or not stored)",
+			},
+			"response_body_file_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex-encoded SHA-256 of the body written to response_body_file",
+			},
 			"outputs": schema.MapAttribute{
 				ElementType: types.StringType,
 				Computed:    true,
 				Description: "Extracted values from extract blocks",
 			},
+			"outputs_lists": schema.MapAttribute{
+				ElementType: types.ListType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "Extracted arrays from extract blocks whose json_path resolves to an array (or that set for_each_path)",
+			},
+			"private_outputs": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Names of extract blocks whose values are kept out of outputs and stored in private state instead, published under shared_context for depends_on_outputs of other resources. on_destroy can still reference them as ${self.outputs.NAME}.",
+			},
+			"shared_context": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name under which private_outputs are published, referenced as ${shared.NAME.OUTPUT} in depends_on_outputs. They are published whenever this resource is created, updated or refreshed, and consumers keep a copy in their private state for runs where this resource isn't applied",
+			},
+			"depends_on_outputs": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Request headers whose values may reference private_outputs of other resources as ${shared.CONTEXT.OUTPUT}, e.g. { Authorization = \"Bearer ${shared.login.token}\" }. Use depends_on to order this resource after the one publishing them. The resolved values are kept in this resource's private state, so refreshes, updates and destroys still work in runs where the publishing resource isn't applied; a create needs it to be created or updated in the same apply",
+			},
 			"last_attempt_count": schema.Int64Attribute{
 				Computed:    true,
 				Description: "Number of attempts made",
@@ -123,6 +394,57 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 				Computed:    true,
 				Description: "Last error message (redacted)",
 			},
+			"error_response_body": schema.StringAttribute{
+				Computed:    true,
+				Description: "Final response body (redacted, first 4096 bytes) of the last create or update that failed its expectations or exhausted its retries, kept for post-mortem debugging. A failed create is kept in state as tainted with a null id so this can be inspected; destroying it sends no on_destroy request. Null after a successful request.",
+			},
+			"attempt_history": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Per-attempt details of the last execution (most recent 20 attempts)",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"attempt": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Attempt number",
+						},
+						"status_code": schema.Int64Attribute{
+							Computed:    true,
+							Description: "HTTP status code (null on transport errors)",
+						},
+						"duration_ms": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Attempt duration in milliseconds",
+						},
+						"error": schema.StringAttribute{
+							Computed:    true,
+							Description: "Error message (redacted)",
+						},
+						"conditions_met": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether retry_until conditions were met (null without retry_until)",
+						},
+						"unsatisfied_conditions": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "retry_until conditions that were not met",
+						},
+					},
+				},
+			},
+			"transcript": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Redacted transcript of the attempts of the last execution when capture_transcript is true",
+			},
+			"nonce": schema.StringAttribute{
+				Computed:    true,
+				Description: "Nonce sent with the last attempt when nonce_header is set",
+			},
+			"request_headers_sent": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Final merged headers sent with the last attempt (provider defaults, headers, header blocks, auth and nonce), with sensitive values redacted",
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"header": schema.ListNestedBlock{
@@ -172,20 +494,43 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 					},
 					"backoff": schema.StringAttribute{
 						Optional:    true,
-						Description: "Backoff strategy: 'fixed', 'linear', or 'exponential'",
+						Description: "Backoff strategy: 'fixed', 'linear', 'exponential', or 'decorrelated'",
 					},
 					"jitter": schema.BoolAttribute{
 						Optional:    true,
 						Description: "Add jitter to retry delays",
 					},
+					"jitter_mode": schema.StringAttribute{
+						Optional:    true,
+						Description: "Jitter strategy: 'none', 'full', 'equal', or 'percentage' (adds up to 25%). Overrides jitter when set.",
+					},
 					"retry_on_status_codes": schema.ListAttribute{
 						ElementType: types.Int64Type,
 						Optional:    true,
 						Description: "HTTP status codes that should trigger a retry",
 					},
+					"retry_on_status_classes": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Status classes or ranges to retry on, e.g. [\"5xx\"] or [\"500-599\"]. When set without retry_on_status_codes, the default status codes are not used.",
+					},
 					"respect_retry_after": schema.BoolAttribute{
 						Optional:    true,
-						Description: "Respect Retry-After header if present",
+						Description: "Respect Retry-After and rate limit reset headers (RateLimit-Reset, X-RateLimit-Reset) if present",
+					},
+					"retry_on_errors": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Transport error classes that should trigger a retry: 'dns', 'connect', 'timeout', 'tls', 'reset', 'other' (any other transport error), or 'any'. Defaults to ['any'].",
+					},
+					"safe_methods_only": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Only retry transport errors that may have reached the server (timeouts, resets) for idempotent methods or requests carrying an Idempotency-Key header (default: true). DNS, connect and TLS errors are always retried",
+					},
+					"status_delay_overrides": schema.MapAttribute{
+						ElementType: types.Int64Type,
+						Optional:    true,
+						Description: "Delay in milliseconds to use for specific status codes instead of the backoff curve, e.g. { \"423\" = 30000 }. Retry-After still takes precedence when respected.",
 					},
 				},
 			},
@@ -197,6 +542,11 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 						Optional:    true,
 						Description: "Status codes that satisfy the condition",
 					},
+					"status_classes": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Status classes or ranges to wait for, e.g. [\"2xx\"] or [\"200-204\"]. A status matching either status_codes or status_classes satisfies the condition.",
+					},
 					"json_path_equals": schema.MapAttribute{
 						ElementType: types.StringType,
 						Optional:    true,
@@ -207,10 +557,150 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 						Optional:    true,
 						Description: "Header conditions that must equal specified values",
 					},
+					"header_equals_ignore_case": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Compare header_equals values case-insensitively, here and in condition blocks (default: false)",
+					},
 					"body_regex": schema.StringAttribute{
 						Optional:    true,
 						Description: "Regex pattern that must match the response body",
 					},
+					"jq": schema.StringAttribute{
+						Optional:    true,
+						Description: "jq expression that must evaluate to a truthy value (not false or null) against the JSON body, e.g. '.status == \"ready\"'",
+					},
+					"interval_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Fixed delay between polls when conditions are not met. Transport error retries keep the retry block's backoff. Defaults to the retry backoff.",
+					},
+					"initial_delay_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Delay before the first poll in milliseconds",
+					},
+					"consecutive_successes": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Number of consecutive polls that must satisfy the conditions before succeeding (default: 1)",
+					},
+					"match": schema.StringAttribute{
+						Optional:    true,
+						Description: "How the conditions combine: 'all' (default) requires every condition and condition block to be satisfied, 'any' requires at least one",
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"condition": schema.ListNestedBlock{
+						Description: "Condition group that counts as one condition of retry_until, e.g. status_codes = [409] with a jq check of the error, combined with status_codes = [200] and match = \"any\" to also accept an already-existing object",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"match": schema.StringAttribute{
+									Optional:    true,
+									Description: "How the group's conditions combine: 'all' (default) or 'any'",
+								},
+								"status_codes": schema.ListAttribute{
+									ElementType: types.Int64Type,
+									Optional:    true,
+									Description: "Status codes that satisfy the condition",
+								},
+								"status_classes": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Status classes or ranges that satisfy the condition, e.g. [\"2xx\"]",
+								},
+								"json_path_equals": schema.MapAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "JSON path conditions that must equal specified values",
+								},
+								"header_equals": schema.MapAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Header conditions that must equal specified values",
+								},
+								"body_regex": schema.StringAttribute{
+									Optional:    true,
+									Description: "Regex pattern that must match the response body",
+								},
+								"jq": schema.StringAttribute{
+									Optional:    true,
+									Description: "jq expression that must evaluate to a truthy value against the JSON body",
+								},
+							},
+						},
+					},
+				},
+			},
+			"abort_on": schema.SingleNestedBlock{
+				Description: "Conditions that stop retrying/polling immediately with an error. The request is aborted when any condition matches.",
+				Attributes: map[string]schema.Attribute{
+					"status_codes": schema.ListAttribute{
+						ElementType: types.Int64Type,
+						Optional:    true,
+						Description: "Status codes that abort the request",
+					},
+					"json_path_equals": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "JSON path conditions that abort the request when a path equals the specified value",
+					},
+					"body_regex": schema.StringAttribute{
+						Optional:    true,
+						Description: "Regex pattern that aborts the request when it matches the response body",
+					},
+				},
+			},
+			"treat_as_success": schema.SingleNestedBlock{
+				Description: "Responses accepted as success even though they failed, e.g. a 409 \"already exists\" from an idempotent create. They skip expect, and with follow_up_url the state is populated from a follow-up GET instead.",
+				Attributes: map[string]schema.Attribute{
+					"status_codes": schema.ListAttribute{
+						ElementType: types.Int64Type,
+						Optional:    true,
+						Description: "Status codes accepted as success",
+					},
+					"json_path_equals": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "JSON path conditions the accepted response must also meet, e.g. { \"error.code\" = \"ALREADY_EXISTS\" }",
+					},
+					"follow_up_url": schema.StringAttribute{
+						Optional:    true,
+						Description: "URL to GET with the request's headers once a response is accepted, to populate outputs and response attributes. Credentials are only sent when the URL has the request's scheme and host. Relative URLs resolve against the request URL; supports ${self.response_body}, ${self.response_headers.NAME} and template functions such as ${jsonpath(self.response_body, \"existing.id\")}.",
+					},
+				},
+			},
+			"on_conflict": schema.SingleNestedBlock{
+				Description: "Alternate request executed when the request conflicts, e.g. a GET by name after a 409 from a create, for get-or-create semantics. Its response replaces the conflicting one, feeds extract blocks and skips expect; it must have a 2xx status. Checked after treat_as_success.",
+				Attributes: map[string]schema.Attribute{
+					"status_codes": schema.ListAttribute{
+						ElementType: types.Int64Type,
+						Optional:    true,
+						Description: "Status codes that count as a conflict (default: [409])",
+					},
+					"json_path_equals": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "JSON path conditions the conflicting response must also meet",
+					},
+					"url": schema.StringAttribute{
+						Optional:    true,
+						Description: "URL of the alternate request (required). Relative URLs resolve against the request URL; supports ${self.response_body}, ${self.response_headers.NAME} and template functions referring to the conflicting response.",
+					},
+					"method": schema.StringAttribute{
+						Optional:    true,
+						Description: "HTTP method of the alternate request (default: GET)",
+					},
+					"request_headers": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Headers set on the alternate request in addition to the request's own headers, which are sent without the ones describing the request body, and without credentials when the URL is on another scheme or host",
+					},
+					"query": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Query parameters added to the alternate request URL",
+					},
+					"body": schema.StringAttribute{
+						Optional:    true,
+						Description: "Body of the alternate request",
+					},
 				},
 			},
 			"expect": schema.SingleNestedBlock{
@@ -221,6 +711,11 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 						Optional:    true,
 						Description: "Expected HTTP status codes",
 					},
+					"status_classes": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Expected status classes or ranges, e.g. [\"2xx\", \"500-599\"]. A status matching either status_codes or status_classes passes.",
+					},
 					"json_path_exists": schema.ListAttribute{
 						ElementType: types.StringType,
 						Optional:    true,
@@ -236,6 +731,102 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 						Optional:    true,
 						Description: "Headers that must be present",
 					},
+					"jq": schema.StringAttribute{
+						Optional:    true,
+						Description: "jq expression that must evaluate to a truthy value (not false or null) against the JSON body, e.g. '.items | length > 0'",
+					},
+					"body_sha256": schema.StringAttribute{
+						Optional:    true,
+						Description: "Expected hex-encoded SHA-256 of the response body, for verifying downloaded artifacts",
+					},
+					"content_length": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Expected response body size in bytes",
+					},
+					"content_type": schema.StringAttribute{
+						Optional:    true,
+						Description: "Expected media type of the response, ignoring parameters such as charset. Supports '*' wildcards, e.g. 'application/*json*'.",
+					},
+					"tls_cert_min_days_valid": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Fail if the server's TLS certificate expires within this many days. Requires an HTTPS request.",
+					},
+					"severity": schema.StringAttribute{
+						Optional:    true,
+						Description: "How failed expectations are reported: 'error' (default) fails the operation, 'warning' emits a warning diagnostic and continues",
+					},
+					"error_message": schema.StringAttribute{
+						Optional:    true,
+						Description: "Message to report instead of the generic one when expectations fail, e.g. \"quota exceeded, request an increase via the portal: ${self.response_body_excerpt}\". Supports ${self.status_code}, ${self.response_body}, ${self.response_body_excerpt}, ${self.response_headers.NAME}, ${self.unsatisfied_conditions} and template functions such as ${jsonpath(self.response_body, \"error.message\")}.",
+					},
+					"match": schema.StringAttribute{
+						Optional:    true,
+						Description: "How the expectations combine: 'all' (default) requires every expectation and condition block to pass, 'any' requires at least one",
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"condition": schema.ListNestedBlock{
+						Description: "Expectation group that counts as one expectation of expect, e.g. status_codes = [409] with a jq check of the error, combined with status_codes = [200] and match = \"any\" to also accept an already-existing object",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"match": schema.StringAttribute{
+									Optional:    true,
+									Description: "How the group's expectations combine: 'all' (default) or 'any'",
+								},
+								"status_codes": schema.ListAttribute{
+									ElementType: types.Int64Type,
+									Optional:    true,
+									Description: "Expected HTTP status codes",
+								},
+								"status_classes": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Expected status classes or ranges, e.g. [\"2xx\"]",
+								},
+								"header_present": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Headers that must be present",
+								},
+								"jq": schema.StringAttribute{
+									Optional:    true,
+									Description: "jq expression that must evaluate to a truthy value against the JSON body",
+								},
+								"content_type": schema.StringAttribute{
+									Optional:    true,
+									Description: "Expected media type of the response, supporting '*' wildcards",
+								},
+							},
+						},
+					},
+				},
+			},
+			"pre_request_command": schema.SingleNestedBlock{
+				Description: "Local program run before every attempt. It receives the request as JSON on stdin ({\"method\", \"url\", \"headers\", \"body\"}, with sensitive values redacted) and may print {\"headers\": {...}} to set request headers or {\"veto\": \"reason\"} to cancel the call. A non-zero exit status also cancels the call.",
+				Attributes: map[string]schema.Attribute{
+					"command": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Program and arguments to run, e.g. [\"/usr/local/bin/sign-request\", \"--profile\", \"prod\"]",
+					},
+					"timeout_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Time the command may run before the call is cancelled (default: 10000)",
+					},
+				},
+			},
+			"post_response_command": schema.SingleNestedBlock{
+				Description: "Local program run after every attempt that received a response. It receives the request and a \"response\" object ({\"status_code\", \"headers\", \"body\"}) as JSON on stdin and may print {\"veto\": \"reason\"} to fail the call. A non-zero exit status also fails the call. Vetoed calls are not retried.",
+				Attributes: map[string]schema.Attribute{
+					"command": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Program and arguments to run",
+					},
+					"timeout_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Time the command may run before the call fails (default: 10000)",
+					},
 				},
 			},
 			"extract": schema.ListNestedBlock{
@@ -250,55 +841,85 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 							Optional:    true,
 							Description: "JSON path to extract from",
 						},
+						"for_each_path": schema.StringAttribute{
+							Optional:    true,
+							Description: "Path evaluated against each element of the array at json_path (or the body root when json_path is unset); results populate outputs_lists",
+						},
+						"jq": schema.StringAttribute{
+							Optional:    true,
+							Description: "jq expression evaluated against the JSON body instead of json_path, e.g. '.items | map(.id) | join(\",\")'. Multiple outputs are returned as a JSON array.",
+						},
 						"header": schema.StringAttribute{
 							Optional:    true,
 							Description: "Header name to extract from",
 						},
+						"cookie": schema.StringAttribute{
+							Optional:    true,
+							Description: "Cookie name to extract the value of from Set-Cookie response headers",
+						},
+						"link_rel": schema.StringAttribute{
+							Optional:    true,
+							Description: "Link header relation type to extract the target URL of, e.g. \"next\"",
+						},
 					},
 				},
 			},
-			"timeouts": schema.SingleNestedBlock{
-				Description: "Timeout configuration",
-				Attributes: map[string]schema.Attribute{
-					"create": schema.StringAttribute{
-						Optional:    true,
-						Description: "Timeout for create operation",
-					},
-					"read": schema.StringAttribute{
-						Optional:    true,
-						Description: "Timeout for read operation",
-					},
-					"update": schema.StringAttribute{
-						Optional:    true,
-						Description: "Timeout for update operation",
-					},
-					"delete": schema.StringAttribute{
-						Optional:    true,
-						Description: "Timeout for delete operation",
-					},
-				},
-			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Read:              true,
+				Update:            true,
+				Delete:            true,
+				CreateDescription: "Timeout for create operation, bounding the request including retries (default: 20m)",
+				ReadDescription:   "Timeout for read operation, bounding the request including retries (default: 5m)",
+				UpdateDescription: "Timeout for update operation, bounding the request including retries (default: 20m)",
+				DeleteDescription: "Timeout for delete operation, covering refresh_before_destroy and the destroy request (default: 20m)",
+			}),
 			"on_destroy": schema.SingleNestedBlock{
-				Description: "HTTP request to execute when resource is destroyed. Supports template interpolation with ${self.outputs.KEY} and ${self.id}",
+				Description: "HTTP request to execute when resource is destroyed. Supports template interpolation with ${self.outputs.KEY}, ${self.id}, ${self.status_code}, ${self.response_body}, and ${self.response_headers.NAME}",
 				Attributes: map[string]schema.Attribute{
 					"url": schema.StringAttribute{
 						Optional:    true,
 						Description: "The URL to make the destroy request to (supports ${self.outputs.KEY} and ${self.id} interpolation)",
 					},
+					"path_params": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Values substituted for {name} tokens in the URL, path-escaped so IDs containing '/' or spaces stay in one segment",
+					},
 					"method": schema.StringAttribute{
 						Optional:    true,
 						Description: "HTTP method for destroy request",
 					},
+					"allow_custom_methods": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Allow methods other than the standard HTTP methods, e.g. PROPFIND or PURGE (default: false)",
+					},
 					"headers": schema.MapAttribute{
+						ElementType:        types.StringType,
+						Optional:           true,
+						DeprecationMessage: headersDeprecationMessage,
+						Description:        "Request headers for destroy request. Deprecated in favor of request_headers.",
+					},
+					"request_headers": schema.MapAttribute{
 						ElementType: types.StringType,
 						Optional:    true,
-						Description: "Request headers for destroy request",
+						Description: "Request headers for destroy request. Replaces headers; repeated or case-sensitive headers still use header blocks.",
 					},
 					"query": schema.MapAttribute{
 						ElementType: types.StringType,
 						Optional:    true,
 						Description: "Query parameters for destroy request",
 					},
+					"cookies": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Cookies to send, rendered into the Cookie header (values are quoted when needed)",
+					},
+					"preserve_header_case": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Send header names exactly as configured instead of canonicalizing them, for legacy servers with case-sensitive header handling (HTTP/1.x only; HTTP/2 always lowercases)",
+					},
 					"body": schema.StringAttribute{
 						Optional:    true,
 						Description: "Raw request body for destroy request",
@@ -307,10 +928,18 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 						Optional:    true,
 						Description: "JSON request body for destroy request",
 					},
+					"body_object": schema.DynamicAttribute{
+						Optional:    true,
+						Description: "Request body written as a native HCL object or list, serialized to JSON with sorted keys (mutually exclusive with body, body_json and body_file)",
+					},
 					"body_file": schema.StringAttribute{
 						Optional:    true,
 						Description: "Path to file to read for destroy request body",
 					},
+					"auto_content_digest": schema.StringAttribute{
+						Optional:    true,
+						Description: "Compute a digest of the request body and send it: \"sha256\" sets Content-Digest, \"md5\" sets Content-MD5. An explicitly configured header is kept.",
+					},
 					"bearer_token": schema.StringAttribute{
 						Optional:    true,
 						Sensitive:   true,
@@ -320,6 +949,23 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 						Optional:    true,
 						Description: "Request timeout for destroy request in milliseconds",
 					},
+					"connect_timeout_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Time allowed to establish a TCP connection, in milliseconds (overrides the provider setting)",
+					},
+					"tls_handshake_timeout_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Time allowed for the TLS handshake, in milliseconds (overrides the provider setting)",
+					},
+					"response_header_timeout_ms": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Time allowed between sending the request and receiving the response headers, in milliseconds (overrides the provider setting)",
+					},
+					"pinned_spki_sha256": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Base64 SHA-256 SubjectPublicKeyInfo digests, one of which the server must present (overrides the provider setting)",
+					},
 					"insecure_skip_verify": schema.BoolAttribute{
 						Optional:    true,
 						Description: "Skip TLS certificate verification for destroy request",
@@ -336,6 +982,40 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 						Optional:    true,
 						Description: "Whether to store destroy response body (not persisted to state since resource is deleted)",
 					},
+					"max_response_body_bytes": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Maximum response body size in bytes for this request. Overrides the provider's max_response_body_bytes.",
+					},
+					"on_body_overflow": schema.StringAttribute{
+						Optional:    true,
+						Description: "What to do when the response body exceeds max_response_body_bytes: 'truncate' (default) keeps the first bytes followed by a truncation marker, 'fail' returns an error instead of a corrupted body.",
+					},
+					"redact_headers": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Additional headers to redact in logs and diagnostics for this request, e.g. [\"X-Internal-Token\"]. Appended to the provider's redact_headers.",
+					},
+					"nonce_header": schema.StringAttribute{
+						Optional:    true,
+						Description: "Header that carries a generated anti-replay nonce (128 random bits, hex encoded), e.g. \"X-Nonce\"",
+					},
+					"nonce_scope": schema.StringAttribute{
+						Optional:    true,
+						Description: "When a new nonce is generated: 'attempt' (default) for every attempt including retries, or 'request' for one value shared by all attempts of an execution",
+					},
+					"refresh_before_destroy": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Re-execute the root request before the destroy request so ${self.outputs.KEY} reflects current remote values instead of those stored at create time. The root request is sent again, so use this with idempotent root requests.",
+					},
+					"failure_mode": schema.StringAttribute{
+						Optional:    true,
+						Description: "How to handle a failed destroy request: 'abort' (fail and keep the resource in state), 'warn' (remove from state with a warning), or 'ignore' (remove from state silently). Defaults to 'abort'.",
+					},
+					"treat_status_as_success": schema.ListAttribute{
+						ElementType: types.Int64Type,
+						Optional:    true,
+						Description: "Status codes that count as a successful destroy regardless of retry and expect settings (e.g. 404 or 410 when the object is already gone)",
+					},
 				},
 				Blocks: map[string]schema.Block{
 					"header": schema.ListNestedBlock{
@@ -385,20 +1065,43 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 							},
 							"backoff": schema.StringAttribute{
 								Optional:    true,
-								Description: "Backoff strategy: 'fixed', 'linear', or 'exponential'",
+								Description: "Backoff strategy: 'fixed', 'linear', 'exponential', or 'decorrelated'",
 							},
 							"jitter": schema.BoolAttribute{
 								Optional:    true,
 								Description: "Add jitter to retry delays",
 							},
+							"jitter_mode": schema.StringAttribute{
+								Optional:    true,
+								Description: "Jitter strategy: 'none', 'full', 'equal', or 'percentage' (adds up to 25%). Overrides jitter when set.",
+							},
 							"retry_on_status_codes": schema.ListAttribute{
 								ElementType: types.Int64Type,
 								Optional:    true,
 								Description: "HTTP status codes that should trigger a retry",
 							},
+							"retry_on_status_classes": schema.ListAttribute{
+								ElementType: types.StringType,
+								Optional:    true,
+								Description: "Status classes or ranges to retry on, e.g. [\"5xx\"] or [\"500-599\"]. When set without retry_on_status_codes, the default status codes are not used.",
+							},
 							"respect_retry_after": schema.BoolAttribute{
 								Optional:    true,
-								Description: "Respect Retry-After header if present",
+								Description: "Respect Retry-After and rate limit reset headers (RateLimit-Reset, X-RateLimit-Reset) if present",
+							},
+							"retry_on_errors": schema.ListAttribute{
+								ElementType: types.StringType,
+								Optional:    true,
+								Description: "Transport error classes that should trigger a retry: 'dns', 'connect', 'timeout', 'tls', 'reset', 'other' (any other transport error), or 'any'. Defaults to ['any'].",
+							},
+							"safe_methods_only": schema.BoolAttribute{
+								Optional:    true,
+								Description: "Only retry transport errors that may have reached the server (timeouts, resets) for idempotent methods or requests carrying an Idempotency-Key header (default: true). DNS, connect and TLS errors are always retried",
+							},
+							"status_delay_overrides": schema.MapAttribute{
+								ElementType: types.Int64Type,
+								Optional:    true,
+								Description: "Delay in milliseconds to use for specific status codes instead of the backoff curve, e.g. { \"423\" = 30000 }. Retry-After still takes precedence when respected.",
 							},
 						},
 					},
@@ -410,6 +1113,11 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 								Optional:    true,
 								Description: "Status codes that satisfy the condition",
 							},
+							"status_classes": schema.ListAttribute{
+								ElementType: types.StringType,
+								Optional:    true,
+								Description: "Status classes or ranges to wait for, e.g. [\"2xx\"] or [\"200-204\"]. A status matching either status_codes or status_classes satisfies the condition.",
+							},
 							"json_path_equals": schema.MapAttribute{
 								ElementType: types.StringType,
 								Optional:    true,
@@ -420,10 +1128,94 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 								Optional:    true,
 								Description: "Header conditions that must equal specified values",
 							},
+							"header_equals_ignore_case": schema.BoolAttribute{
+								Optional:    true,
+								Description: "Compare header_equals values case-insensitively, here and in condition blocks (default: false)",
+							},
 							"body_regex": schema.StringAttribute{
 								Optional:    true,
 								Description: "Regex pattern that must match the response body",
 							},
+							"jq": schema.StringAttribute{
+								Optional:    true,
+								Description: "jq expression that must evaluate to a truthy value (not false or null) against the JSON body, e.g. '.status == \"ready\"'",
+							},
+							"interval_ms": schema.Int64Attribute{
+								Optional:    true,
+								Description: "Fixed delay between polls when conditions are not met. Transport error retries keep the retry block's backoff. Defaults to the retry backoff.",
+							},
+							"initial_delay_ms": schema.Int64Attribute{
+								Optional:    true,
+								Description: "Delay before the first poll in milliseconds",
+							},
+							"consecutive_successes": schema.Int64Attribute{
+								Optional:    true,
+								Description: "Number of consecutive polls that must satisfy the conditions before succeeding (default: 1)",
+							},
+							"match": schema.StringAttribute{
+								Optional:    true,
+								Description: "How the conditions combine: 'all' (default) requires every condition and condition block to be satisfied, 'any' requires at least one",
+							},
+						},
+						Blocks: map[string]schema.Block{
+							"condition": schema.ListNestedBlock{
+								Description: "Condition group that counts as one condition of retry_until, e.g. status_codes = [409] with a jq check of the error, combined with status_codes = [200] and match = \"any\" to also accept an already-existing object",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"match": schema.StringAttribute{
+											Optional:    true,
+											Description: "How the group's conditions combine: 'all' (default) or 'any'",
+										},
+										"status_codes": schema.ListAttribute{
+											ElementType: types.Int64Type,
+											Optional:    true,
+											Description: "Status codes that satisfy the condition",
+										},
+										"status_classes": schema.ListAttribute{
+											ElementType: types.StringType,
+											Optional:    true,
+											Description: "Status classes or ranges that satisfy the condition, e.g. [\"2xx\"]",
+										},
+										"json_path_equals": schema.MapAttribute{
+											ElementType: types.StringType,
+											Optional:    true,
+											Description: "JSON path conditions that must equal specified values",
+										},
+										"header_equals": schema.MapAttribute{
+											ElementType: types.StringType,
+											Optional:    true,
+											Description: "Header conditions that must equal specified values",
+										},
+										"body_regex": schema.StringAttribute{
+											Optional:    true,
+											Description: "Regex pattern that must match the response body",
+										},
+										"jq": schema.StringAttribute{
+											Optional:    true,
+											Description: "jq expression that must evaluate to a truthy value against the JSON body",
+										},
+									},
+								},
+							},
+						},
+					},
+					"abort_on": schema.SingleNestedBlock{
+						Description: "Conditions that stop retrying/polling immediately with an error. The request is aborted when any condition matches.",
+						Attributes: map[string]schema.Attribute{
+							"status_codes": schema.ListAttribute{
+								ElementType: types.Int64Type,
+								Optional:    true,
+								Description: "Status codes that abort the request",
+							},
+							"json_path_equals": schema.MapAttribute{
+								ElementType: types.StringType,
+								Optional:    true,
+								Description: "JSON path conditions that abort the request when a path equals the specified value",
+							},
+							"body_regex": schema.StringAttribute{
+								Optional:    true,
+								Description: "Regex pattern that aborts the request when it matches the response body",
+							},
 						},
 					},
 					"expect": schema.SingleNestedBlock{
@@ -434,6 +1226,11 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 								Optional:    true,
 								Description: "Expected HTTP status codes",
 							},
+							"status_classes": schema.ListAttribute{
+								ElementType: types.StringType,
+								Optional:    true,
+								Description: "Expected status classes or ranges, e.g. [\"2xx\", \"500-599\"]. A status matching either status_codes or status_classes passes.",
+							},
 							"json_path_exists": schema.ListAttribute{
 								ElementType: types.StringType,
 								Optional:    true,
@@ -449,6 +1246,102 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 								Optional:    true,
 								Description: "Headers that must be present",
 							},
+							"jq": schema.StringAttribute{
+								Optional:    true,
+								Description: "jq expression that must evaluate to a truthy value (not false or null) against the JSON body, e.g. '.items | length > 0'",
+							},
+							"body_sha256": schema.StringAttribute{
+								Optional:    true,
+								Description: "Expected hex-encoded SHA-256 of the response body, for verifying downloaded artifacts",
+							},
+							"content_length": schema.Int64Attribute{
+								Optional:    true,
+								Description: "Expected response body size in bytes",
+							},
+							"content_type": schema.StringAttribute{
+								Optional:    true,
+								Description: "Expected media type of the response, ignoring parameters such as charset. Supports '*' wildcards, e.g. 'application/*json*'.",
+							},
+							"tls_cert_min_days_valid": schema.Int64Attribute{
+								Optional:    true,
+								Description: "Fail if the server's TLS certificate expires within this many days. Requires an HTTPS request.",
+							},
+							"severity": schema.StringAttribute{
+								Optional:    true,
+								Description: "How failed expectations are reported: 'error' (default) fails the operation, 'warning' emits a warning diagnostic and continues",
+							},
+							"error_message": schema.StringAttribute{
+								Optional:    true,
+								Description: "Message to report instead of the generic one when expectations fail, e.g. \"quota exceeded, request an increase via the portal: ${self.response_body_excerpt}\". Supports ${self.status_code}, ${self.response_body}, ${self.response_body_excerpt}, ${self.response_headers.NAME}, ${self.unsatisfied_conditions} and template functions such as ${jsonpath(self.response_body, \"error.message\")}.",
+							},
+							"match": schema.StringAttribute{
+								Optional:    true,
+								Description: "How the expectations combine: 'all' (default) requires every expectation and condition block to pass, 'any' requires at least one",
+							},
+						},
+						Blocks: map[string]schema.Block{
+							"condition": schema.ListNestedBlock{
+								Description: "Expectation group that counts as one expectation of expect, e.g. status_codes = [409] with a jq check of the error, combined with status_codes = [200] and match = \"any\" to also accept an already-existing object",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: map[string]schema.Attribute{
+										"match": schema.StringAttribute{
+											Optional:    true,
+											Description: "How the group's expectations combine: 'all' (default) or 'any'",
+										},
+										"status_codes": schema.ListAttribute{
+											ElementType: types.Int64Type,
+											Optional:    true,
+											Description: "Expected HTTP status codes",
+										},
+										"status_classes": schema.ListAttribute{
+											ElementType: types.StringType,
+											Optional:    true,
+											Description: "Expected status classes or ranges, e.g. [\"2xx\"]",
+										},
+										"header_present": schema.ListAttribute{
+											ElementType: types.StringType,
+											Optional:    true,
+											Description: "Headers that must be present",
+										},
+										"jq": schema.StringAttribute{
+											Optional:    true,
+											Description: "jq expression that must evaluate to a truthy value against the JSON body",
+										},
+										"content_type": schema.StringAttribute{
+											Optional:    true,
+											Description: "Expected media type of the response, supporting '*' wildcards",
+										},
+									},
+								},
+							},
+						},
+					},
+					"pre_request_command": schema.SingleNestedBlock{
+						Description: "Local program run before every attempt. It receives the request as JSON on stdin ({\"method\", \"url\", \"headers\", \"body\"}, with sensitive values redacted) and may print {\"headers\": {...}} to set request headers or {\"veto\": \"reason\"} to cancel the call. A non-zero exit status also cancels the call.",
+						Attributes: map[string]schema.Attribute{
+							"command": schema.ListAttribute{
+								ElementType: types.StringType,
+								Optional:    true,
+								Description: "Program and arguments to run, e.g. [\"/usr/local/bin/sign-request\", \"--profile\", \"prod\"]",
+							},
+							"timeout_ms": schema.Int64Attribute{
+								Optional:    true,
+								Description: "Time the command may run before the call is cancelled (default: 10000)",
+							},
+						},
+					},
+					"post_response_command": schema.SingleNestedBlock{
+						Description: "Local program run after every attempt that received a response. It receives the request and a \"response\" object ({\"status_code\", \"headers\", \"body\"}) as JSON on stdin and may print {\"veto\": \"reason\"} to fail the call. A non-zero exit status also fails the call. Vetoed calls are not retried.",
+						Attributes: map[string]schema.Attribute{
+							"command": schema.ListAttribute{
+								ElementType: types.StringType,
+								Optional:    true,
+								Description: "Program and arguments to run",
+							},
+							"timeout_ms": schema.Int64Attribute{
+								Optional:    true,
+								Description: "Time the command may run before the call fails (default: 10000)",
+							},
 						},
 					},
 					"extract": schema.ListNestedBlock{
@@ -463,10 +1356,26 @@ func (r *HttpxRequestResource) Schema(_ context.Context, _ resource.SchemaReques
 									Optional:    true,
 									Description: "JSON path to extract from",
 								},
+								"for_each_path": schema.StringAttribute{
+									Optional:    true,
+									Description: "Path evaluated against each element of the array at json_path (or the body root when json_path is unset); results populate outputs_lists",
+								},
+								"jq": schema.StringAttribute{
+									Optional:    true,
+									Description: "jq expression evaluated against the JSON body instead of json_path, e.g. '.items | map(.id) | join(\",\")'. Multiple outputs are returned as a JSON array.",
+								},
 								"header": schema.StringAttribute{
 									Optional:    true,
 									Description: "Header name to extract from",
 								},
+								"cookie": schema.StringAttribute{
+									Optional:    true,
+									Description: "Cookie name to extract the value of from Set-Cookie response headers",
+								},
+								"link_rel": schema.StringAttribute{
+									Optional:    true,
+									Description: "Link header relation type to extract the target URL of, e.g. \"next\"",
+								},
 							},
 						},
 					},
@@ -493,6 +1402,16 @@ func (r *HttpxRequestResource) Configure(_ context.Context, req resource.Configu
 	r.config = config
 }
 
+func (r *HttpxRequestResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateAttributeAliases(ctx, req.Config, resourceAttributeAliases)...)
+	resp.Diagnostics.Append(validateExtractBlocks(ctx, req.Config, path.Root("extract"))...)
+	resp.Diagnostics.Append(validateExtractBlocks(ctx, req.Config, path.Root("on_destroy").AtName("extract"))...)
+	resp.Diagnostics.Append(validateRegexAttributes(ctx, req.Config, resourceRegexAttributes, resourceRetryConditionBlocks)...)
+	resp.Diagnostics.Append(validateResponseHeaderNames(ctx, req.Config)...)
+	resp.Diagnostics.Append(validateRetryBlocks(ctx, req.Config, resourceRetryBlocks)...)
+	resp.Diagnostics.Append(validateTimeouts(ctx, req.Config)...)
+}
+
 func (r *HttpxRequestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var model HttpxRequestResourceModel
 
@@ -501,9 +1420,20 @@ func (r *HttpxRequestResource) Create(ctx context.Context, req resource.CreateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ensureRequestPreview(ctx, &model, r.config)
+
+	// Skip execution entirely when the request is disabled
+	if !isRequestEnabled(model.Enabled) {
+		tflog.Info(ctx, "Request is disabled, skipping execution")
+		model.Id = types.StringValue(generateResourceID(model))
+		model.CreatedAt = currentTimestamp()
+		setDisabledComputedValues(&model)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
 
 	// Build request configuration
-	headers, err := ConvertTerraformMap(ctx, model.Headers)
+	headers, err := ConvertTerraformMap(ctx, aliasedMap(model.RequestHeaders, model.Headers))
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid Headers", err.Error())
 		return
@@ -515,56 +1445,116 @@ func (r *HttpxRequestResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	cookies, err := ConvertTerraformMap(ctx, model.Cookies)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Cookies", err.Error())
+		return
+	}
+
+	pathParams, err := ConvertTerraformMap(ctx, model.PathParams)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Path Params", err.Error())
+		return
+	}
+
 	// Build HTTP request
-	httpReq, err := BuildRequest(ctx, &RequestConfig{
-		Url:              model.Url.ValueString(),
-		Method:           model.Method.ValueString(),
-		Headers:          headers,
-		HeaderBlocks:     model.HeaderBlocks,
-		Query:            query,
-		Body:             model.Body,
-		BodyJson:         model.BodyJson,
-		BodyFile:         model.BodyFile,
-		BasicAuth:        model.BasicAuth,
-		BearerToken:      model.BearerToken,
-		ProviderDefaults: r.config,
-	})
+	httpReq, err := BuildRequest(ctx, requestConfigFromModel(&model, headers, query, cookies, pathParams, r.config))
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to build request", err.Error())
 		return
 	}
 
+	// Add headers built from other resources' private_outputs
+	shared, err := applyDependsOnOutputs(ctx, httpReq, model.DependsOnOutputs, r.config, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid depends_on_outputs", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(storeSharedOutputs(ctx, r.config, shared, resp.Private)...)
+
+	// Extend the provider's redact_headers with this request's own list
+	reqConfig, err := r.config.WithAuditSource("httpx_request", "create").WithRedactHeaders(ctx, model.RedactHeaders)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid redact_headers", err.Error())
+		return
+	}
+
+	// In dry-run mode the request is only logged
+	if reqConfig.DryRun {
+		logDryRunRequest(ctx, httpReq, reqConfig)
+		addDryRunNotSentError(&resp.Diagnostics, "create")
+		return
+	}
+
+	// Apply per-resource response body limits
+	execConfig, err := reqConfig.WithResponseBodyLimit(model.MaxResponseBodyBytes, model.OnBodyOverflow)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid response body limit", err.Error())
+		return
+	}
+	execConfig = execConfig.WithCaptureTranscript(model.CaptureTranscript).WithResume(model.Resume)
+	execConfig, err = execConfig.WithNonce(model.NonceHeader, model.NonceScope)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid nonce configuration", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithCommandHooks(ctx, model.PreRequestCommand, model.PostResponseCommand)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid command hook", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithPhaseTimeouts(model.ConnectTimeoutMs, model.TlsHandshakeTimeoutMs, model.ResponseHeaderTimeoutMs)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout configuration", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithPinnedSPKI(ctx, model.PinnedSpkiSha256)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid pinned_spki_sha256", err.Error())
+		return
+	}
+
 	// Build retry configs
 	retryConfig := BuildRetryConfig(ctx, model.Retry)
 	retryUntilConfig := BuildRetryUntilConfig(ctx, model.RetryUntil)
+	abortOnConfig := BuildAbortOnConfig(ctx, model.AbortOn)
 
-	// Handle timeouts if configured
-	createCtx := ctx
-	if model.Timeouts != nil && !model.Timeouts.Create.IsNull() && !model.Timeouts.Create.IsUnknown() {
-		timeoutStr := model.Timeouts.Create.ValueString()
-		if timeout, err := time.ParseDuration(timeoutStr); err == nil {
-			var cancel context.CancelFunc
-			createCtx, cancel = context.WithTimeout(ctx, timeout)
-			defer cancel()
-		}
+	// Bound the operation by timeouts.create or its default
+	timeout, diags := model.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	createCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
 	// Execute request with retry and conditional retry
-	result, err := ExecuteRequestWithRetry(createCtx, httpReq, r.config, retryConfig, retryUntilConfig)
+	result, err := ExecuteRequestWithRetry(createCtx, httpReq, execConfig, retryConfig, retryUntilConfig, abortOnConfig)
+
+	// Accept error responses configured in treat_as_success or on_conflict, such as 409 from an idempotent create
+	result, accepted, err := acceptErrorResponse(createCtx, &model, httpReq, execConfig, retryConfig, result, err)
 	if err != nil {
+		notifyFailure(ctx, httpReq, execConfig, result, err)
 		if createCtx.Err() == context.DeadlineExceeded {
-			resp.Diagnostics.AddError("Request timeout", fmt.Sprintf("Request exceeded timeout, last error: %s", err.Error()))
+			resp.Diagnostics.AddError("Request timeout", fmt.Sprintf("Request exceeded timeout, last error: %s", requestFailureDetail(err, result)))
 		} else {
-			resp.Diagnostics.AddError("Request failed", err.Error())
+			resp.Diagnostics.AddError("Request failed", requestFailureDetail(err, result))
 		}
+		saveFailedCreate(ctx, resp, model, result, execConfig)
 		return
 	}
 
 	// Validate expectations
-	if model.Expect != nil {
+	if model.Expect != nil && !accepted {
 		if err := ValidateExpectations(ctx, result, model.Expect); err != nil {
-			resp.Diagnostics.AddError("Expectation validation failed", err.Error())
-			return
+			if expectationIsWarning(model.Expect) {
+				resp.Diagnostics.AddWarning("Expectation validation failed", err.Error())
+			} else {
+				notifyFailure(ctx, httpReq, execConfig, result, fmt.Errorf("expectation validation failed: %w", err))
+				resp.Diagnostics.AddError("Expectation validation failed", err.Error())
+				saveFailedCreate(ctx, resp, model, result, execConfig)
+				return
+			}
 		}
 	}
 
@@ -572,21 +1562,48 @@ func (r *HttpxRequestResource) Create(ctx context.Context, req resource.CreateRe
 	id := generateResourceID(model)
 
 	// Set computed attributes
+	now := currentTimestamp()
 	model.Id = types.StringValue(id)
 	model.StatusCode = types.Int64Value(result.StatusCode)
+	model.StatusText = stringOrNull(result.StatusText)
+	model.Protocol = stringOrNull(result.Protocol)
+	model.RemoteAddr = stringOrNull(result.RemoteAddr)
+	model.TlsVersion = tlsVersionValue(result.TLS)
+	model.TlsCipherSuite = tlsCipherSuiteValue(result.TLS)
+	model.PeerCertSha256 = peerCertSha256Value(result.TLS)
 	model.LastAttemptCount = types.Int64Value(result.AttemptCount)
+	model.CreatedAt = now
+	model.LastResponseAt = now
+	historyValue, historyDiags := AttemptHistoryValue(ctx, result.AttemptHistory)
+	resp.Diagnostics.Append(historyDiags...)
+	model.AttemptHistory = historyValue
+	model.Transcript = TranscriptValue(result.AttemptHistory)
+	model.Nonce = NonceValue(result.AttemptHistory)
+	model.RequestHeadersSent = RequestHeadersSentValue(result.AttemptHistory)
 	if result.Error != "" {
 		model.LastError = types.StringValue(result.Error)
 	} else {
 		model.LastError = types.StringNull()
 	}
+	model.ErrorResponseBody = types.StringNull()
 
 	// Set response headers
-	responseHeaders := make(map[string]attr.Value)
-	for k, v := range result.Headers {
-		responseHeaders[k] = types.StringValue(v)
+	responseHeaders, err := ResponseHeadersValue(ctx, normalizeResponseHeaderNames(result.Headers, model.ResponseHeaderNames), model.IgnoreResponseHeaders)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ignore_response_headers", err.Error())
+		return
 	}
-	model.ResponseHeaders = types.MapValueMust(types.StringType, responseHeaders)
+	model.ResponseHeaders = responseHeaders
+
+	responseCookies, cookieDiags := ResponseCookiesValue(ctx, result.Cookies)
+	resp.Diagnostics.Append(cookieDiags...)
+	model.ResponseCookies = responseCookies
+	model.ResponseLinks = ResponseLinksValue(result)
+
+	redirectChain, redirectDiags := RedirectChainValue(ctx, result.RedirectChain)
+	resp.Diagnostics.Append(redirectDiags...)
+	model.RedirectChain = redirectChain
+	model.EffectiveUrl = effectiveURLValue(result)
 
 	// Set response body (respect store_response_body)
 	// Default: true for resources (users may need the body)
@@ -601,31 +1618,57 @@ func (r *HttpxRequestResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	if storeBody {
-		model.ResponseBody = types.StringValue(result.Body)
+		storedBody, err := NormalizeResponseBody(ctx, result.Body, model.NormalizeResponseBody, model.IgnoreBodyPaths)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to normalize response body", err.Error())
+			return
+		}
+		model.ResponseBody = types.StringValue(storedBody)
+		model.ResponseBodyJson = ResponseBodyJsonValue(ctx, storedBody)
 	} else {
 		model.ResponseBody = types.StringNull()
+		model.ResponseBodyJson = types.DynamicNull()
 	}
 
-	// Extract values from response
-	extractedOutputs, err := ExtractValues(ctx, result, model.ExtractBlocks)
+	// Write response body to a local file if configured
+	bodyFileSha256, err := ResponseBodyFileValue(model.ResponseBodyFile, result.Body)
 	if err != nil {
-		resp.Diagnostics.AddWarning("Extraction warnings", fmt.Sprintf("Some values could not be extracted: %v", err))
+		resp.Diagnostics.AddError("Failed to write response body file", err.Error())
+		return
 	}
+	model.ResponseBodyFileSha256 = bodyFileSha256
 
-	// Convert extracted outputs to Terraform map
-	outputsMap := make(map[string]attr.Value)
-	for k, v := range extractedOutputs {
-		outputsMap[k] = types.StringValue(v)
+	// Extract values from response
+	outputsMap, ok := extractResourceOutputs(ctx, &resp.Diagnostics, &model, result)
+	if !ok {
+		saveFailedCreate(ctx, resp, model, result, execConfig)
+		return
 	}
+	resp.Diagnostics.Append(storePrivateOutputs(ctx, r.config, &model, outputsMap, resp.Private)...)
 	model.Outputs = types.MapValueMust(types.StringType, outputsMap)
 
+	// Extract arrays into list outputs
+	outputsLists, listDiags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, ExtractListValues(ctx, result, model.ExtractBlocks))
+	resp.Diagnostics.Append(listDiags...)
+	model.OutputsLists = outputsLists
+
+	// Encrypt stored payloads when state_encryption_key is set
+	if err := sealResponseState(ctx, r.config, resourceResponseFields(&model)); err != nil {
+		resp.Diagnostics.AddError("Failed to encrypt state", err.Error())
+		return
+	}
+
 	// Save state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
 // generateResourceID generates a stable ID for the resource
 func generateResourceID(model HttpxRequestResourceModel) string {
-	// Create a hash of key request attributes for stability
+	// The fingerprint covers every request-shaping input, falling back to url|method|body when
+	// it could not be computed
+	if fingerprint := model.RequestFingerprint.ValueString(); len(fingerprint) >= 16 {
+		return fingerprint[:16]
+	}
 	hashInput := fmt.Sprintf("%s|%s|%s",
 		model.Url.ValueString(),
 		model.Method.ValueString(),
@@ -635,6 +1678,120 @@ func generateResourceID(model HttpxRequestResourceModel) string {
 	return hex.EncodeToString(hash[:])[:16] // Use first 16 chars
 }
 
+// isRequestEnabled reports whether the request should be executed (defaults to true)
+func isRequestEnabled(enabled types.Bool) bool {
+	if enabled.IsNull() || enabled.IsUnknown() {
+		return true
+	}
+	return enabled.ValueBool()
+}
+
+// setDisabledComputedValues sets computed attributes to null markers for a disabled request
+func setDisabledComputedValues(model *HttpxRequestResourceModel) {
+	model.StatusCode = types.Int64Null()
+	model.StatusText = types.StringNull()
+	model.Protocol = types.StringNull()
+	model.RemoteAddr = types.StringNull()
+	model.TlsVersion = types.StringNull()
+	model.TlsCipherSuite = types.StringNull()
+	model.PeerCertSha256 = types.StringNull()
+	model.ResponseHeaders = types.MapNull(types.StringType)
+	model.ResponseCookies = types.MapNull(types.ObjectType{AttrTypes: responseCookieAttrTypes})
+	model.ResponseLinks = types.MapNull(types.StringType)
+	model.EffectiveUrl = types.StringNull()
+	model.RedirectChain = types.ListNull(types.ObjectType{AttrTypes: redirectHopAttrTypes})
+	model.ResponseBody = types.StringNull()
+	model.ResponseBodyJson = types.DynamicNull()
+	model.ResponseBodyFileSha256 = types.StringNull()
+	model.Outputs = types.MapNull(types.StringType)
+	model.OutputsLists = types.MapNull(types.ListType{ElemType: types.StringType})
+	model.LastAttemptCount = types.Int64Value(0)
+	model.LastError = types.StringNull()
+	model.ErrorResponseBody = types.StringNull()
+	model.AttemptHistory = types.ListNull(types.ObjectType{AttrTypes: attemptHistoryAttrTypes})
+	model.Transcript = types.StringNull()
+	model.Nonce = types.StringNull()
+	model.RequestHeadersSent = types.MapNull(types.StringType)
+	model.LastResponseAt = types.StringNull()
+}
+
+// requestHasRun reports whether state holds a response, i.e. the request executed before it was
+// disabled
+func requestHasRun(state HttpxRequestResourceModel) bool {
+	return !state.StatusCode.IsNull() || !state.LastResponseAt.IsNull()
+}
+
+// keepLastResponseValues copies the computed attributes cleared by setDisabledComputedValues from
+// state, for a request disabled after it executed
+func keepLastResponseValues(model *HttpxRequestResourceModel, state HttpxRequestResourceModel) {
+	model.StatusCode = state.StatusCode
+	model.StatusText = state.StatusText
+	model.Protocol = state.Protocol
+	model.RemoteAddr = state.RemoteAddr
+	model.TlsVersion = state.TlsVersion
+	model.TlsCipherSuite = state.TlsCipherSuite
+	model.PeerCertSha256 = state.PeerCertSha256
+	model.ResponseHeaders = state.ResponseHeaders
+	model.ResponseCookies = state.ResponseCookies
+	model.ResponseLinks = state.ResponseLinks
+	model.EffectiveUrl = state.EffectiveUrl
+	model.RedirectChain = state.RedirectChain
+	model.ResponseBody = state.ResponseBody
+	model.ResponseBodyJson = state.ResponseBodyJson
+	model.ResponseBodyFileSha256 = state.ResponseBodyFileSha256
+	model.Outputs = state.Outputs
+	model.OutputsLists = state.OutputsLists
+	model.LastAttemptCount = state.LastAttemptCount
+	model.LastError = state.LastError
+	model.ErrorResponseBody = state.ErrorResponseBody
+	model.AttemptHistory = state.AttemptHistory
+	model.Transcript = state.Transcript
+	model.Nonce = state.Nonce
+	model.RequestHeadersSent = state.RequestHeadersSent
+	model.LastResponseAt = state.LastResponseAt
+}
+
+// Read modes for read_mode
+const (
+	readModeNone               = "none"
+	readModeRefresh            = "refresh"
+	readModeRefreshIfOlderThan = "refresh_if_older_than"
+)
+
+// shouldRefreshOnRead decides whether Read re-executes the request. With
+// "refresh_if_older_than" the request only runs once last_response_at is older than refresh_interval.
+func shouldRefreshOnRead(readMode string, refreshInterval types.String, lastResponseAt types.String, now time.Time) (bool, error) {
+	switch readMode {
+	case readModeNone:
+		return false, nil
+	case readModeRefresh:
+		return true, nil
+	case readModeRefreshIfOlderThan:
+		if refreshInterval.IsNull() || refreshInterval.IsUnknown() || refreshInterval.ValueString() == "" {
+			return false, fmt.Errorf("refresh_interval is required when read_mode is %q", readModeRefreshIfOlderThan)
+		}
+		interval, err := time.ParseDuration(refreshInterval.ValueString())
+		if err != nil {
+			return false, fmt.Errorf("invalid refresh_interval %q: %w", refreshInterval.ValueString(), err)
+		}
+		if lastResponseAt.IsNull() || lastResponseAt.IsUnknown() {
+			return true, nil
+		}
+		last, err := time.Parse(time.RFC3339, lastResponseAt.ValueString())
+		if err != nil {
+			return true, nil
+		}
+		return now.Sub(last) >= interval, nil
+	default:
+		return false, fmt.Errorf("read_mode must be %q, %q or %q, got %q", readModeNone, readModeRefresh, readModeRefreshIfOlderThan, readMode)
+	}
+}
+
+// currentTimestamp returns the current time as an RFC 3339 timestamp value
+func currentTimestamp() types.String {
+	return types.StringValue(time.Now().UTC().Format(time.RFC3339))
+}
+
 func (r *HttpxRequestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var model HttpxRequestResourceModel
 
@@ -645,19 +1802,32 @@ func (r *HttpxRequestResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	// Check read_mode
-	readMode := "none"
+	readMode := readModeNone
 	if !model.ReadMode.IsNull() && !model.ReadMode.IsUnknown() {
 		readMode = model.ReadMode.ValueString()
 	}
 
-	if readMode == "none" {
+	refresh, err := shouldRefreshOnRead(readMode, model.RefreshInterval, model.LastResponseAt, time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid read_mode configuration", err.Error())
+		return
+	}
+
+	if r.config.DryRun && refresh {
+		tflog.Info(ctx, "Dry run enabled, skipping refresh")
+		refresh = false
+	}
+
+	// A null ID marks a failed create kept in state only for error_response_body
+	if !refresh || !isRequestEnabled(model.Enabled) || model.Id.IsNull() {
 		// No-op: just return current state
+		resp.Diagnostics.Append(loadPrivateOutputs(ctx, r.config, &model, req.Private)...)
 		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 		return
 	}
 
-	// readMode == "refresh": re-execute the request
-	headers, err := ConvertTerraformMap(ctx, model.Headers)
+	// Re-execute the request
+	headers, err := ConvertTerraformMap(ctx, aliasedMap(model.RequestHeaders, model.Headers))
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid Headers", err.Error())
 		return
@@ -669,65 +1839,144 @@ func (r *HttpxRequestResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	cookies, err := ConvertTerraformMap(ctx, model.Cookies)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Cookies", err.Error())
+		return
+	}
+
+	pathParams, err := ConvertTerraformMap(ctx, model.PathParams)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Path Params", err.Error())
+		return
+	}
+
 	// Build and execute request
-	httpReq, err := BuildRequest(ctx, &RequestConfig{
-		Url:              model.Url.ValueString(),
-		Method:           model.Method.ValueString(),
-		Headers:          headers,
-		HeaderBlocks:     model.HeaderBlocks,
-		Query:            query,
-		Body:             model.Body,
-		BodyJson:         model.BodyJson,
-		BodyFile:         model.BodyFile,
-		BasicAuth:        model.BasicAuth,
-		BearerToken:      model.BearerToken,
-		ProviderDefaults: r.config,
-	})
+	httpReq, err := BuildRequest(ctx, requestConfigFromModel(&model, headers, query, cookies, pathParams, r.config))
+	if errors.Is(err, errPresignedURLExpired) {
+		// A URL that expired after create must not block every later plan; replacing it is up to the user
+		resp.Diagnostics.AddWarning("Presigned URL expired", fmt.Sprintf("Keeping the values from the last successful read: %s", err.Error()))
+		resp.Diagnostics.Append(loadPrivateOutputs(ctx, r.config, &model, req.Private)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to build request", err.Error())
 		return
 	}
 
+	// Add headers built from other resources' private_outputs, falling back to the copy in
+	// private state when their producer isn't applied in this run
+	shared, err := applyDependsOnOutputs(ctx, httpReq, model.DependsOnOutputs, r.config, req.Private)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid depends_on_outputs", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(storeSharedOutputs(ctx, r.config, shared, resp.Private)...)
+
+	// Extend the provider's redact_headers with this request's own list
+	reqConfig, err := r.config.WithAuditSource("httpx_request", "read").WithRedactHeaders(ctx, model.RedactHeaders)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid redact_headers", err.Error())
+		return
+	}
+
+	// Apply per-resource response body limits
+	execConfig, err := reqConfig.WithResponseBodyLimit(model.MaxResponseBodyBytes, model.OnBodyOverflow)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid response body limit", err.Error())
+		return
+	}
+	execConfig = execConfig.WithCaptureTranscript(model.CaptureTranscript).WithResume(model.Resume)
+	execConfig, err = execConfig.WithNonce(model.NonceHeader, model.NonceScope)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid nonce configuration", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithCommandHooks(ctx, model.PreRequestCommand, model.PostResponseCommand)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid command hook", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithPhaseTimeouts(model.ConnectTimeoutMs, model.TlsHandshakeTimeoutMs, model.ResponseHeaderTimeoutMs)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout configuration", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithPinnedSPKI(ctx, model.PinnedSpkiSha256)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid pinned_spki_sha256", err.Error())
+		return
+	}
+
 	// Build retry configs
 	retryConfig := BuildRetryConfig(ctx, model.Retry)
 	retryUntilConfig := BuildRetryUntilConfig(ctx, model.RetryUntil)
+	abortOnConfig := BuildAbortOnConfig(ctx, model.AbortOn)
 
-	// Handle timeouts if configured
-	updateCtx := ctx
-	if model.Timeouts != nil && !model.Timeouts.Update.IsNull() && !model.Timeouts.Update.IsUnknown() {
-		timeoutStr := model.Timeouts.Update.ValueString()
-		if timeout, err := time.ParseDuration(timeoutStr); err == nil {
-			var cancel context.CancelFunc
-			updateCtx, cancel = context.WithTimeout(ctx, timeout)
-			defer cancel()
-		}
+	// Bound the operation by timeouts.read or its default
+	timeout, diags := model.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	readCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
 	// Execute request with retry and conditional retry
-	result, err := ExecuteRequestWithRetry(updateCtx, httpReq, r.config, retryConfig, retryUntilConfig)
+	result, err := ExecuteRequestWithRetry(readCtx, httpReq, execConfig, retryConfig, retryUntilConfig, abortOnConfig)
+
+	// Accept error responses configured in treat_as_success or on_conflict, such as 409 from an idempotent create
+	result, _, err = acceptErrorResponse(readCtx, &model, httpReq, execConfig, retryConfig, result, err)
 	if err != nil {
-		if updateCtx.Err() == context.DeadlineExceeded {
-			resp.Diagnostics.AddError("Request timeout", fmt.Sprintf("Request exceeded timeout, last error: %s", err.Error()))
+		notifyFailure(ctx, httpReq, execConfig, result, err)
+		if readCtx.Err() == context.DeadlineExceeded {
+			resp.Diagnostics.AddError("Request timeout", fmt.Sprintf("Request exceeded timeout, last error: %s", requestFailureDetail(err, result)))
 		} else {
-			resp.Diagnostics.AddError("Request failed", err.Error())
+			resp.Diagnostics.AddError("Request failed", requestFailureDetail(err, result))
 		}
 		return
 	}
 
 	// Update state with fresh response
 	model.StatusCode = types.Int64Value(result.StatusCode)
+	model.StatusText = stringOrNull(result.StatusText)
+	model.Protocol = stringOrNull(result.Protocol)
+	model.RemoteAddr = stringOrNull(result.RemoteAddr)
+	model.TlsVersion = tlsVersionValue(result.TLS)
+	model.TlsCipherSuite = tlsCipherSuiteValue(result.TLS)
+	model.PeerCertSha256 = peerCertSha256Value(result.TLS)
 	model.LastAttemptCount = types.Int64Value(result.AttemptCount)
+	model.LastResponseAt = currentTimestamp()
+	historyValue, historyDiags := AttemptHistoryValue(ctx, result.AttemptHistory)
+	resp.Diagnostics.Append(historyDiags...)
+	model.AttemptHistory = historyValue
+	model.Transcript = TranscriptValue(result.AttemptHistory)
+	model.Nonce = NonceValue(result.AttemptHistory)
+	model.RequestHeadersSent = RequestHeadersSentValue(result.AttemptHistory)
 	if result.Error != "" {
 		model.LastError = types.StringValue(result.Error)
 	} else {
 		model.LastError = types.StringNull()
 	}
+	model.ErrorResponseBody = types.StringNull()
 
-	responseHeaders := make(map[string]attr.Value)
-	for k, v := range result.Headers {
-		responseHeaders[k] = types.StringValue(v)
+	responseHeaders, err := ResponseHeadersValue(ctx, normalizeResponseHeaderNames(result.Headers, model.ResponseHeaderNames), model.IgnoreResponseHeaders)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ignore_response_headers", err.Error())
+		return
 	}
-	model.ResponseHeaders = types.MapValueMust(types.StringType, responseHeaders)
+	model.ResponseHeaders = responseHeaders
+
+	responseCookies, cookieDiags := ResponseCookiesValue(ctx, result.Cookies)
+	resp.Diagnostics.Append(cookieDiags...)
+	model.ResponseCookies = responseCookies
+	model.ResponseLinks = ResponseLinksValue(result)
+
+	redirectChain, redirectDiags := RedirectChainValue(ctx, result.RedirectChain)
+	resp.Diagnostics.Append(redirectDiags...)
+	model.RedirectChain = redirectChain
+	model.EffectiveUrl = effectiveURLValue(result)
 
 	// Default: true, but false if extract blocks present (unless explicitly set)
 	storeBody := true
@@ -740,22 +1989,42 @@ func (r *HttpxRequestResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	if storeBody {
-		model.ResponseBody = types.StringValue(result.Body)
+		storedBody, err := NormalizeResponseBody(ctx, result.Body, model.NormalizeResponseBody, model.IgnoreBodyPaths)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to normalize response body", err.Error())
+			return
+		}
+		model.ResponseBody = types.StringValue(storedBody)
+		model.ResponseBodyJson = ResponseBodyJsonValue(ctx, storedBody)
 	}
 
-	// Extract values from response
-	extractedOutputs, err := ExtractValues(ctx, result, model.ExtractBlocks)
+	// Write response body to a local file if configured
+	bodyFileSha256, err := ResponseBodyFileValue(model.ResponseBodyFile, result.Body)
 	if err != nil {
-		resp.Diagnostics.AddWarning("Extraction warnings", fmt.Sprintf("Some values could not be extracted: %v", err))
+		resp.Diagnostics.AddError("Failed to write response body file", err.Error())
+		return
 	}
+	model.ResponseBodyFileSha256 = bodyFileSha256
 
-	// Convert extracted outputs to Terraform map
-	outputsMap := make(map[string]attr.Value)
-	for k, v := range extractedOutputs {
-		outputsMap[k] = types.StringValue(v)
+	// Extract values from response
+	outputsMap, ok := extractResourceOutputs(ctx, &resp.Diagnostics, &model, result)
+	if !ok {
+		return
 	}
+	resp.Diagnostics.Append(storePrivateOutputs(ctx, r.config, &model, outputsMap, resp.Private)...)
 	model.Outputs = types.MapValueMust(types.StringType, outputsMap)
 
+	// Extract arrays into list outputs
+	outputsLists, listDiags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, ExtractListValues(ctx, result, model.ExtractBlocks))
+	resp.Diagnostics.Append(listDiags...)
+	model.OutputsLists = outputsLists
+
+	// Encrypt stored payloads when state_encryption_key is set
+	if err := sealResponseState(ctx, r.config, resourceResponseFields(&model)); err != nil {
+		resp.Diagnostics.AddError("Failed to encrypt state", err.Error())
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
@@ -768,7 +2037,44 @@ func (r *HttpxRequestResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	headers, err := ConvertTerraformMap(ctx, model.Headers)
+	// Changes to read_mode, store_response_body and the like don't warrant sending the request again
+	if metadataOnlyChange(req.Plan, req.State) {
+		tflog.Info(ctx, "Only metadata attributes changed, updating state without executing the request")
+		var state HttpxRequestResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if storesResponseBody(model) && state.ResponseBody.IsNull() {
+			resp.Diagnostics.AddWarning("Response body not available",
+				"store_response_body takes effect the next time the request is executed, the request was not re-sent for this change")
+		}
+		merged := applyMetadataChange(model, state)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &merged)...)
+		return
+	}
+	ensureRequestPreview(ctx, &model, r.config)
+
+	if !isRequestEnabled(model.Enabled) {
+		tflog.Info(ctx, "Request is disabled, skipping execution")
+		var state HttpxRequestResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		model.Id = state.Id
+		model.CreatedAt = state.CreatedAt
+		if requestHasRun(state) {
+			// on_destroy still has to tear down what the request created, using its last response
+			keepLastResponseValues(&model, state)
+		} else {
+			setDisabledComputedValues(&model)
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
+	headers, err := ConvertTerraformMap(ctx, aliasedMap(model.RequestHeaders, model.Headers))
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid Headers", err.Error())
 		return
@@ -780,67 +2086,161 @@ func (r *HttpxRequestResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	httpReq, err := BuildRequest(ctx, &RequestConfig{
-		Url:              model.Url.ValueString(),
-		Method:           model.Method.ValueString(),
-		Headers:          headers,
-		HeaderBlocks:     model.HeaderBlocks,
-		Query:            query,
-		Body:             model.Body,
-		BodyJson:         model.BodyJson,
-		BodyFile:         model.BodyFile,
-		BasicAuth:        model.BasicAuth,
-		BearerToken:      model.BearerToken,
-		ProviderDefaults: r.config,
-	})
+	cookies, err := ConvertTerraformMap(ctx, model.Cookies)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Cookies", err.Error())
+		return
+	}
+
+	pathParams, err := ConvertTerraformMap(ctx, model.PathParams)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Path Params", err.Error())
+		return
+	}
+
+	httpReq, err := BuildRequest(ctx, requestConfigFromModel(&model, headers, query, cookies, pathParams, r.config))
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to build request", err.Error())
 		return
 	}
 
+	// Add headers built from other resources' private_outputs, falling back to the copy in
+	// private state when their producer isn't applied in this run
+	shared, err := applyDependsOnOutputs(ctx, httpReq, model.DependsOnOutputs, r.config, req.Private)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid depends_on_outputs", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(storeSharedOutputs(ctx, r.config, shared, resp.Private)...)
+
+	// Extend the provider's redact_headers with this request's own list
+	reqConfig, err := r.config.WithAuditSource("httpx_request", "update").WithRedactHeaders(ctx, model.RedactHeaders)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid redact_headers", err.Error())
+		return
+	}
+
+	// In dry-run mode the request is only logged
+	if reqConfig.DryRun {
+		logDryRunRequest(ctx, httpReq, reqConfig)
+		addDryRunNotSentError(&resp.Diagnostics, "update")
+		return
+	}
+
+	// Apply per-resource response body limits
+	execConfig, err := reqConfig.WithResponseBodyLimit(model.MaxResponseBodyBytes, model.OnBodyOverflow)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid response body limit", err.Error())
+		return
+	}
+	execConfig = execConfig.WithCaptureTranscript(model.CaptureTranscript).WithResume(model.Resume)
+	execConfig, err = execConfig.WithNonce(model.NonceHeader, model.NonceScope)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid nonce configuration", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithCommandHooks(ctx, model.PreRequestCommand, model.PostResponseCommand)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid command hook", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithPhaseTimeouts(model.ConnectTimeoutMs, model.TlsHandshakeTimeoutMs, model.ResponseHeaderTimeoutMs)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout configuration", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithPinnedSPKI(ctx, model.PinnedSpkiSha256)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid pinned_spki_sha256", err.Error())
+		return
+	}
+
 	// Build retry configs
 	retryConfig := BuildRetryConfig(ctx, model.Retry)
 	retryUntilConfig := BuildRetryUntilConfig(ctx, model.RetryUntil)
+	abortOnConfig := BuildAbortOnConfig(ctx, model.AbortOn)
 
-	// Handle timeouts if configured
-	readCtx := ctx
-	if model.Timeouts != nil && !model.Timeouts.Read.IsNull() && !model.Timeouts.Read.IsUnknown() {
-		timeoutStr := model.Timeouts.Read.ValueString()
-		if timeout, err := time.ParseDuration(timeoutStr); err == nil {
-			var cancel context.CancelFunc
-			readCtx, cancel = context.WithTimeout(ctx, timeout)
-			defer cancel()
-		}
+	// Bound the operation by timeouts.update or its default
+	timeout, diags := model.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	updateCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
 	// Execute request with retry and conditional retry
-	result, err := ExecuteRequestWithRetry(readCtx, httpReq, r.config, retryConfig, retryUntilConfig)
+	result, err := ExecuteRequestWithRetry(updateCtx, httpReq, execConfig, retryConfig, retryUntilConfig, abortOnConfig)
+
+	// Accept error responses configured in treat_as_success or on_conflict, such as 409 from an idempotent create
+	result, accepted, err := acceptErrorResponse(updateCtx, &model, httpReq, execConfig, retryConfig, result, err)
 	if err != nil {
-		resp.Diagnostics.AddError("Request failed", err.Error())
+		notifyFailure(ctx, httpReq, execConfig, result, err)
+		if updateCtx.Err() == context.DeadlineExceeded {
+			resp.Diagnostics.AddError("Request timeout", fmt.Sprintf("Request exceeded timeout, last error: %s", requestFailureDetail(err, result)))
+		} else {
+			resp.Diagnostics.AddError("Request failed", requestFailureDetail(err, result))
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("error_response_body"), errorResponseBodyValue(result, execConfig))...)
 		return
 	}
 
-	if model.Expect != nil {
+	if model.Expect != nil && !accepted {
 		if err := ValidateExpectations(ctx, result, model.Expect); err != nil {
-			resp.Diagnostics.AddError("Expectation validation failed", err.Error())
-			return
+			if expectationIsWarning(model.Expect) {
+				resp.Diagnostics.AddWarning("Expectation validation failed", err.Error())
+			} else {
+				notifyFailure(ctx, httpReq, execConfig, result, fmt.Errorf("expectation validation failed: %w", err))
+				resp.Diagnostics.AddError("Expectation validation failed", err.Error())
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("error_response_body"), errorResponseBodyValue(result, execConfig))...)
+				return
+			}
 		}
 	}
 
 	// Update computed attributes
 	model.StatusCode = types.Int64Value(result.StatusCode)
+	model.StatusText = stringOrNull(result.StatusText)
+	model.Protocol = stringOrNull(result.Protocol)
+	model.RemoteAddr = stringOrNull(result.RemoteAddr)
+	model.TlsVersion = tlsVersionValue(result.TLS)
+	model.TlsCipherSuite = tlsCipherSuiteValue(result.TLS)
+	model.PeerCertSha256 = peerCertSha256Value(result.TLS)
 	model.LastAttemptCount = types.Int64Value(result.AttemptCount)
+	model.LastResponseAt = currentTimestamp()
+	if model.CreatedAt.IsUnknown() {
+		// Resources created before created_at existed have no stored value
+		model.CreatedAt = model.LastResponseAt
+	}
+	historyValue, historyDiags := AttemptHistoryValue(ctx, result.AttemptHistory)
+	resp.Diagnostics.Append(historyDiags...)
+	model.AttemptHistory = historyValue
+	model.Transcript = TranscriptValue(result.AttemptHistory)
+	model.Nonce = NonceValue(result.AttemptHistory)
+	model.RequestHeadersSent = RequestHeadersSentValue(result.AttemptHistory)
 	if result.Error != "" {
 		model.LastError = types.StringValue(result.Error)
 	} else {
 		model.LastError = types.StringNull()
 	}
+	model.ErrorResponseBody = types.StringNull()
 
-	responseHeaders := make(map[string]attr.Value)
-	for k, v := range result.Headers {
-		responseHeaders[k] = types.StringValue(v)
+	responseHeaders, err := ResponseHeadersValue(ctx, normalizeResponseHeaderNames(result.Headers, model.ResponseHeaderNames), model.IgnoreResponseHeaders)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ignore_response_headers", err.Error())
+		return
 	}
-	model.ResponseHeaders = types.MapValueMust(types.StringType, responseHeaders)
+	model.ResponseHeaders = responseHeaders
+
+	responseCookies, cookieDiags := ResponseCookiesValue(ctx, result.Cookies)
+	resp.Diagnostics.Append(cookieDiags...)
+	model.ResponseCookies = responseCookies
+	model.ResponseLinks = ResponseLinksValue(result)
+
+	redirectChain, redirectDiags := RedirectChainValue(ctx, result.RedirectChain)
+	resp.Diagnostics.Append(redirectDiags...)
+	model.RedirectChain = redirectChain
+	model.EffectiveUrl = effectiveURLValue(result)
 
 	// Default: true, but false if extract blocks present (unless explicitly set)
 	storeBody := true
@@ -853,24 +2253,45 @@ func (r *HttpxRequestResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	if storeBody {
-		model.ResponseBody = types.StringValue(result.Body)
+		storedBody, err := NormalizeResponseBody(ctx, result.Body, model.NormalizeResponseBody, model.IgnoreBodyPaths)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to normalize response body", err.Error())
+			return
+		}
+		model.ResponseBody = types.StringValue(storedBody)
+		model.ResponseBodyJson = ResponseBodyJsonValue(ctx, storedBody)
 	} else {
 		model.ResponseBody = types.StringNull()
+		model.ResponseBodyJson = types.DynamicNull()
 	}
 
-	// Extract values from response
-	extractedOutputs, err := ExtractValues(ctx, result, model.ExtractBlocks)
+	// Write response body to a local file if configured
+	bodyFileSha256, err := ResponseBodyFileValue(model.ResponseBodyFile, result.Body)
 	if err != nil {
-		resp.Diagnostics.AddWarning("Extraction warnings", fmt.Sprintf("Some values could not be extracted: %v", err))
+		resp.Diagnostics.AddError("Failed to write response body file", err.Error())
+		return
 	}
+	model.ResponseBodyFileSha256 = bodyFileSha256
 
-	// Convert extracted outputs to Terraform map
-	outputsMap := make(map[string]attr.Value)
-	for k, v := range extractedOutputs {
-		outputsMap[k] = types.StringValue(v)
+	// Extract values from response
+	outputsMap, ok := extractResourceOutputs(ctx, &resp.Diagnostics, &model, result)
+	if !ok {
+		return
 	}
+	resp.Diagnostics.Append(storePrivateOutputs(ctx, r.config, &model, outputsMap, resp.Private)...)
 	model.Outputs = types.MapValueMust(types.StringType, outputsMap)
 
+	// Extract arrays into list outputs
+	outputsLists, listDiags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, ExtractListValues(ctx, result, model.ExtractBlocks))
+	resp.Diagnostics.Append(listDiags...)
+	model.OutputsLists = outputsLists
+
+	// Encrypt stored payloads when state_encryption_key is set
+	if err := sealResponseState(ctx, r.config, resourceResponseFields(&model)); err != nil {
+		resp.Diagnostics.AddError("Failed to encrypt state", err.Error())
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
@@ -889,14 +2310,57 @@ func (r *HttpxRequestResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	// A request created while disabled never executed, so there is nothing to tear down
+	if !isRequestEnabled(model.Enabled) && !requestHasRun(model) {
+		tflog.Info(ctx, "Delete method called - request never executed, skipping on_destroy")
+		return
+	}
+
+	// A failed create (null ID) is only kept in state for error_response_body
+	if model.Id.IsNull() {
+		tflog.Info(ctx, "Delete method called - create did not succeed, skipping on_destroy")
+		return
+	}
+
 	tflog.Info(ctx, "Delete method called - executing on_destroy request")
 
+	failureMode, err := parseDestroyFailureMode(model.OnDestroy.FailureMode)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid on_destroy configuration", err.Error())
+		return
+	}
+
+	// Bound the operation by timeouts.delete or its default (covers the pre-destroy refresh and the destroy request)
+	timeout, diags := model.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deleteCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Optionally re-execute the root request so templates see current remote values
+	if !model.OnDestroy.RefreshBeforeDestroy.IsNull() && model.OnDestroy.RefreshBeforeDestroy.ValueBool() && !r.config.DryRun {
+		if err := r.refreshForDestroy(deleteCtx, &model, req.Private); err != nil {
+			resp.Diagnostics.AddWarning("Pre-destroy refresh failed", fmt.Sprintf("Falling back to values stored in state: %s", err.Error()))
+		}
+	}
+
 	// Build interpolation context from current state
 	interpolCtx, err := BuildInterpolationContextFromState(ctx, &model)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to build interpolation context", err.Error())
 		return
 	}
+	if err := openInterpolationContext(r.config, interpolCtx); err != nil {
+		resp.Diagnostics.AddError("Failed to decrypt state", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(addPrivateOutputs(ctx, r.config, interpolCtx, req.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Expand templates in on_destroy config
 	destroyConfig := model.OnDestroy
@@ -912,6 +2376,7 @@ func (r *HttpxRequestResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 
 	// Interpolate headers (map)
+	destroyConfig.Headers = aliasedMap(destroyConfig.RequestHeaders, destroyConfig.Headers)
 	if !destroyConfig.Headers.IsNull() {
 		headersMap, err := ConvertTerraformMap(ctx, destroyConfig.Headers)
 		if err != nil {
@@ -960,6 +2425,44 @@ func (r *HttpxRequestResource) Delete(ctx context.Context, req resource.DeleteRe
 		destroyConfig.Query = types.MapValueMust(types.StringType, queryAttrMap)
 	}
 
+	// Interpolate path parameters
+	if !destroyConfig.PathParams.IsNull() {
+		pathParamsMap, err := ConvertTerraformMap(ctx, destroyConfig.PathParams)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid destroy path_params", err.Error())
+			return
+		}
+		expandedPathParams, err := InterpolateMap(ctx, pathParamsMap, interpolCtx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to interpolate destroy path_params", err.Error())
+			return
+		}
+		pathParamsAttrMap := make(map[string]attr.Value)
+		for k, v := range expandedPathParams {
+			pathParamsAttrMap[k] = types.StringValue(v)
+		}
+		destroyConfig.PathParams = types.MapValueMust(types.StringType, pathParamsAttrMap)
+	}
+
+	// Interpolate cookies
+	if !destroyConfig.Cookies.IsNull() {
+		cookiesMap, err := ConvertTerraformMap(ctx, destroyConfig.Cookies)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid destroy cookies", err.Error())
+			return
+		}
+		expandedCookies, err := InterpolateMap(ctx, cookiesMap, interpolCtx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to interpolate destroy cookies", err.Error())
+			return
+		}
+		cookiesAttrMap := make(map[string]attr.Value)
+		for k, v := range expandedCookies {
+			cookiesAttrMap[k] = types.StringValue(v)
+		}
+		destroyConfig.Cookies = types.MapValueMust(types.StringType, cookiesAttrMap)
+	}
+
 	// Interpolate body fields
 	if !destroyConfig.Body.IsNull() {
 		expandedBody, err := InterpolateString(ctx, destroyConfig.Body.ValueString(), interpolCtx)
@@ -979,6 +2482,15 @@ func (r *HttpxRequestResource) Delete(ctx context.Context, req resource.DeleteRe
 		destroyConfig.BodyJson = types.StringValue(expandedBodyJson)
 	}
 
+	if !destroyConfig.BodyObject.IsNull() {
+		expandedBodyObject, err := InterpolateBodyObject(ctx, destroyConfig.BodyObject, interpolCtx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to interpolate destroy body_object", err.Error())
+			return
+		}
+		destroyConfig.BodyObject = expandedBodyObject
+	}
+
 	// Build HTTP request from destroy config
 	headers, err := ConvertTerraformMap(ctx, destroyConfig.Headers)
 	if err != nil {
@@ -992,44 +2504,129 @@ func (r *HttpxRequestResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	cookies, err := ConvertTerraformMap(ctx, destroyConfig.Cookies)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid destroy cookies", err.Error())
+		return
+	}
+
+	pathParams, err := ConvertTerraformMap(ctx, destroyConfig.PathParams)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid destroy path_params", err.Error())
+		return
+	}
+
 	httpReq, err := BuildRequest(ctx, &RequestConfig{
-		Url:              destroyConfig.Url.ValueString(),
-		Method:           destroyConfig.Method.ValueString(),
-		Headers:          headers,
-		HeaderBlocks:     destroyConfig.HeaderBlocks,
-		Query:            query,
-		Body:             destroyConfig.Body,
-		BodyJson:         destroyConfig.BodyJson,
-		BodyFile:         destroyConfig.BodyFile,
-		BasicAuth:        destroyConfig.BasicAuth,
-		BearerToken:      destroyConfig.BearerToken,
-		ProviderDefaults: r.config,
+		Url:                destroyConfig.Url.ValueString(),
+		PathParams:         pathParams,
+		Method:             destroyConfig.Method.ValueString(),
+		AllowCustomMethods: destroyConfig.AllowCustomMethods.ValueBool(),
+		Headers:            headers,
+		HeaderBlocks:       destroyConfig.HeaderBlocks,
+		PreserveHeaderCase: destroyConfig.PreserveHeaderCase.ValueBool(),
+		Query:              query,
+		Cookies:            cookies,
+		Body:               destroyConfig.Body,
+		BodyJson:           destroyConfig.BodyJson,
+		BodyObject:         destroyConfig.BodyObject,
+		BodyFile:           destroyConfig.BodyFile,
+		AutoContentDigest:  destroyConfig.AutoContentDigest.ValueString(),
+		BasicAuth:          destroyConfig.BasicAuth,
+		BearerToken:        destroyConfig.BearerToken,
+		ProviderDefaults:   r.config,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to build destroy request", err.Error())
 		return
 	}
 
+	// Extend the provider's redact_headers with this request's own list
+	reqConfig, err := r.config.WithAuditSource("httpx_request", "delete").WithRedactHeaders(ctx, destroyConfig.RedactHeaders)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid destroy redact_headers", err.Error())
+		return
+	}
+
+	// In dry-run mode the destroy request is only logged
+	if reqConfig.DryRun {
+		logDryRunRequest(ctx, httpReq, reqConfig)
+		addDryRunNotSentError(&resp.Diagnostics, "destroy")
+		return
+	}
+
+	// Apply per-resource response body limits
+	execConfig, err := reqConfig.WithResponseBodyLimit(destroyConfig.MaxResponseBodyBytes, destroyConfig.OnBodyOverflow)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid destroy response body limit", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithNonce(destroyConfig.NonceHeader, destroyConfig.NonceScope)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid destroy nonce configuration", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithCommandHooks(ctx, destroyConfig.PreRequestCommand, destroyConfig.PostResponseCommand)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid destroy command hook", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithPhaseTimeouts(destroyConfig.ConnectTimeoutMs, destroyConfig.TlsHandshakeTimeoutMs, destroyConfig.ResponseHeaderTimeoutMs)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid destroy timeout configuration", err.Error())
+		return
+	}
+	execConfig, err = execConfig.WithPinnedSPKI(ctx, destroyConfig.PinnedSpkiSha256)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid destroy pinned_spki_sha256", err.Error())
+		return
+	}
+
 	// Build retry configs
 	retryConfig := BuildRetryConfig(ctx, destroyConfig.Retry)
 	retryUntilConfig := BuildRetryUntilConfig(ctx, destroyConfig.RetryUntil)
+	abortOnConfig := BuildAbortOnConfig(ctx, destroyConfig.AbortOn)
+
+	successCodes, err := ConvertTerraformList(ctx, destroyConfig.TreatStatusAsSuccess, func(v interface{}) (int64, error) {
+		if intVal, ok := v.(types.Int64); ok {
+			return intVal.ValueInt64(), nil
+		}
+		return 0, fmt.Errorf("expected int64, got %T", v)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid treat_status_as_success", err.Error())
+		return
+	}
 
 	// Execute request with retry logic
-	result, err := ExecuteRequestWithRetry(ctx, httpReq, r.config, retryConfig, retryUntilConfig)
+	result, err := ExecuteRequestWithRetry(deleteCtx, httpReq, execConfig, retryConfig, retryUntilConfig, abortOnConfig)
+	if result != nil && containsStatusCode(successCodes, result.StatusCode) {
+		// e.g. 404/410: the remote object was already deleted out-of-band
+		tflog.Info(ctx, fmt.Sprintf("Destroy request returned status code %d, treating as success", result.StatusCode))
+		return
+	}
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Destroy request failed: %s", err.Error()))
-		resp.Diagnostics.AddError("Destroy request failed", err.Error())
-		// Keep state on destroy failure so Terraform can retry
+		notifyFailure(ctx, httpReq, execConfig, result, err)
+		if deleteCtx.Err() == context.DeadlineExceeded {
+			handleDestroyFailure(ctx, resp, failureMode, "Destroy request timeout", fmt.Sprintf("Destroy request exceeded timeout, last error: %s", err.Error()))
+		} else {
+			handleDestroyFailure(ctx, resp, failureMode, "Destroy request failed", err.Error())
+		}
 		return
 	}
 
 	// Validate expectations
 	if destroyConfig.Expect != nil {
 		if err := ValidateExpectations(ctx, result, destroyConfig.Expect); err != nil {
-			tflog.Error(ctx, fmt.Sprintf("Destroy expectation validation failed: %s", err.Error()))
-			resp.Diagnostics.AddError("Destroy expectation validation failed", err.Error())
-			// Keep state on expectation failure
-			return
+			if expectationIsWarning(destroyConfig.Expect) {
+				tflog.Warn(ctx, fmt.Sprintf("Destroy expectation validation failed: %s", err.Error()))
+				resp.Diagnostics.AddWarning("Destroy expectation validation failed", err.Error())
+			} else {
+				tflog.Error(ctx, fmt.Sprintf("Destroy expectation validation failed: %s", err.Error()))
+				notifyFailure(ctx, httpReq, execConfig, result, fmt.Errorf("destroy expectation validation failed: %w", err))
+				handleDestroyFailure(ctx, resp, failureMode, "Destroy expectation validation failed", err.Error())
+				return
+			}
 		}
 	}
 
@@ -1038,3 +2635,173 @@ func (r *HttpxRequestResource) Delete(ctx context.Context, req resource.DeleteRe
 
 	// Successfully removed - state will be cleared by Terraform framework
 }
+
+// refreshForDestroy re-executes the root request and updates the response fields
+// of model in memory. The refreshed values are only used for on_destroy interpolation.
+func (r *HttpxRequestResource) refreshForDestroy(ctx context.Context, model *HttpxRequestResourceModel, private privateStateReader) error {
+	headers, err := ConvertTerraformMap(ctx, aliasedMap(model.RequestHeaders, model.Headers))
+	if err != nil {
+		return fmt.Errorf("invalid headers: %w", err)
+	}
+
+	query, err := ConvertTerraformMap(ctx, model.Query)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	cookies, err := ConvertTerraformMap(ctx, model.Cookies)
+	if err != nil {
+		return fmt.Errorf("invalid cookies: %w", err)
+	}
+
+	pathParams, err := ConvertTerraformMap(ctx, model.PathParams)
+	if err != nil {
+		return fmt.Errorf("invalid path_params: %w", err)
+	}
+
+	httpReq, err := BuildRequest(ctx, requestConfigFromModel(model, headers, query, cookies, pathParams, r.config))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	if _, err := applyDependsOnOutputs(ctx, httpReq, model.DependsOnOutputs, r.config, private); err != nil {
+		return fmt.Errorf("invalid depends_on_outputs: %w", err)
+	}
+
+	reqConfig, err := r.config.WithAuditSource("httpx_request", "refresh").WithRedactHeaders(ctx, model.RedactHeaders)
+	if err != nil {
+		return fmt.Errorf("invalid redact_headers: %w", err)
+	}
+
+	execConfig, err := reqConfig.WithResponseBodyLimit(model.MaxResponseBodyBytes, model.OnBodyOverflow)
+	if err != nil {
+		return fmt.Errorf("invalid response body limit: %w", err)
+	}
+	execConfig, err = execConfig.WithNonce(model.NonceHeader, model.NonceScope)
+	if err != nil {
+		return fmt.Errorf("invalid nonce configuration: %w", err)
+	}
+	execConfig, err = execConfig.WithCommandHooks(ctx, model.PreRequestCommand, model.PostResponseCommand)
+	if err != nil {
+		return fmt.Errorf("invalid command hook: %w", err)
+	}
+	execConfig, err = execConfig.WithPhaseTimeouts(model.ConnectTimeoutMs, model.TlsHandshakeTimeoutMs, model.ResponseHeaderTimeoutMs)
+	if err != nil {
+		return fmt.Errorf("invalid timeout configuration: %w", err)
+	}
+	execConfig, err = execConfig.WithPinnedSPKI(ctx, model.PinnedSpkiSha256)
+	if err != nil {
+		return fmt.Errorf("invalid pinned_spki_sha256: %w", err)
+	}
+
+	retryConfig := BuildRetryConfig(ctx, model.Retry)
+	retryUntilConfig := BuildRetryUntilConfig(ctx, model.RetryUntil)
+	abortOnConfig := BuildAbortOnConfig(ctx, model.AbortOn)
+
+	result, err := ExecuteRequestWithRetry(ctx, httpReq, execConfig, retryConfig, retryUntilConfig, abortOnConfig)
+	if err != nil {
+		return err
+	}
+
+	// The refreshed outputs are only used for on_destroy interpolation and never stored, so
+	// private_outputs stay among them instead of moving to private state
+	var extractDiags diag.Diagnostics
+	outputsMap, ok := extractResourceOutputs(ctx, &extractDiags, model, result)
+	if !ok {
+		return fmt.Errorf("%s", extractDiags.Errors()[0].Detail())
+	}
+	for _, warning := range extractDiags.Warnings() {
+		tflog.Warn(ctx, fmt.Sprintf("Pre-destroy refresh: %s", warning.Detail()))
+	}
+	model.Outputs = types.MapValueMust(types.StringType, outputsMap)
+	model.StatusCode = types.Int64Value(result.StatusCode)
+	model.ResponseBody = types.StringValue(result.Body)
+
+	tflog.Info(ctx, fmt.Sprintf("Pre-destroy refresh completed with status code %d", result.StatusCode))
+	return nil
+}
+
+const (
+	destroyFailureModeAbort  = "abort"
+	destroyFailureModeWarn   = "warn"
+	destroyFailureModeIgnore = "ignore"
+)
+
+// parseDestroyFailureMode validates on_destroy.failure_mode (defaults to abort)
+func parseDestroyFailureMode(mode types.String) (string, error) {
+	if mode.IsNull() || mode.IsUnknown() || mode.ValueString() == "" {
+		return destroyFailureModeAbort, nil
+	}
+
+	switch mode.ValueString() {
+	case destroyFailureModeAbort, destroyFailureModeWarn, destroyFailureModeIgnore:
+		return mode.ValueString(), nil
+	default:
+		return "", fmt.Errorf("failure_mode must be one of 'abort', 'warn', or 'ignore', got %q", mode.ValueString())
+	}
+}
+
+// handleDestroyFailure reports a destroy failure according to the failure mode.
+// Only 'abort' adds an error, which keeps the resource in state so Terraform can retry.
+func handleDestroyFailure(ctx context.Context, resp *resource.DeleteResponse, failureMode string, summary string, detail string) {
+	switch failureMode {
+	case destroyFailureModeIgnore:
+		tflog.Warn(ctx, fmt.Sprintf("%s (ignored by failure_mode): %s", summary, detail))
+	case destroyFailureModeWarn:
+		resp.Diagnostics.AddWarning(summary, detail+"\n\nThe resource was removed from state because on_destroy.failure_mode is 'warn'.")
+	default:
+		resp.Diagnostics.AddError(summary, detail)
+	}
+}
+
+// extractResourceOutputs evaluates the extract blocks of model against result, reporting values
+// that did not resolve to diags according to fail_on_extract_error. It returns false when they
+// fail the operation.
+func extractResourceOutputs(ctx context.Context, diags *diag.Diagnostics, model *HttpxRequestResourceModel, result *ResponseResult) (map[string]attr.Value, bool) {
+	extractedOutputs, extractFailures := extractValues(ctx, result, model.ExtractBlocks)
+	if reportExtractFailures(diags, model.FailOnExtractError, extractFailures) {
+		return nil, false
+	}
+
+	outputsMap := make(map[string]attr.Value, len(extractedOutputs))
+	for k, v := range extractedOutputs {
+		outputsMap[k] = types.StringValue(v)
+	}
+	return outputsMap, true
+}
+
+// containsStatusCode reports whether code is present in codes
+func containsStatusCode(codes []int64, code int64) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// requestConfigFromModel returns the configuration of the root request of model, given its map
+// attributes already converted
+func requestConfigFromModel(model *HttpxRequestResourceModel, headers, query, cookies, pathParams map[string]string, providerConfig *ProviderConfig) *RequestConfig {
+	return &RequestConfig{
+		Url:                model.Url.ValueString(),
+		PathParams:         pathParams,
+		Method:             model.Method.ValueString(),
+		AllowCustomMethods: model.AllowCustomMethods.ValueBool(),
+		Headers:            headers,
+		HeaderBlocks:       model.HeaderBlocks,
+		PreserveHeaderCase: model.PreserveHeaderCase.ValueBool(),
+		Query:              query,
+		Cookies:            cookies,
+		Body:               model.Body,
+		BodyJson:           model.BodyJson,
+		BodyObject:         model.BodyObject,
+		BodyFile:           model.BodyFile,
+		AutoContentDigest:  model.AutoContentDigest.ValueString(),
+		Range:              model.Range.ValueString(),
+		TransferEncoding:   model.TransferEncoding.ValueString(),
+		BasicAuth:          model.BasicAuth,
+		BearerToken:        model.BearerToken,
+		ProviderDefaults:   providerConfig,
+	}
+}
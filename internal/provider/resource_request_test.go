@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRequestEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled types.Bool
+		want    bool
+	}{
+		{
+			name:    "null defaults to enabled",
+			enabled: types.BoolNull(),
+			want:    true,
+		},
+		{
+			name:    "unknown defaults to enabled",
+			enabled: types.BoolUnknown(),
+			want:    true,
+		},
+		{
+			name:    "explicitly enabled",
+			enabled: types.BoolValue(true),
+			want:    true,
+		},
+		{
+			name:    "explicitly disabled",
+			enabled: types.BoolValue(false),
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRequestEnabled(tt.enabled))
+		})
+	}
+}
+
+func TestSetDisabledComputedValues(t *testing.T) {
+	model := &HttpxRequestResourceModel{
+		StatusCode:       types.Int64Value(200),
+		ResponseBody:     types.StringValue(`{"id": "123"}`),
+		LastAttemptCount: types.Int64Value(3),
+		LastError:        types.StringValue("previous error"),
+		ResponseHeaders: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Content-Type": types.StringValue("application/json"),
+		}),
+		Outputs: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"id": types.StringValue("123"),
+		}),
+	}
+
+	setDisabledComputedValues(model)
+
+	assert.True(t, model.StatusCode.IsNull())
+	assert.True(t, model.StatusText.IsNull())
+	assert.True(t, model.Protocol.IsNull())
+	assert.True(t, model.RemoteAddr.IsNull())
+	assert.True(t, model.TlsVersion.IsNull())
+	assert.True(t, model.TlsCipherSuite.IsNull())
+	assert.True(t, model.PeerCertSha256.IsNull())
+	assert.True(t, model.ResponseBody.IsNull())
+	assert.True(t, model.ResponseBodyFileSha256.IsNull())
+	assert.True(t, model.ResponseHeaders.IsNull())
+	assert.True(t, model.ResponseCookies.IsNull())
+	assert.True(t, model.ResponseLinks.IsNull())
+	assert.True(t, model.EffectiveUrl.IsNull())
+	assert.True(t, model.RedirectChain.IsNull())
+	assert.True(t, model.Outputs.IsNull())
+	assert.True(t, model.OutputsLists.IsNull())
+	assert.True(t, model.LastError.IsNull())
+	assert.True(t, model.ErrorResponseBody.IsNull())
+	assert.True(t, model.AttemptHistory.IsNull())
+	assert.True(t, model.Transcript.IsNull())
+	assert.True(t, model.Nonce.IsNull())
+	assert.True(t, model.RequestHeadersSent.IsNull())
+	assert.True(t, model.LastResponseAt.IsNull())
+	assert.Equal(t, int64(0), model.LastAttemptCount.ValueInt64())
+}
+
+func TestCurrentTimestamp(t *testing.T) {
+	value := currentTimestamp()
+	parsed, err := time.Parse(time.RFC3339, value.ValueString())
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), parsed, 2*time.Second)
+}
+
+func TestShouldRefreshOnRead(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	recent := types.StringValue("2026-01-02T00:00:00Z")
+	stale := types.StringValue("2026-01-01T00:00:00Z")
+
+	tests := []struct {
+		name            string
+		readMode        string
+		refreshInterval types.String
+		lastResponseAt  types.String
+		expected        bool
+		expectError     bool
+	}{
+		{name: "none", readMode: "none", refreshInterval: types.StringNull(), lastResponseAt: stale, expected: false},
+		{name: "refresh", readMode: "refresh", refreshInterval: types.StringNull(), lastResponseAt: recent, expected: true},
+		{name: "fresh response", readMode: "refresh_if_older_than", refreshInterval: types.StringValue("24h"), lastResponseAt: recent, expected: false},
+		{name: "stale response", readMode: "refresh_if_older_than", refreshInterval: types.StringValue("24h"), lastResponseAt: stale, expected: true},
+		{name: "never executed", readMode: "refresh_if_older_than", refreshInterval: types.StringValue("24h"), lastResponseAt: types.StringNull(), expected: true},
+		{name: "missing interval", readMode: "refresh_if_older_than", refreshInterval: types.StringNull(), lastResponseAt: stale, expectError: true},
+		{name: "invalid interval", readMode: "refresh_if_older_than", refreshInterval: types.StringValue("1 day"), lastResponseAt: stale, expectError: true},
+		{name: "unknown mode", readMode: "always", refreshInterval: types.StringNull(), lastResponseAt: stale, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := shouldRefreshOnRead(tt.readMode, tt.refreshInterval, tt.lastResponseAt, now)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
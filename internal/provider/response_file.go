@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// writeResponseBodyFile writes the response body to path and returns its hex-encoded SHA-256.
+// The body is written to a temporary file first so readers never see a partial artifact.
+func writeResponseBodyFile(path string, body string) (string, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory for response_body_file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create response_body_file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.WriteString(body); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write response_body_file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write response_body_file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0o644); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to set response_body_file permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write response_body_file: %w", err)
+	}
+
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ResponseBodyFileValue writes the body to response_body_file when configured and returns
+// the response_body_file_sha256 value (null when no file is configured)
+func ResponseBodyFileValue(path types.String, body string) (types.String, error) {
+	if path.IsNull() || path.IsUnknown() || path.ValueString() == "" {
+		return types.StringNull(), nil
+	}
+
+	checksum, err := writeResponseBodyFile(path.ValueString(), body)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(checksum), nil
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseBodyFileValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "artifacts", "rendered.txt")
+
+	checksum, err := ResponseBodyFileValue(types.StringValue(path), "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", checksum.ValueString())
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	// Rewriting replaces the previous content
+	_, err = ResponseBodyFileValue(types.StringValue(path), "world")
+	assert.NoError(t, err)
+	content, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "world", string(content))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files must be cleaned up")
+
+	// Not configured
+	checksum, err = ResponseBodyFileValue(types.StringNull(), "hello")
+	assert.NoError(t, err)
+	assert.True(t, checksum.IsNull())
+}
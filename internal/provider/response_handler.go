@@ -2,24 +2,60 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/davidshato/terraform-provider-httpx/internal/client"
+	"github.com/davidshato/terraform-provider-httpx/internal/config"
 	"github.com/davidshato/terraform-provider-httpx/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// errResponseBodyTooLarge is returned when on_body_overflow is "fail" and the body exceeds the limit
+var errResponseBodyTooLarge = errors.New("response body exceeds max_response_body_bytes")
+
+// redactedError masks header values, credentials and redact_query_params in an error message
+// while keeping the original error reachable through errors.Is and errors.As
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string { return e.msg }
+
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactErr wraps err so its message no longer contains redacted values
+func redactErr(err error, cfg *config.ProviderConfig) error {
+	redaction := utils.Redaction{Headers: cfg.RedactHeaders, QueryParams: cfg.RedactQueryParams, Patterns: cfg.RedactPatterns}
+	return &redactedError{err: err, msg: redaction.Apply(err.Error())}
+}
+
 // ResponseResult holds the result of an HTTP request
 type ResponseResult struct {
 	StatusCode      int64
+	StatusText      string
+	Protocol        string
+	RemoteAddr      string
 	Headers         map[string]string
+	Cookies         []*http.Cookie
+	TLS             *tls.ConnectionState
+	EffectiveURL    string
+	RedirectChain   []client.RedirectHop
 	Body            string
 	AttemptCount    int64
 	Error           string
+	AttemptHistory  []AttemptRecord
 }
 
 // ExecuteRequest executes an HTTP request and returns the response
@@ -32,14 +68,18 @@ func ExecuteRequest(ctx context.Context, req *http.Request, providerConfig *Prov
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
+	httpClient.SetLimiter(providerConfig.Limiter)
+	httpClient.SetFaultInjector(providerConfig.FaultInjector)
 
-	// Execute request
-	httpResp, err := httpClient.Do(req)
+	// Execute request bound to ctx so operation timeouts also cancel in-flight attempts
+	var remoteAddr string
+	httpResp, redirects, err := httpClient.DoTrackingRedirects(req.WithContext(withRemoteAddrTrace(ctx, &remoteAddr)))
 	if err != nil {
+		err = redactErr(err, cfg)
 		return &ResponseResult{
 			StatusCode:   0,
 			AttemptCount:  1,
-			Error:        utils.RedactError(err.Error(), cfg.RedactHeaders),
+			Error:        err.Error(),
 		}, fmt.Errorf("request failed: %w", err)
 	}
 	defer func() {
@@ -48,21 +88,34 @@ func ExecuteRequest(ctx context.Context, req *http.Request, providerConfig *Prov
 		}
 	}()
 
-	// Read response body with size limit
-	limitedReader := client.LimitReader(httpResp.Body, cfg.MaxResponseBodyBytes)
+	// Read response body with size limit, one extra byte detects overflow
+	limitedReader := client.LimitReader(httpResp.Body, cfg.MaxResponseBodyBytes+1)
 	bodyBytes, err := io.ReadAll(limitedReader)
 	if err != nil {
+		// With resume the next attempt continues after the bytes received so far
+		providerConfig.Resume.save(httpResp, bodyBytes)
+		err = redactErr(err, cfg)
 		return &ResponseResult{
 			StatusCode:   int64(httpResp.StatusCode),
 			AttemptCount: 1,
-			Error:        utils.RedactError(err.Error(), cfg.RedactHeaders),
+			Error:        err.Error(),
 		}, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	bodyStr := string(bodyBytes)
 	
-	// Truncate if needed
-	if int64(len(bodyBytes)) >= cfg.MaxResponseBodyBytes {
+	// Truncate or fail if the body exceeds the limit
+	if int64(len(bodyBytes)) > cfg.MaxResponseBodyBytes {
+		if providerConfig.OnBodyOverflow == bodyOverflowFail {
+			return &ResponseResult{
+				StatusCode:   int64(httpResp.StatusCode),
+				AttemptCount: 1,
+				Error:        errResponseBodyTooLarge.Error(),
+			}, fmt.Errorf("%w: more than %d bytes (raise max_response_body_bytes or set on_body_overflow = \"truncate\")", errResponseBodyTooLarge, cfg.MaxResponseBodyBytes)
+		}
+		tflog.Warn(ctx, "Response body exceeds max_response_body_bytes, truncating", map[string]interface{}{
+			"max_response_body_bytes": cfg.MaxResponseBodyBytes,
+		})
 		bodyStr = utils.TruncateString(bodyStr, int(cfg.MaxResponseBodyBytes))
 	}
 
@@ -75,7 +128,14 @@ func ExecuteRequest(ctx context.Context, req *http.Request, providerConfig *Prov
 
 	result := &ResponseResult{
 		StatusCode:   int64(httpResp.StatusCode),
+		StatusText:   responseStatusText(httpResp),
+		Protocol:     httpResp.Proto,
+		RemoteAddr:   remoteAddr,
 		Headers:      headers,
+		Cookies:      httpResp.Cookies(),
+		TLS:          httpResp.TLS,
+		EffectiveURL: httpResp.Request.URL.String(),
+		RedirectChain: redirects,
 		Body:         bodyStr,
 		AttemptCount: 1,
 	}
@@ -88,24 +148,173 @@ func ExecuteRequest(ctx context.Context, req *http.Request, providerConfig *Prov
 	return result, nil
 }
 
+// Expectation severities for expect.severity
+const (
+	expectSeverityError   = "error"
+	expectSeverityWarning = "warning"
+)
+
+// expectationIsWarning reports whether failed expectations should only produce a warning
+func expectationIsWarning(expect *ExpectModel) bool {
+	if expect == nil || expect.Severity.IsNull() || expect.Severity.IsUnknown() {
+		return false
+	}
+	return strings.EqualFold(expect.Severity.ValueString(), expectSeverityWarning)
+}
+
 // ValidateExpectations validates response expectations
 func ValidateExpectations(ctx context.Context, result *ResponseResult, expect *ExpectModel) error {
 	if expect == nil {
 		return nil
 	}
 
+	if errors := expectationFailures(ctx, result, expect); len(errors) > 0 {
+		return expectationError(ctx, result, expect, errors)
+	}
+
+	return nil
+}
+
+// expectationFailures returns a description of each expectation the response doesn't meet
+func expectationFailures(ctx context.Context, result *ResponseResult, expect *ExpectModel) []string {
+	var errors []string
+
+	if !expect.Severity.IsNull() && !expect.Severity.IsUnknown() {
+		switch strings.ToLower(expect.Severity.ValueString()) {
+		case "", expectSeverityError, expectSeverityWarning:
+		default:
+			errors = append(errors, fmt.Sprintf("invalid severity '%s', expected 'error' or 'warning'", expect.Severity.ValueString()))
+		}
+	}
+
+	switch match := matchValue(expect.Match); match {
+	case "", matchAll:
+		errors = append(errors, expectConditionFailures(ctx, result, expect)...)
+		for i, group := range expect.Conditions {
+			errors = append(errors, conditionGroupMessages(i, expectationFailures(ctx, result, group.toExpectModel()))...)
+		}
+	case matchAny:
+		errors = append(errors, anyExpectationFailures(ctx, result, expect)...)
+	default:
+		errors = append(errors, invalidMatchMessage(match))
+	}
+
+	return errors
+}
+
+// anyExpectationFailures returns no failures when at least one expectation or condition group
+// is met, and the failures of all of them otherwise
+func anyExpectationFailures(ctx context.Context, result *ResponseResult, expect *ExpectModel) []string {
+	conditions := splitExpectConditions(expect)
+	if len(conditions) == 0 && len(expect.Conditions) == 0 {
+		return nil
+	}
+
 	var errors []string
+	for _, condition := range conditions {
+		failures := expectConditionFailures(ctx, result, condition)
+		if len(failures) == 0 {
+			return nil
+		}
+		errors = append(errors, failures...)
+	}
+	for i, group := range expect.Conditions {
+		failures := expectationFailures(ctx, result, group.toExpectModel())
+		if len(failures) == 0 {
+			return nil
+		}
+		errors = append(errors, conditionGroupMessages(i, failures)...)
+	}
+	return errors
+}
+
+// splitExpectConditions returns one expect block per configured expectation, for evaluating
+// them separately
+func splitExpectConditions(expect *ExpectModel) []*ExpectModel {
+	set := func(value attr.Value) bool {
+		return !value.IsNull() && !value.IsUnknown()
+	}
+
+	var conditions []*ExpectModel
+	if set(expect.StatusCodes) || set(expect.StatusClasses) {
+		conditions = append(conditions, &ExpectModel{StatusCodes: expect.StatusCodes, StatusClasses: expect.StatusClasses})
+	}
+	if set(expect.HeaderPresent) {
+		conditions = append(conditions, &ExpectModel{HeaderPresent: expect.HeaderPresent})
+	}
+	if set(expect.Jq) && expect.Jq.ValueString() != "" {
+		conditions = append(conditions, &ExpectModel{Jq: expect.Jq})
+	}
+	if set(expect.ContentLength) {
+		conditions = append(conditions, &ExpectModel{ContentLength: expect.ContentLength})
+	}
+	if set(expect.BodySha256) && expect.BodySha256.ValueString() != "" {
+		conditions = append(conditions, &ExpectModel{BodySha256: expect.BodySha256})
+	}
+	if set(expect.ContentType) && expect.ContentType.ValueString() != "" {
+		conditions = append(conditions, &ExpectModel{ContentType: expect.ContentType})
+	}
+	if set(expect.TlsCertMinDaysValid) {
+		conditions = append(conditions, &ExpectModel{TlsCertMinDaysValid: expect.TlsCertMinDaysValid})
+	}
+	return conditions
+}
 
-	// Validate status codes
-	if !expect.StatusCodes.IsNull() && !expect.StatusCodes.IsUnknown() {
-		expectedCodes, err := ConvertTerraformList(ctx, expect.StatusCodes, func(v interface{}) (int64, error) {
-			if intVal, ok := v.(types.Int64); ok {
-				return intVal.ValueInt64(), nil
+// toExpectModel converts a condition group to an expect block
+func (c ExpectConditionModel) toExpectModel() *ExpectModel {
+	return &ExpectModel{
+		StatusCodes:   c.StatusCodes,
+		StatusClasses: c.StatusClasses,
+		HeaderPresent: c.HeaderPresent,
+		Jq:            c.Jq,
+		ContentType:   c.ContentType,
+		Match:         c.Match,
+	}
+}
+
+// expectConditionFailures describes each of the expect block's own expectations the response
+// doesn't meet, ignoring match and condition groups
+func expectConditionFailures(ctx context.Context, result *ResponseResult, expect *ExpectModel) []string {
+	var errors []string
+
+	// Validate status codes and classes (a match in either passes)
+	hasCodes := !expect.StatusCodes.IsNull() && !expect.StatusCodes.IsUnknown()
+	hasClasses := !expect.StatusClasses.IsNull() && !expect.StatusClasses.IsUnknown()
+	if hasCodes || hasClasses {
+		var expectedCodes []int64
+		var expectedRanges []statusRange
+		valid := true
+
+		if hasCodes {
+			codes, err := ConvertTerraformList(ctx, expect.StatusCodes, func(v interface{}) (int64, error) {
+				if intVal, ok := v.(types.Int64); ok {
+					return intVal.ValueInt64(), nil
+				}
+				return 0, fmt.Errorf("expected int64, got %T", v)
+			})
+			if err == nil {
+				expectedCodes = codes
 			}
-			return 0, fmt.Errorf("expected int64, got %T", v)
-		})
-		if err == nil {
-			found := false
+		}
+		if hasClasses {
+			classes, err := ConvertTerraformList(ctx, expect.StatusClasses, func(v interface{}) (string, error) {
+				if strVal, ok := v.(types.String); ok {
+					return strVal.ValueString(), nil
+				}
+				return "", fmt.Errorf("expected string, got %T", v)
+			})
+			if err == nil {
+				ranges, err := parseStatusClasses(classes)
+				if err != nil {
+					errors = append(errors, err.Error())
+					valid = false
+				}
+				expectedRanges = ranges
+			}
+		}
+
+		if valid && (len(expectedCodes) > 0 || len(expectedRanges) > 0) {
+			found := statusInRanges(result.StatusCode, expectedRanges)
 			for _, code := range expectedCodes {
 				if code == result.StatusCode {
 					found = true
@@ -113,7 +322,11 @@ func ValidateExpectations(ctx context.Context, result *ResponseResult, expect *E
 				}
 			}
 			if !found {
-				errors = append(errors, fmt.Sprintf("status code %d not in expected codes %v", result.StatusCode, expectedCodes))
+				if len(expectedRanges) > 0 {
+					errors = append(errors, fmt.Sprintf("status code %d not in expected codes %v or classes %s", result.StatusCode, expectedCodes, statusRangesString(expectedRanges)))
+				} else {
+					errors = append(errors, fmt.Sprintf("status code %d not in expected codes %v", result.StatusCode, expectedCodes))
+				}
 			}
 		}
 	}
@@ -144,10 +357,100 @@ func ValidateExpectations(ctx context.Context, result *ResponseResult, expect *E
 
 	// TODO: Implement json_path_exists and json_path_equals in Phase 4/5
 
-	if len(errors) > 0 {
-		return fmt.Errorf("expectation validation failed: %s", strings.Join(errors, "; "))
+	// Validate jq condition
+	if !expect.Jq.IsNull() && !expect.Jq.IsUnknown() && expect.Jq.ValueString() != "" {
+		holds, err := evaluateJqCondition(ctx, result.Body, expect.Jq.ValueString())
+		if err != nil {
+			errors = append(errors, err.Error())
+		} else if !holds {
+			errors = append(errors, fmt.Sprintf("jq condition not satisfied: %s", expect.Jq.ValueString()))
+		}
 	}
 
-	return nil
+	// Validate body size and checksum
+	if !expect.ContentLength.IsNull() && !expect.ContentLength.IsUnknown() {
+		if actual := int64(len(result.Body)); actual != expect.ContentLength.ValueInt64() {
+			errors = append(errors, fmt.Sprintf("body length %d does not match expected content_length %d", actual, expect.ContentLength.ValueInt64()))
+		}
+	}
+	if !expect.BodySha256.IsNull() && !expect.BodySha256.IsUnknown() && expect.BodySha256.ValueString() != "" {
+		sum := sha256.Sum256([]byte(result.Body))
+		actual := hex.EncodeToString(sum[:])
+		if !strings.EqualFold(actual, strings.TrimSpace(expect.BodySha256.ValueString())) {
+			errors = append(errors, fmt.Sprintf("body sha256 %s does not match expected %s", actual, expect.BodySha256.ValueString()))
+		}
+	}
+
+	// Validate content type
+	if !expect.ContentType.IsNull() && !expect.ContentType.IsUnknown() && expect.ContentType.ValueString() != "" {
+		contentType := ""
+		for k, v := range result.Headers {
+			if strings.EqualFold(k, "Content-Type") {
+				contentType = v
+				break
+			}
+		}
+		if !matchContentType(expect.ContentType.ValueString(), contentType) {
+			errors = append(errors, fmt.Sprintf("content type '%s' does not match expected '%s'", contentType, expect.ContentType.ValueString()))
+		}
+	}
+
+	// Validate TLS certificate expiry
+	if !expect.TlsCertMinDaysValid.IsNull() && !expect.TlsCertMinDaysValid.IsUnknown() {
+		if err := checkCertificateValidity(result.TLS, expect.TlsCertMinDaysValid.ValueInt64(), time.Now()); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+
+	return errors
 }
 
+// expectationError reports failed expectations, using expect.error_message when it is set.
+// The message is interpolated with the response and the unsatisfied conditions; if it can't be
+// rendered the generic message is used.
+func expectationError(ctx context.Context, result *ResponseResult, expect *ExpectModel, failures []string) error {
+	generic := fmt.Errorf("expectation validation failed: %s", strings.Join(failures, "; "))
+	if expect.ErrorMessage.IsNull() || expect.ErrorMessage.IsUnknown() || expect.ErrorMessage.ValueString() == "" {
+		return generic
+	}
+
+	message, err := InterpolateString(ctx, expect.ErrorMessage.ValueString(), &InterpolationContext{
+		ResponseBody:          result.Body,
+		StatusCode:            result.StatusCode,
+		ResponseHeaders:       result.Headers,
+		UnsatisfiedConditions: failures,
+	})
+	if err != nil {
+		return fmt.Errorf("%w (error_message could not be rendered: %v)", generic, err)
+	}
+	return fmt.Errorf("%s", message)
+}
+
+
+// matchContentType reports whether a Content-Type header value matches a media type pattern.
+// Parameters such as charset are ignored and '*' matches any run of characters within
+// the type or subtype, so "application/*json*" matches "application/vnd.api+json; charset=utf-8".
+func matchContentType(pattern string, contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	if mediaType == "" {
+		return false
+	}
+	pattern = strings.ToLower(strings.TrimSpace(strings.SplitN(pattern, ";", 2)[0]))
+	matched, err := path.Match(pattern, mediaType)
+	return err == nil && matched
+}
+
+// checkCertificateValidity verifies that the server's leaf certificate remains valid for at least minDays
+func checkCertificateValidity(state *tls.ConnectionState, minDays int64, now time.Time) error {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("tls_cert_min_days_valid: no TLS certificate was presented (is the URL https?)")
+	}
+
+	cert := state.PeerCertificates[0]
+	if cert.NotAfter.Before(now.AddDate(0, 0, int(minDays))) {
+		remaining := cert.NotAfter.Sub(now)
+		return fmt.Errorf("TLS certificate for %s expires %s (%d days remaining, expected at least %d)",
+			cert.Subject.CommonName, cert.NotAfter.UTC().Format(time.RFC3339), int64(remaining.Hours()/24), minDays)
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteRequest_BodyOverflow(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"0123456789"}`)) // 19 bytes
+	}))
+	defer server.Close()
+
+	newRequest := func() *http.Request {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		return req
+	}
+
+	t.Run("body at the limit is kept intact", func(t *testing.T) {
+		result, err := ExecuteRequest(context.Background(), newRequest(), &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 19, OnBodyOverflow: bodyOverflowFail})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":"0123456789"}`, result.Body)
+	})
+
+	t.Run("truncate by default", func(t *testing.T) {
+		result, err := ExecuteRequest(context.Background(), newRequest(), &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 8})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":"0... [TRUNCATED]`, result.Body)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		result, err := ExecuteRequest(context.Background(), newRequest(), &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 8, OnBodyOverflow: bodyOverflowFail})
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, errResponseBodyTooLarge))
+		assert.Equal(t, int64(http.StatusOK), result.StatusCode)
+		assert.Empty(t, result.Body)
+	})
+
+	t.Run("fail is not retried", func(t *testing.T) {
+		requests = 0
+		retryConfig := &RetryConfig{Attempts: 3, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed"}
+		_, err := ExecuteRequestWithRetry(context.Background(), newRequest(), &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 8, OnBodyOverflow: bodyOverflowFail}, retryConfig, nil, nil)
+		assert.True(t, errors.Is(err, errResponseBodyTooLarge))
+		assert.Equal(t, 1, requests)
+	})
+}
+
+func TestExecuteRequest_RedactsQueryParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	providerConfig := &ProviderConfig{TimeoutMs: 1000, MaxResponseBodyBytes: 1024, RedactQueryParams: []string{"api_key"}}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, serverURL+"/v1?api_key=secret&page=2", nil)
+	assert.NoError(t, err)
+
+	result, err := ExecuteRequest(context.Background(), req, providerConfig)
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+	assert.Contains(t, err.Error(), "api_key=[REDACTED]&page=2")
+	assert.NotContains(t, result.Error, "secret")
+
+	var urlErr *url.Error
+	assert.True(t, errors.As(err, &urlErr), "the original error must stay reachable")
+}
+
+func TestProviderConfig_WithResponseBodyLimit(t *testing.T) {
+	base := &ProviderConfig{MaxResponseBodyBytes: 1048576}
+
+	cfg, err := base.WithResponseBodyLimit(types.Int64Null(), types.StringNull())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1048576), cfg.MaxResponseBodyBytes)
+	assert.Equal(t, "", cfg.OnBodyOverflow)
+
+	cfg, err = base.WithResponseBodyLimit(types.Int64Value(10485760), types.StringValue("fail"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10485760), cfg.MaxResponseBodyBytes)
+	assert.Equal(t, bodyOverflowFail, cfg.OnBodyOverflow)
+	assert.Equal(t, int64(1048576), base.MaxResponseBodyBytes, "provider config must not be modified")
+
+	_, err = base.WithResponseBodyLimit(types.Int64Value(0), types.StringNull())
+	assert.Error(t, err)
+
+	_, err = base.WithResponseBodyLimit(types.Int64Null(), types.StringValue("drop"))
+	assert.Error(t, err)
+}
+
+func TestProviderConfig_WithRedactHeaders(t *testing.T) {
+	ctx := context.Background()
+	base := &ProviderConfig{RedactHeaders: []string{"X-Api-Key"}}
+
+	cfg, err := base.WithRedactHeaders(ctx, types.ListNull(types.StringType))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"X-Api-Key"}, cfg.RedactHeaders)
+
+	cfg, err = base.WithRedactHeaders(ctx, types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("X-Internal-Token"),
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"X-Api-Key", "X-Internal-Token"}, cfg.RedactHeaders)
+	assert.Equal(t, []string{"X-Api-Key"}, base.RedactHeaders, "provider config must not be modified")
+}
+
+func TestValidateExpectations_BodyChecksum(t *testing.T) {
+	ctx := context.Background()
+	result := &ResponseResult{StatusCode: 200, Body: "hello"}
+	const helloSha256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	assert.NoError(t, ValidateExpectations(ctx, result, &ExpectModel{
+		BodySha256:    types.StringValue(helloSha256),
+		ContentLength: types.Int64Value(5),
+	}))
+	assert.NoError(t, ValidateExpectations(ctx, result, &ExpectModel{
+		BodySha256: types.StringValue("2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824"),
+	}), "checksum comparison is case-insensitive")
+
+	err := ValidateExpectations(ctx, result, &ExpectModel{
+		BodySha256: types.StringValue("0000000000000000000000000000000000000000000000000000000000000000"),
+	})
+	assert.ErrorContains(t, err, "body sha256 "+helloSha256+" does not match")
+
+	err = ValidateExpectations(ctx, result, &ExpectModel{ContentLength: types.Int64Value(6)})
+	assert.ErrorContains(t, err, "body length 5 does not match expected content_length 6")
+}
+
+func TestMatchContentType(t *testing.T) {
+	tests := []struct {
+		pattern     string
+		contentType string
+		want        bool
+	}{
+		{"application/json", "application/json", true},
+		{"application/json", "application/json; charset=utf-8", true},
+		{"application/json", "Application/JSON", true},
+		{"application/*json*", "application/vnd.api+json", true},
+		{"application/*json*", "application/json-patch+json; charset=utf-8", true},
+		{"application/*", "application/xml", true},
+		{"*/*", "text/html", true},
+		{"application/json", "text/html", false},
+		{"application/*json*", "text/json", false},
+		{"application/json", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.contentType, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchContentType(tt.pattern, tt.contentType))
+		})
+	}
+}
+
+func TestValidateExpectations_ContentType(t *testing.T) {
+	ctx := context.Background()
+	result := &ResponseResult{
+		StatusCode: 200,
+		Headers:    map[string]string{"Content-Type": "application/problem+json; charset=utf-8"},
+	}
+
+	assert.NoError(t, ValidateExpectations(ctx, result, &ExpectModel{ContentType: types.StringValue("application/*json*")}))
+	assert.ErrorContains(t, ValidateExpectations(ctx, result, &ExpectModel{ContentType: types.StringValue("application/xml")}),
+		"content type 'application/problem+json; charset=utf-8' does not match expected 'application/xml'")
+	assert.Error(t, ValidateExpectations(ctx, &ResponseResult{StatusCode: 204}, &ExpectModel{ContentType: types.StringValue("*/*")}))
+}
+
+func TestCheckCertificateValidity(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{
+			{Subject: pkix.Name{CommonName: "example.com"}, NotAfter: now.Add(10 * 24 * time.Hour)},
+		},
+	}
+
+	assert.NoError(t, checkCertificateValidity(state, 7, now))
+	assert.ErrorContains(t, checkCertificateValidity(state, 30, now), "TLS certificate for example.com expires 2026-01-11T00:00:00Z (10 days remaining, expected at least 30)")
+	assert.ErrorContains(t, checkCertificateValidity(nil, 30, now), "no TLS certificate")
+}
+
+func TestValidateExpectations_TLSCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	result, err := ExecuteRequest(context.Background(), req, &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576, InsecureSkipVerify: true})
+	assert.NoError(t, err)
+	if assert.NotNil(t, result.TLS) {
+		assert.NotEmpty(t, result.TLS.PeerCertificates)
+	}
+
+	// The test server certificate is valid for decades
+	assert.NoError(t, ValidateExpectations(context.Background(), result, &ExpectModel{TlsCertMinDaysValid: types.Int64Value(30)}))
+	assert.Error(t, ValidateExpectations(context.Background(), result, &ExpectModel{TlsCertMinDaysValid: types.Int64Value(365 * 1000)}))
+}
+
+func TestExecuteRequest_RedirectChain(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vanity", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/target", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/target", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/vanity", nil)
+	assert.NoError(t, err)
+
+	result, err := ExecuteRequest(context.Background(), req, &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576})
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/target", effectiveURLValue(result).ValueString())
+
+	chain, diags := RedirectChainValue(context.Background(), result.RedirectChain)
+	assert.False(t, diags.HasError())
+
+	var hops []RedirectHopModel
+	assert.False(t, chain.ElementsAs(context.Background(), &hops, false).HasError())
+	if assert.Len(t, hops, 1) {
+		assert.Equal(t, server.URL+"/vanity", hops[0].Url.ValueString())
+		assert.Equal(t, int64(http.StatusMovedPermanently), hops[0].StatusCode.ValueInt64())
+		assert.Equal(t, server.URL+"/target", hops[0].Location.ValueString())
+	}
+}
+
+func TestExpectationSeverity(t *testing.T) {
+	assert.False(t, expectationIsWarning(nil))
+	assert.False(t, expectationIsWarning(&ExpectModel{}))
+	assert.False(t, expectationIsWarning(&ExpectModel{Severity: types.StringValue("error")}))
+	assert.True(t, expectationIsWarning(&ExpectModel{Severity: types.StringValue("Warning")}))
+
+	err := ValidateExpectations(context.Background(), &ResponseResult{StatusCode: 200}, &ExpectModel{Severity: types.StringValue("warn")})
+	assert.ErrorContains(t, err, "invalid severity 'warn'")
+	assert.NoError(t, ValidateExpectations(context.Background(), &ResponseResult{StatusCode: 200}, &ExpectModel{Severity: types.StringValue("warning")}))
+}
+
+func TestValidateExpectations_ErrorMessage(t *testing.T) {
+	ctx := context.Background()
+	result := &ResponseResult{
+		StatusCode: 429,
+		Body:       `{"error": {"message": "quota exceeded for project demo"}}`,
+		Headers:    map[string]string{"Retry-After": "60"},
+	}
+	codes := types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(200)})
+
+	err := ValidateExpectations(ctx, result, &ExpectModel{
+		StatusCodes:  codes,
+		ErrorMessage: types.StringValue(`${jsonpath(self.response_body, "error.message")} (HTTP ${self.status_code}, retry in ${self.response_headers.retry-after}s): request an increase via the quota portal`),
+	})
+	assert.EqualError(t, err, "quota exceeded for project demo (HTTP 429, retry in 60s): request an increase via the quota portal")
+
+	err = ValidateExpectations(ctx, result, &ExpectModel{
+		StatusCodes:  codes,
+		ErrorMessage: types.StringValue("unexpected response: ${self.unsatisfied_conditions}"),
+	})
+	assert.EqualError(t, err, "unexpected response: status code 429 not in expected codes [200]")
+
+	err = ValidateExpectations(ctx, result, &ExpectModel{
+		StatusCodes:  codes,
+		ErrorMessage: types.StringValue("missing ${self.response_headers.X-Request-Id}"),
+	})
+	assert.ErrorContains(t, err, "expectation validation failed: status code 429 not in expected codes [200]")
+	assert.ErrorContains(t, err, "error_message could not be rendered")
+
+	assert.NoError(t, ValidateExpectations(ctx, &ResponseResult{StatusCode: 200}, &ExpectModel{
+		StatusCodes:  codes,
+		ErrorMessage: types.StringValue("never shown"),
+	}))
+}
+
+func TestValidateExpectations_Match(t *testing.T) {
+	ctx := context.Background()
+	created := &ResponseResult{StatusCode: 201, Body: `{"id": "1"}`}
+	exists := &ResponseResult{StatusCode: 409, Body: `{"error": "already_exists"}`}
+	conflict := &ResponseResult{StatusCode: 409, Body: `{"error": "locked"}`}
+	codes := func(values ...int64) types.List {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.Int64Value(value))
+		}
+		return types.ListValueMust(types.Int64Type, elements)
+	}
+
+	// Succeed on 201, or on a 409 that says the object already exists
+	expect := &ExpectModel{
+		Match:       types.StringValue("any"),
+		StatusCodes: codes(201),
+		Conditions: []ExpectConditionModel{{
+			StatusCodes: codes(409),
+			Jq:          types.StringValue(`.error == "already_exists"`),
+		}},
+	}
+	assert.NoError(t, ValidateExpectations(ctx, created, expect))
+	assert.NoError(t, ValidateExpectations(ctx, exists, expect))
+	err := ValidateExpectations(ctx, conflict, expect)
+	assert.ErrorContains(t, err, "status code 409 not in expected codes [201]")
+	assert.ErrorContains(t, err, `condition[0]: jq condition not satisfied: .error == "already_exists"`)
+
+	// Groups are ANDed with the other expectations by default
+	expect = &ExpectModel{
+		StatusCodes: codes(201, 409),
+		Conditions:  []ExpectConditionModel{{Match: types.StringValue("any"), Jq: types.StringValue(`.id`), HeaderPresent: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Location")})}},
+	}
+	assert.NoError(t, ValidateExpectations(ctx, created, expect))
+	assert.ErrorContains(t, ValidateExpectations(ctx, exists, expect), "condition[0]: jq condition not satisfied: .id")
+
+	assert.ErrorContains(t, ValidateExpectations(ctx, created, &ExpectModel{Match: types.StringValue("some")}), "invalid match 'some'")
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// compileHeaderPatterns compiles ignore_response_headers entries. Each entry is a regular
+// expression matched case-insensitively against the whole header name, so plain names work too.
+func compileHeaderPatterns(ctx context.Context, patterns types.List) ([]*regexp.Regexp, error) {
+	if patterns.IsNull() || patterns.IsUnknown() {
+		return nil, nil
+	}
+
+	entries, err := ConvertTerraformList(ctx, patterns, func(v interface{}) (string, error) {
+		if strVal, ok := v.(types.String); ok {
+			return strVal.ValueString(), nil
+		}
+		return "", fmt.Errorf("expected string, got %T", v)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := make([]*regexp.Regexp, 0, len(entries))
+	for _, entry := range entries {
+		re, err := regexp.Compile("(?i)^(?:" + entry + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid header pattern %q: %w", entry, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// ResponseHeadersValue converts response headers to the response_headers map value,
+// dropping headers that match ignore_response_headers
+func ResponseHeadersValue(ctx context.Context, headers map[string]string, ignore types.List) (types.Map, error) {
+	patterns, err := compileHeaderPatterns(ctx, ignore)
+	if err != nil {
+		return types.MapNull(types.StringType), err
+	}
+
+	values := make(map[string]attr.Value, len(headers))
+	for name, value := range headers {
+		if headerMatchesAny(name, patterns) {
+			continue
+		}
+		values[name] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, values), nil
+}
+
+// headerMatchesAny reports whether a header name matches any of the patterns
+func headerMatchesAny(name string, patterns []*regexp.Regexp) bool {
+	for _, re := range patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// response_header_names values
+const (
+	responseHeaderNamesCanonical = "canonical"
+	responseHeaderNamesLower     = "lower"
+)
+
+// normalizeResponseHeaderNames renames headers for response_headers as response_header_names
+// asks: "canonical" (the default) as in Content-Type, or "lower" as in content-type. Values
+// of headers that end up with the same name are joined.
+func normalizeResponseHeaderNames(headers map[string]string, names types.String) map[string]string {
+	rename := http.CanonicalHeaderKey
+	if strings.EqualFold(names.ValueString(), responseHeaderNamesLower) {
+		rename = strings.ToLower
+	}
+
+	normalized := make(map[string]string, len(headers))
+	for name, value := range headers {
+		name = rename(name)
+		if existing, ok := normalized[name]; ok {
+			value = existing + ", " + value
+		}
+		normalized[name] = value
+	}
+	return normalized
+}
+
+// validateResponseHeaderNames rejects unknown response_header_names values
+func validateResponseHeaderNames(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var names types.String
+	diags := config.GetAttribute(ctx, path.Root("response_header_names"), &names)
+	if diags.HasError() || names.IsNull() || names.IsUnknown() {
+		return diags
+	}
+	switch strings.ToLower(names.ValueString()) {
+	case responseHeaderNamesCanonical, responseHeaderNamesLower:
+	default:
+		diags.AddAttributeError(path.Root("response_header_names"), "Invalid response_header_names",
+			fmt.Sprintf("response_header_names must be %q or %q, got %q", responseHeaderNamesCanonical, responseHeaderNamesLower, names.ValueString()))
+	}
+	return diags
+}
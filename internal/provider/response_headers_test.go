@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseHeadersValue(t *testing.T) {
+	ctx := context.Background()
+	headers := map[string]string{
+		"Content-Type":    "application/json",
+		"Date":            "Mon, 01 Jan 2026 00:00:00 GMT",
+		"X-Request-Id":    "abc123",
+		"Cf-Ray":          "8a1b2c3d",
+		"Cf-Cache-Status": "HIT",
+	}
+
+	ignore := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("date"),
+		types.StringValue("X-Request-ID"),
+		types.StringValue("Cf-.*"),
+	})
+
+	value, err := ResponseHeadersValue(ctx, headers, ignore)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]attr.Value{"Content-Type": types.StringValue("application/json")}, value.Elements())
+
+	// Patterns match the whole name
+	value, err = ResponseHeadersValue(ctx, headers, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Content")}))
+	assert.NoError(t, err)
+	assert.Len(t, value.Elements(), 5)
+
+	// Nothing ignored by default
+	value, err = ResponseHeadersValue(ctx, headers, types.ListNull(types.StringType))
+	assert.NoError(t, err)
+	assert.Len(t, value.Elements(), 5)
+
+	_, err = ResponseHeadersValue(ctx, headers, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("X-(")}))
+	assert.Error(t, err)
+}
+
+func TestNormalizeResponseHeaderNames(t *testing.T) {
+	headers := map[string]string{"Content-Type": "application/json", "x-request-id": "abc"}
+
+	assert.Equal(t, map[string]string{"Content-Type": "application/json", "X-Request-Id": "abc"},
+		normalizeResponseHeaderNames(headers, types.StringNull()))
+	assert.Equal(t, map[string]string{"Content-Type": "application/json", "X-Request-Id": "abc"},
+		normalizeResponseHeaderNames(headers, types.StringValue("canonical")))
+	assert.Equal(t, map[string]string{"content-type": "application/json", "x-request-id": "abc"},
+		normalizeResponseHeaderNames(headers, types.StringValue("lower")))
+
+	// Headers that differ only in case are joined
+	joined := normalizeResponseHeaderNames(map[string]string{"vary": "Accept", "VARY": "Origin"}, types.StringValue("lower"))
+	assert.Len(t, joined, 1)
+	assert.Contains(t, joined["vary"], "Accept")
+	assert.Contains(t, joined["vary"], "Origin")
+}
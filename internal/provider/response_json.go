@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ResponseBodyJsonValue parses a JSON response body into a dynamic value that configurations
+// can index natively. Objects become objects, arrays become tuples and JSON null becomes a
+// null string. Bodies that are empty or not valid JSON produce a null value.
+func ResponseBodyJsonValue(ctx context.Context, body string) types.Dynamic {
+	if body == "" {
+		return types.DynamicNull()
+	}
+
+	data, err := decodeJSON(body)
+	if err != nil {
+		return types.DynamicNull()
+	}
+
+	value, err := jsonToAttrValue(ctx, data)
+	if err != nil {
+		return types.DynamicNull()
+	}
+	return types.DynamicValue(value)
+}
+
+// jsonToAttrValue converts a decoded JSON value to a framework attribute value
+func jsonToAttrValue(ctx context.Context, data interface{}) (attr.Value, error) {
+	switch v := data.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case json.Number:
+		number, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", v.String(), err)
+		}
+		return types.NumberValue(number), nil
+	case []interface{}:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, item := range v {
+			elem, err := jsonToAttrValue(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes = append(elemTypes, elem.Type(ctx))
+			elems = append(elems, elem)
+		}
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to build tuple: %v", diags)
+		}
+		return tuple, nil
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for key, item := range v {
+			elem, err := jsonToAttrValue(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[key] = elem.Type(ctx)
+			attrs[key] = elem
+		}
+		object, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to build object: %v", diags)
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value of type %T", data)
+	}
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseBodyJsonValue(t *testing.T) {
+	ctx := context.Background()
+
+	value := ResponseBodyJsonValue(ctx, `{"items": [{"id": "a", "count": 12345678901234567890}, true, null], "name": "test"}`)
+	if !assert.False(t, value.IsNull()) {
+		return
+	}
+
+	object, ok := value.UnderlyingValue().(types.Object)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, types.StringValue("test"), object.Attributes()["name"])
+
+	items, ok := object.Attributes()["items"].(types.Tuple)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Len(t, items.Elements(), 3)
+	assert.Equal(t, types.BoolValue(true), items.Elements()[1])
+	assert.True(t, items.Elements()[2].IsNull())
+
+	first := items.Elements()[0].(types.Object)
+	assert.Equal(t, types.StringValue("a"), first.Attributes()["id"])
+	count := first.Attributes()["count"].(types.Number).ValueBigFloat()
+	expected, _, _ := big.ParseFloat("12345678901234567890", 10, 512, big.ToNearestEven)
+	assert.Equal(t, 0, count.Cmp(expected), "large numbers keep full precision")
+
+	assert.True(t, ResponseBodyJsonValue(ctx, "").IsNull())
+	assert.True(t, ResponseBodyJsonValue(ctx, "not json").IsNull())
+	assert.True(t, ResponseBodyJsonValue(ctx, `{"a": 1} trailing`).IsNull())
+
+	scalar := ResponseBodyJsonValue(ctx, `"plain"`)
+	assert.Equal(t, types.StringValue("plain"), scalar.UnderlyingValue())
+}
+
+func TestResponseBodyJsonValue_StateRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	bodyJson := ResponseBodyJsonValue(ctx, `{"items": [{"id": "a"}, {"id": "b", "tags": ["x"]}]}`)
+	diags := state.SetAttribute(ctx, path.Root("response_body_json"), bodyJson)
+	assert.False(t, diags.HasError(), "%v", diags)
+
+	var got types.Dynamic
+	diags = state.GetAttribute(ctx, path.Root("response_body_json"), &got)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.True(t, bodyJson.Equal(got))
+}
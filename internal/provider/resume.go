@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// byteRangePattern matches a single "bytes=start-[end]" range, the only form that can be resumed
+var byteRangePattern = regexp.MustCompile(`^bytes=(\d+)-(\d*)$`)
+
+// contentRangeStartPattern extracts the first byte position from a Content-Range header
+var contentRangeStartPattern = regexp.MustCompile(`^bytes (\d+)-\d+/(?:\d+|\*)$`)
+
+// validateRange checks the range attribute, which is sent as the Range header
+func validateRange(value string) error {
+	if value != "" && !strings.HasPrefix(value, "bytes=") {
+		return fmt.Errorf("range must be a byte range such as \"bytes=0-1023\", got %q", value)
+	}
+	return nil
+}
+
+// resumeState carries the part of a response body received by an interrupted attempt, so the
+// next attempt only asks for the remaining bytes
+type resumeState struct {
+	baseRange string
+	captured  bool
+
+	partial   []byte
+	status    int64
+	validator string
+}
+
+// WithResume returns a copy of the provider config that resumes interrupted downloads when
+// resume is true. Each execution gets its own state.
+func (p *ProviderConfig) WithResume(enabled types.Bool) *ProviderConfig {
+	cfg := *p
+	cfg.Resume = nil
+	if enabled.ValueBool() {
+		cfg.Resume = &resumeState{}
+	}
+	return &cfg
+}
+
+// applyResume sets the Range header for the next attempt: the configured range when nothing has
+// been received yet, otherwise the bytes after the saved partial body. If-Range makes the server
+// send the full body instead when it changed since the interrupted attempt.
+func (s *resumeState) applyResume(req *http.Request) {
+	if s == nil {
+		return
+	}
+	if !s.captured {
+		s.baseRange, s.captured = req.Header.Get("Range"), true
+	}
+
+	req.Header.Del("If-Range")
+	if len(s.partial) == 0 {
+		if s.baseRange == "" {
+			req.Header.Del("Range")
+		} else {
+			req.Header.Set("Range", s.baseRange)
+		}
+		return
+	}
+
+	start, end := int64(0), ""
+	if m := byteRangePattern.FindStringSubmatch(s.baseRange); m != nil {
+		start, _ = strconv.ParseInt(m[1], 10, 64)
+		end = m[2]
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%s", start+int64(len(s.partial)), end))
+	if s.validator != "" {
+		req.Header.Set("If-Range", s.validator)
+	}
+}
+
+// save keeps the body received before an attempt was interrupted. Only complete or partial
+// content responses to a resumable range are kept.
+func (s *resumeState) save(resp *http.Response, body []byte) {
+	if s == nil || len(body) == 0 {
+		return
+	}
+	if s.baseRange != "" && !byteRangePattern.MatchString(s.baseRange) {
+		return
+	}
+
+	if len(s.partial) == 0 {
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+			return
+		}
+		s.status = int64(resp.StatusCode)
+		s.validator = resp.Header.Get("ETag")
+		if s.validator == "" || strings.HasPrefix(s.validator, "W/") {
+			// Weak validators can't be used with If-Range
+			s.validator = resp.Header.Get("Last-Modified")
+		}
+		s.partial = append([]byte{}, body...)
+		return
+	}
+	if resp.StatusCode == http.StatusPartialContent && s.continues(resp.Header.Get("Content-Range")) {
+		s.partial = append(s.partial, body...)
+	}
+}
+
+// continues reports whether a Content-Range starts right after the saved partial body
+func (s *resumeState) continues(contentRange string) bool {
+	m := contentRangeStartPattern.FindStringSubmatch(contentRange)
+	if m == nil {
+		return false
+	}
+	start, _ := strconv.ParseInt(m[1], 10, 64)
+	offset := int64(0)
+	if base := byteRangePattern.FindStringSubmatch(s.baseRange); base != nil {
+		offset, _ = strconv.ParseInt(base[1], 10, 64)
+	}
+	return start == offset+int64(len(s.partial))
+}
+
+// complete joins the saved partial body with a successful resumed response. A 200 response means
+// the server ignored the range or the content changed, so its body replaces the partial one.
+func (s *resumeState) complete(result *ResponseResult, config *ProviderConfig) (*ResponseResult, error) {
+	if s == nil || len(s.partial) == 0 || result == nil {
+		return result, nil
+	}
+
+	switch result.StatusCode {
+	case http.StatusOK:
+		s.partial = nil
+		return result, nil
+	case http.StatusPartialContent:
+	default:
+		// Keep the partial body for the next attempt
+		return result, nil
+	}
+
+	continues := s.continues(result.Headers["Content-Range"])
+	partial := s.partial
+	s.partial = nil
+	if !continues {
+		return result, fmt.Errorf("failed to resume download: Content-Range %q does not continue at byte %d",
+			result.Headers["Content-Range"], len(partial))
+	}
+
+	body := string(partial) + result.Body
+	if int64(len(body)) > config.MaxResponseBodyBytes {
+		if config.OnBodyOverflow == bodyOverflowFail {
+			return &ResponseResult{
+				StatusCode:   result.StatusCode,
+				AttemptCount: result.AttemptCount,
+				Error:        errResponseBodyTooLarge.Error(),
+			}, fmt.Errorf("%w: more than %d bytes (raise max_response_body_bytes or set on_body_overflow = \"truncate\")", errResponseBodyTooLarge, config.MaxResponseBodyBytes)
+		}
+		body = utils.TruncateString(body, int(config.MaxResponseBodyBytes))
+	}
+
+	resumed := *result
+	resumed.Body = body
+	resumed.StatusCode = s.status
+	return &resumed, nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newInterruptingServer serves content, cutting the connection after cutAfter bytes of every
+// response that starts before cutAt
+func newInterruptingServer(t *testing.T, content string, cutAfter int, cutAt int, ranges *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*ranges = append(*ranges, r.Header.Get("Range")+"|"+r.Header.Get("If-Range"))
+
+		start, status := 0, http.StatusOK
+		if value := r.Header.Get("Range"); value != "" {
+			m := byteRangePattern.FindStringSubmatch(value)
+			if m == nil {
+				t.Fatalf("unexpected Range %q", value)
+			}
+			start, _ = strconv.Atoi(m[1])
+			status = http.StatusPartialContent
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)-start))
+		w.WriteHeader(status)
+
+		remaining := content[start:]
+		if start < cutAt && len(remaining) > cutAfter {
+			_, _ = w.Write([]byte(remaining[:cutAfter]))
+			w.(http.Flusher).Flush()
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			_ = conn.Close()
+			return
+		}
+		_, _ = w.Write([]byte(remaining))
+	}))
+}
+
+func TestResumeDownload(t *testing.T) {
+	content := "0123456789abcdefghijklmnopqrstuvwxyz"
+	retryConfig := &RetryConfig{Attempts: 5, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed"}
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}
+
+	t.Run("resumes after interruptions", func(t *testing.T) {
+		var ranges []string
+		server := newInterruptingServer(t, content, 10, 20, &ranges)
+		defer server.Close()
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		result, err := ExecuteRequestWithRetry(context.Background(), req, providerConfig.WithResume(types.BoolValue(true)), retryConfig, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(200), result.StatusCode)
+		assert.Equal(t, content, result.Body)
+		assert.Equal(t, []string{"|", `bytes=10-|"v1"`, `bytes=20-|"v1"`}, ranges)
+	})
+
+	t.Run("without resume every attempt restarts", func(t *testing.T) {
+		var ranges []string
+		server := newInterruptingServer(t, content, 10, 20, &ranges)
+		defer server.Close()
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		_, err = ExecuteRequestWithRetry(context.Background(), req, providerConfig, &RetryConfig{Attempts: 2, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed"}, nil, nil)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"|", "|"}, ranges)
+	})
+
+	t.Run("resumes within a configured range", func(t *testing.T) {
+		var ranges []string
+		server := newInterruptingServer(t, content, 10, 5, &ranges)
+		defer server.Close()
+
+		httpReq, err := BuildRequest(context.Background(), &RequestConfig{Url: server.URL, Range: "bytes=4-"})
+		assert.NoError(t, err)
+		result, err := ExecuteRequestWithRetry(context.Background(), httpReq, providerConfig.WithResume(types.BoolValue(true)), retryConfig, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(206), result.StatusCode)
+		assert.Equal(t, content[4:], result.Body)
+		assert.Equal(t, []string{"bytes=4-|", `bytes=14-|"v1"`}, ranges)
+	})
+}
+
+func TestValidateRange(t *testing.T) {
+	assert.NoError(t, validateRange("bytes=0-1023"))
+	assert.NoError(t, validateRange("bytes=-500"))
+	assert.Error(t, validateRange("0-1023"))
+
+	_, err := BuildRequest(context.Background(), &RequestConfig{Url: "https://example.com", Range: "items=0-9"})
+	assert.Error(t, err)
+}
@@ -2,12 +2,18 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,15 +27,58 @@ type RetryConfig struct {
 	MaxDelayMs          int64
 	Backoff             string
 	Jitter              bool
+	JitterMode          string
 	RetryOnStatusCodes  []int64
+	RetryOnStatusRanges []statusRange
 	RespectRetryAfter   bool
+	RetryOnErrors       []string
+	SafeMethodsOnly     bool
+	StatusDelayOverrides map[int64]int64
+
+	// lastDelayMs tracks the previous delay for decorrelated backoff
+	lastDelayMs int64
 }
 
+// Jitter modes for jitter_mode
+const (
+	jitterModeNone       = "none"
+	jitterModeFull       = "full"
+	jitterModeEqual      = "equal"
+	jitterModePercentage = "percentage"
+)
+
+// Transport error classes for retry_on_errors
+const (
+	errorClassAny     = "any"
+	errorClassDNS     = "dns"
+	errorClassConnect = "connect"
+	errorClassTimeout = "timeout"
+	errorClassTLS     = "tls"
+	errorClassReset   = "reset"
+	errorClassOther   = "other"
+)
+
 // ShouldRetry determines if a request should be retried based on error or status code
 func (rc *RetryConfig) ShouldRetry(err error, statusCode int64) bool {
-	// Always retry on transport errors
+	// Retry on transport errors matching the configured classes (all errors by default)
 	if err != nil {
-		return true
+		if errors.Is(err, errResponseBodyTooLarge) {
+			// The body won't get smaller on the next attempt
+			return false
+		}
+		if errors.Is(err, errCommandVeto) {
+			return false
+		}
+		if rc.RetryOnErrors == nil {
+			return true
+		}
+		class := classifyTransportError(err)
+		for _, allowed := range rc.RetryOnErrors {
+			if allowed == errorClassAny || strings.EqualFold(allowed, class) {
+				return true
+			}
+		}
+		return false
 	}
 
 	// Retry on configured status codes
@@ -38,6 +87,9 @@ func (rc *RetryConfig) ShouldRetry(err error, statusCode int64) bool {
 			return true
 		}
 	}
+	if statusInRanges(statusCode, rc.RetryOnStatusRanges) {
+		return true
+	}
 
 	return false
 }
@@ -55,6 +107,26 @@ func (rc *RetryConfig) CalculateDelay(attempt int64, retryAfter string) time.Dur
 
 	// Calculate base delay based on backoff strategy
 	switch rc.Backoff {
+	case "decorrelated":
+		// Decorrelated: random between min_delay and 3x the previous delay
+		// (already randomized, so jitter_mode is not applied)
+		prevMs := rc.lastDelayMs
+		if attempt <= 1 || prevMs < rc.MinDelayMs {
+			prevMs = rc.MinDelayMs
+		}
+		upperMs := prevMs * 3
+		if upperMs > rc.MaxDelayMs {
+			upperMs = rc.MaxDelayMs
+		}
+		delayMs = rc.MinDelayMs
+		if upperMs > rc.MinDelayMs {
+			delayMs += rand.Int63n(upperMs - rc.MinDelayMs + 1) //nolint:gosec // Non-cryptographic use for jitter
+		}
+		if delayMs > rc.MaxDelayMs {
+			delayMs = rc.MaxDelayMs
+		}
+		rc.lastDelayMs = delayMs
+		return time.Duration(delayMs) * time.Millisecond
 	case "exponential":
 		// Exponential: min_delay * 2^(attempt-1)
 		delayMs = rc.MinDelayMs * int64(math.Pow(2, float64(attempt-1)))
@@ -73,15 +145,124 @@ func (rc *RetryConfig) CalculateDelay(attempt int64, retryAfter string) time.Dur
 		delayMs = rc.MaxDelayMs
 	}
 
-	delay := time.Duration(delayMs) * time.Millisecond
+	return time.Duration(rc.applyJitter(delayMs)) * time.Millisecond
+}
+
+// applyJitter randomizes a delay according to the configured jitter mode
+func (rc *RetryConfig) applyJitter(delayMs int64) int64 {
+	mode := rc.JitterMode
+	if mode == "" {
+		// Fall back to the jitter flag
+		mode = jitterModeNone
+		if rc.Jitter {
+			mode = jitterModePercentage
+		}
+	}
+
+	if delayMs <= 0 {
+		return delayMs
+	}
+
+	switch mode {
+	case jitterModeFull:
+		// Random between 0 and delay
+		return rand.Int63n(delayMs + 1) //nolint:gosec // Non-cryptographic use for jitter
+	case jitterModeEqual:
+		// Half the delay plus a random half
+		half := delayMs / 2
+		return half + rand.Int63n(delayMs-half+1) //nolint:gosec // Non-cryptographic use for jitter
+	case jitterModePercentage:
+		// Add random 0-25% of delay
+		return delayMs + int64(float64(delayMs)*0.25*rand.Float64()) //nolint:gosec // Non-cryptographic use for jitter
+	default:
+		return delayMs
+	}
+}
+
+// DelayForStatus calculates the delay before retrying a response with the given status code.
+// Retry-After (when respected) wins, then status_delay_overrides, then the backoff curve.
+func (rc *RetryConfig) DelayForStatus(attempt int64, statusCode int64, retryAfter string) time.Duration {
+	if rc.RespectRetryAfter && retryAfter != "" {
+		if delay, err := parseRetryAfter(retryAfter); err == nil {
+			return delay
+		}
+	}
+
+	if delay, ok := rc.statusDelayOverride(statusCode); ok {
+		return delay
+	}
+
+	return rc.CalculateDelay(attempt, retryAfter)
+}
+
+// statusDelayOverride returns the configured delay override for a status code
+func (rc *RetryConfig) statusDelayOverride(statusCode int64) (time.Duration, bool) {
+	delayMs, ok := rc.StatusDelayOverrides[statusCode]
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(delayMs) * time.Millisecond, true
+}
+
+// classifyTransportError maps a request error to one of the retry_on_errors classes
+func classifyTransportError(err error) string {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return errorClassDNS
+	}
+
+	var certVerifyErr *tls.CertificateVerificationError
+	var recordHeaderErr tls.RecordHeaderError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var certInvalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &certVerifyErr) || errors.As(err, &recordHeaderErr) ||
+		errors.As(err, &unknownAuthorityErr) || errors.As(err, &certInvalidErr) ||
+		errors.As(err, &hostnameErr) || strings.Contains(err.Error(), "tls: ") {
+		return errorClassTLS
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return errorClassTimeout
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		strings.Contains(err.Error(), "connection reset") {
+		return errorClassReset
+	}
 
-	// Apply jitter if enabled (add random 0-25% of delay)
-	if rc.Jitter {
-		jitterMs := int64(float64(delayMs) * 0.25 * rand.Float64()) //nolint:gosec // Non-cryptographic use for jitter
-		delay += time.Duration(jitterMs) * time.Millisecond
+	var opErr *net.OpError
+	if errors.Is(err, syscall.ECONNREFUSED) || (errors.As(err, &opErr) && opErr.Op == "dial") {
+		return errorClassConnect
 	}
 
-	return delay
+	return errorClassOther
+}
+
+// idempotentMethods are the methods RFC 9110 defines as idempotent
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// transportRetryIsSafe reports whether a request that failed with a transport error can be
+// re-sent without risking a duplicate side effect. DNS, connect and TLS errors happen before the
+// request reaches the server; otherwise the method must be idempotent or carry an idempotency key.
+func transportRetryIsSafe(req *http.Request, err error) bool {
+	switch classifyTransportError(err) {
+	case errorClassDNS, errorClassConnect, errorClassTLS:
+		return true
+	}
+	if idempotentMethods[strings.ToUpper(req.Method)] {
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != "" || req.Header.Get("X-Idempotency-Key") != ""
 }
 
 // parseRetryAfter parses the Retry-After header value
@@ -118,12 +299,134 @@ func parseRetryAfter(retryAfter string) (time.Duration, error) {
 	return 0, fmt.Errorf("unable to parse retry-after: %s", retryAfter)
 }
 
+// epochThresholdSeconds separates X-RateLimit-Reset epoch timestamps from delta-seconds values
+const epochThresholdSeconds = 1000000000
+
+// retryAfterFromHeaders derives a Retry-After value (in seconds or HTTP-date) from response headers.
+// Retry-After takes precedence. Otherwise, when the rate limit is exhausted (429 or remaining = 0),
+// the reset time is taken from RateLimit-Reset, the structured RateLimit field, or X-RateLimit-Reset.
+func retryAfterFromHeaders(statusCode int64, headers map[string]string) string {
+	if value, ok := lookupHeader(headers, "Retry-After"); ok && strings.TrimSpace(value) != "" {
+		return value
+	}
+
+	// Structured field from the IETF draft, e.g. RateLimit: "default";r=0;t=30
+	// Older drafts combine fields instead, e.g. RateLimit: limit=100, remaining=0, reset=30
+	var structuredRemaining, structuredReset string
+	if value, ok := lookupHeader(headers, "RateLimit"); ok {
+		params := parseRateLimitParams(value)
+		structuredRemaining = firstNonEmpty(params["r"], params["remaining"])
+		structuredReset = firstNonEmpty(params["t"], params["reset"])
+	}
+
+	remaining := structuredRemaining
+	if value, ok := lookupHeader(headers, "RateLimit-Remaining"); ok {
+		remaining = value
+	} else if value, ok := lookupHeader(headers, "X-RateLimit-Remaining"); ok {
+		remaining = value
+	}
+
+	if statusCode != http.StatusTooManyRequests && strings.TrimSpace(remaining) != "0" {
+		return ""
+	}
+
+	if value, ok := lookupHeader(headers, "RateLimit-Reset"); ok {
+		if seconds, err := parseResetSeconds(value, false); err == nil {
+			return strconv.FormatInt(seconds, 10)
+		}
+	}
+
+	if structuredReset != "" {
+		if seconds, err := parseResetSeconds(structuredReset, false); err == nil {
+			return strconv.FormatInt(seconds, 10)
+		}
+	}
+
+	if value, ok := lookupHeader(headers, "X-RateLimit-Reset"); ok {
+		if seconds, err := parseResetSeconds(value, true); err == nil {
+			return strconv.FormatInt(seconds, 10)
+		}
+	}
+
+	return ""
+}
+
+// parseResetSeconds parses a rate limit reset value into whole seconds to wait.
+// When allowEpoch is set, large values are treated as a Unix timestamp.
+func parseResetSeconds(value string, allowEpoch bool) (int64, error) {
+	reset, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || reset < 0 {
+		return 0, fmt.Errorf("invalid rate limit reset: %s", value)
+	}
+
+	if allowEpoch && reset >= epochThresholdSeconds {
+		reset -= float64(time.Now().UnixNano()) / float64(time.Second)
+		if reset < 0 {
+			reset = 0
+		}
+	}
+
+	return int64(math.Ceil(reset)), nil
+}
+
+// parseRateLimitParams parses key=value parameters from a RateLimit header,
+// accepting both ';' and ',' separators
+func parseRateLimitParams(value string) map[string]string {
+	params := make(map[string]string)
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' }) {
+		key, val, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(val), `"`)
+	}
+	return params
+}
+
+// lookupHeader finds a response header value by case-insensitive name
+func lookupHeader(headers map[string]string, name string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 // ExecuteRequestWithRetry executes an HTTP request with retry logic
 // If retryUntilConfig is provided, it will poll until conditions are met
-func ExecuteRequestWithRetry(ctx context.Context, req *http.Request, config *ProviderConfig, retryConfig *RetryConfig, retryUntilConfig *RetryUntilConfig) (*ResponseResult, error) {
+// If abortOnConfig is provided, a matching response stops all further attempts with an error
+// Each attempt is recorded in the returned result's AttemptHistory (capped at maxAttemptHistory)
+func ExecuteRequestWithRetry(ctx context.Context, req *http.Request, config *ProviderConfig, retryConfig *RetryConfig, retryUntilConfig *RetryUntilConfig, abortOnConfig *AbortOnConfig) (*ResponseResult, error) {
+	history := &attemptHistory{}
+	result, err := executeRequestWithRetry(ctx, req, config, retryConfig, retryUntilConfig, abortOnConfig, history)
+	if result != nil {
+		result.AttemptHistory = history.records
+	}
+	return result, err
+}
+
+// executeRequestWithRetry implements ExecuteRequestWithRetry, recording each attempt into history
+func executeRequestWithRetry(ctx context.Context, req *http.Request, config *ProviderConfig, retryConfig *RetryConfig, retryUntilConfig *RetryUntilConfig, abortOnConfig *AbortOnConfig, history *attemptHistory) (*ResponseResult, error) {
 	if retryConfig == nil && retryUntilConfig == nil {
 		// No retry config, execute once
-		return ExecuteRequest(ctx, req, config)
+		result, err := executeRecordedAttempt(ctx, req, config, 1, history)
+		if err == nil {
+			if aborted, reason := abortOnConfig.EvaluateAbortOn(ctx, result); aborted {
+				return result, fmt.Errorf("request aborted: %s", reason)
+			}
+		}
+		return result, err
 	}
 
 	// If retry_until is configured, we need retry config too
@@ -137,6 +440,7 @@ func ExecuteRequestWithRetry(ctx context.Context, req *http.Request, config *Pro
 			Jitter:             true,
 			RetryOnStatusCodes: []int64{},
 			RespectRetryAfter:  true,
+			SafeMethodsOnly:    true,
 		}
 	}
 
@@ -144,28 +448,52 @@ func ExecuteRequestWithRetry(ctx context.Context, req *http.Request, config *Pro
 	var lastResult *ResponseResult
 	var retryAfter string
 
+	// Track consecutive polls satisfying retry_until
+	var successStreak int64
+	requiredSuccesses := int64(1)
+	if retryUntilConfig != nil && retryUntilConfig.ConsecutiveSuccesses > 1 {
+		requiredSuccesses = retryUntilConfig.ConsecutiveSuccesses
+	}
+
 	attempts := retryConfig.Attempts
 	if attempts <= 0 {
 		attempts = 1 // Default to 1 attempt if not configured
 	}
 
+	// Wait before the first poll if configured
+	if retryUntilConfig != nil && retryUntilConfig.InitialDelayMs > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(retryUntilConfig.InitialDelayMs) * time.Millisecond):
+		}
+	}
+
 	for attempt := int64(1); attempt <= attempts; attempt++ {
 		tflog.Debug(ctx, "Executing HTTP request", map[string]interface{}{
 			"attempt": attempt,
 			"max_attempts": attempts,
-			"url": req.URL.String(),
+			"url": config.redact(req.URL.String()),
 		})
 
 		// Execute request
-		result, err := ExecuteRequest(ctx, req, config)
+		result, err := executeRecordedAttempt(ctx, req, config, attempt, history)
 		if err != nil {
 			lastErr = err
 			lastResult = result
+			successStreak = 0
 			
 			// Check if we should retry
 			if !retryConfig.ShouldRetry(err, 0) || attempt >= attempts {
 				return result, err
 			}
+			if retryConfig.SafeMethodsOnly && !transportRetryIsSafe(req, err) {
+				tflog.Debug(ctx, "Not retrying non-idempotent request after transport error", map[string]interface{}{
+					"method": req.Method,
+					"error":  err.Error(),
+				})
+				return result, err
+			}
 
 			// Calculate delay and wait
 			delay := retryConfig.CalculateDelay(attempt, "")
@@ -185,54 +513,78 @@ func ExecuteRequestWithRetry(ctx context.Context, req *http.Request, config *Pro
 			continue
 		}
 
+		// Check abort conditions before deciding whether to keep polling
+		if aborted, reason := abortOnConfig.EvaluateAbortOn(ctx, result); aborted {
+			result.AttemptCount = attempt
+			return result, fmt.Errorf("request aborted after %d attempt(s): %s", attempt, reason)
+		}
+
 		// Check conditional retry (retry_until)
 		if retryUntilConfig != nil {
 			satisfied, unsatisfied := retryUntilConfig.EvaluateRetryUntil(ctx, result)
-			if !satisfied && attempt < attempts {
-				// Extract Retry-After header if present
-				if retryConfig.RespectRetryAfter {
-					if retryAfterHeader, ok := result.Headers["Retry-After"]; ok {
-						retryAfter = retryAfterHeader
-					}
-				}
-
-				// Calculate delay and wait
-				delay := retryConfig.CalculateDelay(attempt, retryAfter)
+			if record := history.last(); record != nil {
+				record.ConditionsEvaluated = true
+				record.ConditionsMet = satisfied
+				record.UnsatisfiedConditions = unsatisfied
+			}
+			if satisfied {
+				successStreak++
+			} else {
+				successStreak = 0
+			}
+			if satisfied && successStreak >= requiredSuccesses {
+				// Conditions met, return success
+				result.AttemptCount = attempt
+				return result, nil
+			}
+
+			lastResult = result
+			if attempt >= attempts {
+				break
+			}
+
+			// Extract Retry-After or rate limit reset headers if present
+			if retryConfig.RespectRetryAfter {
+				retryAfter = retryAfterFromHeaders(result.StatusCode, result.Headers)
+			}
+
+			// Calculate delay and wait (steady interval if configured)
+			delay := retryUntilConfig.PollDelay(retryConfig, attempt, result.StatusCode, retryAfter)
+			if satisfied {
+				tflog.Debug(ctx, "Conditional retry conditions met, waiting for consecutive successes", map[string]interface{}{
+					"attempt": attempt,
+					"consecutive_successes": successStreak,
+					"required": requiredSuccesses,
+					"delay_ms": delay.Milliseconds(),
+				})
+			} else {
 				tflog.Debug(ctx, "Conditional retry conditions not met", map[string]interface{}{
 					"attempt": attempt,
 					"status_code": result.StatusCode,
 					"unsatisfied": unsatisfied,
 					"delay_ms": delay.Milliseconds(),
 				})
-
-				select {
-				case <-ctx.Done():
-					return result, ctx.Err()
-				case <-time.After(delay):
-					// Continue to next attempt
-				}
-
-				lastResult = result
-				continue
 			}
-			if satisfied {
-				// Conditions met, return success
-				result.AttemptCount = attempt
-				return result, nil
+
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(delay):
+				// Continue to next attempt
 			}
+
+			continue
 		}
 
 		// Check if we should retry based on status code (only if no retry_until)
 		if retryUntilConfig == nil && retryConfig.ShouldRetry(nil, result.StatusCode) && attempt < attempts {
-			// Extract Retry-After header if present
+			// Extract Retry-After or rate limit reset headers if present
 			if retryConfig.RespectRetryAfter {
-				if retryAfterHeader, ok := result.Headers["Retry-After"]; ok {
-					retryAfter = retryAfterHeader
-				}
+				retryAfter = retryAfterFromHeaders(result.StatusCode, result.Headers)
 			}
 
 			// Calculate delay and wait
-			delay := retryConfig.CalculateDelay(attempt, retryAfter)
+			delay := retryConfig.DelayForStatus(attempt, result.StatusCode, retryAfter)
 			tflog.Debug(ctx, "Status code requires retry", map[string]interface{}{
 				"attempt": attempt,
 				"status_code": result.StatusCode,
@@ -259,7 +611,10 @@ func ExecuteRequestWithRetry(ctx context.Context, req *http.Request, config *Pro
 	if lastResult != nil {
 		lastResult.AttemptCount = attempts
 		if retryUntilConfig != nil {
-			_, unsatisfied := retryUntilConfig.EvaluateRetryUntil(ctx, lastResult)
+			satisfied, unsatisfied := retryUntilConfig.EvaluateRetryUntil(ctx, lastResult)
+			if satisfied {
+				return lastResult, fmt.Errorf("exhausted %d retry attempts, conditions held for %d of %d consecutive polls", attempts, successStreak, requiredSuccesses)
+			}
 			return lastResult, fmt.Errorf("exhausted %d retry attempts, conditions not met: %v", attempts, unsatisfied)
 		}
 		return lastResult, fmt.Errorf("exhausted %d retry attempts, last status: %d", attempts, lastResult.StatusCode)
@@ -276,6 +631,54 @@ func ExecuteRequestWithRetry(ctx context.Context, req *http.Request, config *Pro
 	return nil, fmt.Errorf("exhausted %d retry attempts", attempts)
 }
 
+// executeRecordedAttempt executes a single attempt and records its outcome in history
+func executeRecordedAttempt(ctx context.Context, req *http.Request, config *ProviderConfig, attempt int64, history *attemptHistory) (*ResponseResult, error) {
+	nonce, err := applyNonce(req, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config != nil {
+		config.Resume.applyResume(req)
+	}
+	if err := runPreRequestCommand(ctx, req, config); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	result, err := ExecuteRequest(ctx, req, config)
+	if err == nil && config != nil {
+		result, err = config.Resume.complete(result, config)
+	}
+	if err == nil {
+		err = runPostResponseCommand(ctx, req, result, config)
+	}
+
+	record := AttemptRecord{
+		Attempt:        attempt,
+		DurationMs:     time.Since(start).Milliseconds(),
+		Nonce:          nonce,
+		RequestHeaders: sentRequestHeaders(req, config.redaction()),
+	}
+	if result != nil {
+		record.StatusCode = result.StatusCode
+		record.Error = result.Error
+	}
+	if err != nil && record.Error == "" {
+		record.Error = err.Error()
+	}
+	if config != nil && config.CaptureTranscript {
+		record.Transcript = renderAttemptTranscript(req, result, err, config.redaction(), attempt)
+	}
+	history.add(record)
+	auditAttempt(ctx, req, config, record)
+	if config != nil {
+		config.Metrics.record(req, record)
+	}
+
+	return result, err
+}
+
 // BuildRetryConfig converts RetryModel to RetryConfig
 func BuildRetryConfig(ctx context.Context, retryModel *RetryModel) *RetryConfig {
 	if retryModel == nil {
@@ -290,6 +693,7 @@ func BuildRetryConfig(ctx context.Context, retryModel *RetryModel) *RetryConfig
 		Jitter:             true,
 		RetryOnStatusCodes: []int64{408, 429, 500, 502, 503, 504},
 		RespectRetryAfter:  true,
+		SafeMethodsOnly:    true,
 	}
 
 	if !retryModel.Attempts.IsNull() && !retryModel.Attempts.IsUnknown() {
@@ -315,6 +719,10 @@ func BuildRetryConfig(ctx context.Context, retryModel *RetryModel) *RetryConfig
 		config.Jitter = retryModel.Jitter.ValueBool()
 	}
 
+	if !retryModel.JitterMode.IsNull() && !retryModel.JitterMode.IsUnknown() {
+		config.JitterMode = strings.ToLower(retryModel.JitterMode.ValueString())
+	}
+
 	if !retryModel.RetryOnStatusCodes.IsNull() && !retryModel.RetryOnStatusCodes.IsUnknown() {
 		codes, err := ConvertTerraformList(ctx, retryModel.RetryOnStatusCodes, func(v interface{}) (int64, error) {
 			if intVal, ok := v.(types.Int64); ok {
@@ -327,10 +735,49 @@ func BuildRetryConfig(ctx context.Context, retryModel *RetryModel) *RetryConfig
 		}
 	}
 
+	config.RetryOnStatusRanges = buildStatusRanges(ctx, "retry_on_status_classes", retryModel.RetryOnStatusClasses)
+	if len(config.RetryOnStatusRanges) > 0 && retryModel.RetryOnStatusCodes.IsNull() {
+		// Classes replace the default status codes unless codes are also configured
+		config.RetryOnStatusCodes = []int64{}
+	}
+
 	if !retryModel.RespectRetryAfter.IsNull() && !retryModel.RespectRetryAfter.IsUnknown() {
 		config.RespectRetryAfter = retryModel.RespectRetryAfter.ValueBool()
 	}
 
+	if !retryModel.RetryOnErrors.IsNull() && !retryModel.RetryOnErrors.IsUnknown() {
+		classes, err := ConvertTerraformList(ctx, retryModel.RetryOnErrors, func(v interface{}) (string, error) {
+			if strVal, ok := v.(types.String); ok {
+				return strings.ToLower(strVal.ValueString()), nil
+			}
+			return "", fmt.Errorf("expected string, got %T", v)
+		})
+		if err == nil {
+			// An explicit empty list disables retries on transport errors
+			config.RetryOnErrors = append([]string{}, classes...)
+		}
+	}
+
+	if !retryModel.SafeMethodsOnly.IsNull() && !retryModel.SafeMethodsOnly.IsUnknown() {
+		config.SafeMethodsOnly = retryModel.SafeMethodsOnly.ValueBool()
+	}
+
+	if !retryModel.StatusDelayOverrides.IsNull() && !retryModel.StatusDelayOverrides.IsUnknown() {
+		config.StatusDelayOverrides = make(map[int64]int64)
+		for k, v := range retryModel.StatusDelayOverrides.Elements() {
+			code, err := strconv.ParseInt(strings.TrimSpace(k), 10, 64)
+			if err != nil {
+				tflog.Warn(ctx, "Ignoring status_delay_overrides entry with invalid status code", map[string]interface{}{
+					"status_code": k,
+				})
+				continue
+			}
+			if intVal, ok := v.(types.Int64); ok && !intVal.IsNull() && !intVal.IsUnknown() {
+				config.StatusDelayOverrides[code] = intVal.ValueInt64()
+			}
+		}
+	}
+
 	return config
 }
 
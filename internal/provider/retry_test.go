@@ -1,7 +1,17 @@
 package provider
 
 import (
+	"context"
+	"crypto/x509"
 	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -41,6 +51,42 @@ func TestRetryConfig_ShouldRetry(t *testing.T) {
 			statusCode: 200,
 			want:       false,
 		},
+		{
+			name: "retry on allowed error class",
+			config: RetryConfig{
+				RetryOnErrors: []string{"dns", "connect"},
+			},
+			err:        fmt.Errorf("request failed: %w", &net.DNSError{Err: "no such host", Name: "missing.example"}),
+			statusCode: 0,
+			want:       true,
+		},
+		{
+			name: "don't retry on disallowed error class",
+			config: RetryConfig{
+				RetryOnErrors: []string{"dns", "connect"},
+			},
+			err:        fmt.Errorf("request failed: %w", x509.UnknownAuthorityError{}),
+			statusCode: 0,
+			want:       false,
+		},
+		{
+			name: "retry on any error class",
+			config: RetryConfig{
+				RetryOnErrors: []string{"any"},
+			},
+			err:        errors.New("something unexpected"),
+			statusCode: 0,
+			want:       true,
+		},
+		{
+			name: "empty error classes disable transport retries",
+			config: RetryConfig{
+				RetryOnErrors: []string{},
+			},
+			err:        errors.New("connection failed"),
+			statusCode: 0,
+			want:       false,
+		},
 		{
 			name: "don't retry on unconfigured status code",
 			config: RetryConfig{
@@ -140,6 +186,54 @@ func TestRetryConfig_CalculateDelay(t *testing.T) {
 			wantMin: 1000 * time.Millisecond,
 			wantMax: 1250 * time.Millisecond, // 1000 + 25% = 1250
 		},
+		{
+			name: "full jitter",
+			config: RetryConfig{
+				MinDelayMs: 1000,
+				MaxDelayMs: 5000,
+				Backoff:    "fixed",
+				JitterMode: "full",
+			},
+			attempt: 1,
+			wantMin: 0,
+			wantMax: 1000 * time.Millisecond,
+		},
+		{
+			name: "equal jitter",
+			config: RetryConfig{
+				MinDelayMs: 1000,
+				MaxDelayMs: 5000,
+				Backoff:    "fixed",
+				JitterMode: "equal",
+			},
+			attempt: 1,
+			wantMin: 500 * time.Millisecond,
+			wantMax: 1000 * time.Millisecond,
+		},
+		{
+			name: "jitter mode none overrides jitter flag",
+			config: RetryConfig{
+				MinDelayMs: 1000,
+				MaxDelayMs: 5000,
+				Backoff:    "fixed",
+				Jitter:     true,
+				JitterMode: "none",
+			},
+			attempt: 1,
+			wantMin: 1000 * time.Millisecond,
+			wantMax: 1000 * time.Millisecond,
+		},
+		{
+			name: "decorrelated first attempt",
+			config: RetryConfig{
+				MinDelayMs: 1000,
+				MaxDelayMs: 10000,
+				Backoff:    "decorrelated",
+			},
+			attempt: 1,
+			wantMin: 1000 * time.Millisecond,
+			wantMax: 3000 * time.Millisecond,
+		},
 	}
 
 	for _, tt := range tests {
@@ -155,6 +249,86 @@ func TestRetryConfig_CalculateDelay(t *testing.T) {
 	}
 }
 
+func TestRetryConfig_CalculateDelay_Decorrelated(t *testing.T) {
+	config := &RetryConfig{
+		MinDelayMs: 100,
+		MaxDelayMs: 2000,
+		Backoff:    "decorrelated",
+	}
+
+	prev := time.Duration(config.MinDelayMs) * time.Millisecond
+	for attempt := int64(1); attempt <= 20; attempt++ {
+		delay := config.CalculateDelay(attempt, "")
+		if delay < 100*time.Millisecond {
+			t.Fatalf("attempt %d: delay %v below min delay", attempt, delay)
+		}
+		if delay > 2000*time.Millisecond {
+			t.Fatalf("attempt %d: delay %v above max delay", attempt, delay)
+		}
+		if attempt > 1 && delay > prev*3 {
+			t.Fatalf("attempt %d: delay %v exceeds 3x previous delay %v", attempt, delay, prev)
+		}
+		prev = delay
+	}
+}
+
+func TestRetryConfig_DelayForStatus(t *testing.T) {
+	config := &RetryConfig{
+		MinDelayMs:           1000,
+		MaxDelayMs:           60000,
+		Backoff:              "fixed",
+		RespectRetryAfter:    true,
+		StatusDelayOverrides: map[int64]int64{423: 30000, 503: 10},
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int64
+		retryAfter string
+		expected   time.Duration
+	}{
+		{
+			name:       "override for locked",
+			statusCode: 423,
+			expected:   30 * time.Second,
+		},
+		{
+			name:       "override for unavailable",
+			statusCode: 503,
+			expected:   10 * time.Millisecond,
+		},
+		{
+			name:       "no override uses backoff",
+			statusCode: 500,
+			expected:   1 * time.Second,
+		},
+		{
+			name:       "retry-after wins over override",
+			statusCode: 503,
+			retryAfter: "2",
+			expected:   2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay := config.DelayForStatus(1, tt.statusCode, tt.retryAfter)
+			if delay != tt.expected {
+				t.Errorf("DelayForStatus() = %v, want %v", delay, tt.expected)
+			}
+		})
+	}
+
+	// Overrides also apply while polling with a fixed interval
+	retryUntilConfig := &RetryUntilConfig{IntervalMs: 500}
+	if delay := retryUntilConfig.PollDelay(config, 1, 423, ""); delay != 30*time.Second {
+		t.Errorf("PollDelay() = %v, want %v", delay, 30*time.Second)
+	}
+	if delay := retryUntilConfig.PollDelay(config, 1, 200, ""); delay != 500*time.Millisecond {
+		t.Errorf("PollDelay() = %v, want %v", delay, 500*time.Millisecond)
+	}
+}
+
 func TestParseRetryAfter(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -205,3 +379,561 @@ func TestParseRetryAfter(t *testing.T) {
 	}
 }
 
+func TestRetryAfterFromHeaders(t *testing.T) {
+	futureEpoch := strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10)
+	pastEpoch := strconv.FormatInt(time.Now().Add(-30*time.Second).Unix(), 10)
+
+	tests := []struct {
+		name       string
+		statusCode int64
+		headers    map[string]string
+		wantMin    int64
+		wantMax    int64
+		wantEmpty  bool
+	}{
+		{
+			name:       "retry-after takes precedence",
+			statusCode: 429,
+			headers:    map[string]string{"Retry-After": "5", "Ratelimit-Reset": "60"},
+			wantMin:    5,
+			wantMax:    5,
+		},
+		{
+			name:       "ratelimit-reset delta seconds on 429",
+			statusCode: 429,
+			headers:    map[string]string{"Ratelimit-Reset": "12"},
+			wantMin:    12,
+			wantMax:    12,
+		},
+		{
+			name:       "ratelimit-reset when remaining is zero",
+			statusCode: 200,
+			headers:    map[string]string{"Ratelimit-Remaining": "0", "Ratelimit-Reset": "7"},
+			wantMin:    7,
+			wantMax:    7,
+		},
+		{
+			name:       "ratelimit-reset ignored while quota remains",
+			statusCode: 200,
+			headers:    map[string]string{"Ratelimit-Remaining": "10", "Ratelimit-Reset": "7"},
+			wantEmpty:  true,
+		},
+		{
+			name:       "structured ratelimit field",
+			statusCode: 200,
+			headers:    map[string]string{"Ratelimit": `"default";r=0;t=9`},
+			wantMin:    9,
+			wantMax:    9,
+		},
+		{
+			name:       "combined ratelimit field",
+			statusCode: 429,
+			headers:    map[string]string{"Ratelimit": "limit=100, remaining=0, reset=4"},
+			wantMin:    4,
+			wantMax:    4,
+		},
+		{
+			name:       "x-ratelimit-reset epoch",
+			statusCode: 429,
+			headers:    map[string]string{"X-Ratelimit-Remaining": "0", "X-Ratelimit-Reset": futureEpoch},
+			wantMin:    28,
+			wantMax:    31,
+		},
+		{
+			name:       "x-ratelimit-reset epoch in the past",
+			statusCode: 429,
+			headers:    map[string]string{"X-Ratelimit-Reset": pastEpoch},
+			wantMin:    0,
+			wantMax:    0,
+		},
+		{
+			name:       "x-ratelimit-reset delta seconds",
+			statusCode: 429,
+			headers:    map[string]string{"X-RateLimit-Reset": "3"},
+			wantMin:    3,
+			wantMax:    3,
+		},
+		{
+			name:       "no rate limit headers",
+			statusCode: 429,
+			headers:    map[string]string{"Content-Type": "application/json"},
+			wantEmpty:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := retryAfterFromHeaders(tt.statusCode, tt.headers)
+			if tt.wantEmpty {
+				if got != "" {
+					t.Errorf("retryAfterFromHeaders() = %q, want empty", got)
+				}
+				return
+			}
+			seconds, err := strconv.ParseInt(got, 10, 64)
+			if err != nil {
+				t.Fatalf("retryAfterFromHeaders() = %q, want seconds", got)
+			}
+			if seconds < tt.wantMin || seconds > tt.wantMax {
+				t.Errorf("retryAfterFromHeaders() = %d, want between %d and %d", seconds, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestExecuteRequestWithRetry_RateLimitReset(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("RateLimit-Remaining", "0")
+			w.Header().Set("RateLimit-Reset", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	config := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576}
+	retryConfig := &RetryConfig{
+		Attempts:           2,
+		MinDelayMs:         1,
+		MaxDelayMs:         1,
+		Backoff:            "fixed",
+		RetryOnStatusCodes: []int64{429},
+		RespectRetryAfter:  true,
+	}
+
+	start := time.Now()
+	result, err := ExecuteRequestWithRetry(context.Background(), req, config, retryConfig, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", result.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for the rate limit reset, waited %v", elapsed)
+	}
+}
+
+func TestExecuteRequestWithRetry_ContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	config := &ProviderConfig{TimeoutMs: 30000, MaxResponseBodyBytes: 1048576}
+	_, err = ExecuteRequestWithRetry(ctx, req, config, nil, nil, nil)
+	if err == nil {
+		t.Fatal("expected error when context deadline is exceeded")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request was not cancelled by context deadline, took %v", elapsed)
+	}
+}
+
+func TestClassifyTransportError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "dns",
+			err:  &url.Error{Op: "Get", URL: "https://missing.example", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "missing.example"}}},
+			want: "dns",
+		},
+		{
+			name: "connection refused",
+			err:  &url.Error{Op: "Get", URL: "https://localhost:1", Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}},
+			want: "connect",
+		},
+		{
+			name: "context deadline",
+			err:  fmt.Errorf("request failed: %w", context.DeadlineExceeded),
+			want: "timeout",
+		},
+		{
+			name: "certificate error",
+			err:  &url.Error{Op: "Get", URL: "https://self-signed.example", Err: x509.UnknownAuthorityError{}},
+			want: "tls",
+		},
+		{
+			name: "connection reset",
+			err:  &url.Error{Op: "Get", URL: "https://api.example.com", Err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}},
+			want: "reset",
+		},
+		{
+			name: "unexpected EOF",
+			err:  fmt.Errorf("request failed: %w", io.ErrUnexpectedEOF),
+			want: "reset",
+		},
+		{
+			name: "other",
+			err:  errors.New("failed to create HTTP client"),
+			want: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyTransportError(tt.err); got != tt.want {
+				t.Errorf("classifyTransportError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteRequestWithRetry_AbortOn(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "failed"}`))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	config := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576}
+	retryConfig := &RetryConfig{Attempts: 5, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed"}
+	retryUntilConfig := &RetryUntilConfig{JsonPathEquals: map[string]string{"status": "done"}}
+	abortOnConfig := &AbortOnConfig{JsonPathEquals: map[string]string{"status": "failed"}}
+
+	result, err := ExecuteRequestWithRetry(context.Background(), req, config, retryConfig, retryUntilConfig, abortOnConfig)
+	if err == nil {
+		t.Fatal("expected abort error")
+	}
+	if calls != 1 {
+		t.Errorf("expected polling to stop after 1 attempt, got %d", calls)
+	}
+	if result == nil || result.AttemptCount != 1 {
+		t.Errorf("expected attempt count 1, got %+v", result)
+	}
+}
+
+func TestRetryUntilConfig_PollDelay(t *testing.T) {
+	retryConfig := &RetryConfig{MinDelayMs: 1000, MaxDelayMs: 30000, Backoff: "exponential", RespectRetryAfter: true}
+
+	tests := []struct {
+		name       string
+		config     *RetryUntilConfig
+		attempt    int64
+		retryAfter string
+		expected   time.Duration
+	}{
+		{
+			name:     "no interval uses backoff",
+			config:   &RetryUntilConfig{},
+			attempt:  3,
+			expected: 4 * time.Second,
+		},
+		{
+			name:     "interval is steady across attempts",
+			config:   &RetryUntilConfig{IntervalMs: 500},
+			attempt:  5,
+			expected: 500 * time.Millisecond,
+		},
+		{
+			name:       "retry-after overrides interval",
+			config:     &RetryUntilConfig{IntervalMs: 500},
+			attempt:    1,
+			retryAfter: "2",
+			expected:   2 * time.Second,
+		},
+		{
+			name:       "invalid retry-after falls back to interval",
+			config:     &RetryUntilConfig{IntervalMs: 500},
+			attempt:    1,
+			retryAfter: "soon",
+			expected:   500 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay := tt.config.PollDelay(retryConfig, tt.attempt, 200, tt.retryAfter)
+			if delay != tt.expected {
+				t.Errorf("expected delay %v, got %v", tt.expected, delay)
+			}
+		})
+	}
+}
+
+func TestExecuteRequestWithRetry_PollInterval(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		status := "pending"
+		if calls >= 3 {
+			status = "done"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "` + status + `"}`))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	config := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576}
+	// A large backoff would make the test time out if the interval were ignored
+	retryConfig := &RetryConfig{Attempts: 5, MinDelayMs: 60000, MaxDelayMs: 60000, Backoff: "fixed"}
+	retryUntilConfig := &RetryUntilConfig{
+		JsonPathEquals: map[string]string{"status": "done"},
+		IntervalMs:     10,
+		InitialDelayMs: 10,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := ExecuteRequestWithRetry(ctx, req, config, retryConfig, retryUntilConfig, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.AttemptCount != 3 {
+		t.Errorf("expected 3 attempts, got %d", result.AttemptCount)
+	}
+}
+
+func TestExecuteRequestWithRetry_ConsecutiveSuccesses(t *testing.T) {
+	tests := []struct {
+		name          string
+		statuses      []string
+		attempts      int64
+		required      int64
+		expectError   bool
+		expectedCalls int
+	}{
+		{
+			name:          "flapping endpoint must stabilize",
+			statuses:      []string{"ready", "pending", "ready", "ready", "ready"},
+			attempts:      10,
+			required:      3,
+			expectedCalls: 5,
+		},
+		{
+			name:          "single success without stabilization window",
+			statuses:      []string{"pending", "ready"},
+			attempts:      10,
+			required:      0,
+			expectedCalls: 2,
+		},
+		{
+			name:          "attempts exhausted before window completes",
+			statuses:      []string{"pending", "ready", "pending", "ready"},
+			attempts:      4,
+			required:      2,
+			expectError:   true,
+			expectedCalls: 4,
+		},
+		{
+			name:          "last attempt not satisfied",
+			statuses:      []string{"pending", "pending"},
+			attempts:      2,
+			required:      1,
+			expectError:   true,
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[len(tt.statuses)-1]
+				if calls < len(tt.statuses) {
+					status = tt.statuses[calls]
+				}
+				calls++
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"status": "` + status + `"}`))
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+
+			config := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576}
+			retryConfig := &RetryConfig{Attempts: tt.attempts, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed"}
+			retryUntilConfig := &RetryUntilConfig{
+				JsonPathEquals:       map[string]string{"status": "ready"},
+				ConsecutiveSuccesses: tt.required,
+			}
+
+			_, err = ExecuteRequestWithRetry(context.Background(), req, config, retryConfig, retryUntilConfig, nil)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestTransportRetryIsSafe(t *testing.T) {
+	reset := &net.OpError{Op: "read", Err: syscall.ECONNRESET}
+	refused := &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}
+
+	tests := []struct {
+		name   string
+		method string
+		header string
+		err    error
+		want   bool
+	}{
+		{"GET after reset", http.MethodGet, "", reset, true},
+		{"PUT after reset", http.MethodPut, "", reset, true},
+		{"POST after reset", http.MethodPost, "", reset, false},
+		{"PATCH after reset", http.MethodPatch, "", reset, false},
+		{"POST with idempotency key", http.MethodPost, "key-123", reset, true},
+		{"POST after connection refused", http.MethodPost, "", refused, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, "https://api.example.com", nil)
+			if tt.header != "" {
+				req.Header.Set("Idempotency-Key", tt.header)
+			}
+			if got := transportRetryIsSafe(req, tt.err); got != tt.want {
+				t.Errorf("transportRetryIsSafe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteRequestWithRetry_SafeMethodsOnly(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		// Drop the connection after the request was received
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}))
+	defer server.Close()
+
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576}
+	for _, tc := range []struct {
+		safeMethodsOnly bool
+		wantAttempts    int
+	}{
+		{true, 1},
+		{false, 3},
+	} {
+		attempts = 0
+		req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+		retryConfig := &RetryConfig{Attempts: 3, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed", SafeMethodsOnly: tc.safeMethodsOnly}
+		if _, err := ExecuteRequestWithRetry(context.Background(), req, providerConfig, retryConfig, nil, nil); err == nil {
+			t.Fatal("expected transport error")
+		}
+		if attempts != tc.wantAttempts {
+			t.Errorf("safe_methods_only=%v: got %d attempts, want %d", tc.safeMethodsOnly, attempts, tc.wantAttempts)
+		}
+	}
+}
+
+func TestExecuteRequestWithRetry_RetryUntilSafeMethodsOnly(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}))
+	defer server.Close()
+
+	// retry_until without a retry block must not re-send a POST after the connection dropped
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576}
+	req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+	retryUntil := &RetryUntilConfig{StatusCodes: []int64{200}, IntervalMs: 1}
+	if _, err := ExecuteRequestWithRetry(context.Background(), req, providerConfig, nil, retryUntil, nil); err == nil {
+		t.Fatal("expected transport error")
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestExecuteRequestWithRetry_FaultInjection(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	status := int64(503)
+	failFirst := int64(2)
+	injector, err := buildFaultInjector(&FaultInjectionModel{FailFirstAttempts: &failFirst, StatusCode: &status})
+	if err != nil {
+		t.Fatalf("buildFaultInjector() error = %v", err)
+	}
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1048576, FaultInjector: injector}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	retryConfig := &RetryConfig{Attempts: 3, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed", RetryOnStatusCodes: []int64{503}}
+	result, err := ExecuteRequestWithRetry(context.Background(), req, providerConfig, retryConfig, nil, nil)
+	if err != nil {
+		t.Fatalf("ExecuteRequestWithRetry() error = %v", err)
+	}
+	if result.StatusCode != 200 || result.AttemptCount != 3 {
+		t.Errorf("got status %d after %d attempts, want 200 after 3", result.StatusCode, result.AttemptCount)
+	}
+	if attempts != 1 {
+		t.Errorf("expected injected failures to never reach the server, got %d requests", attempts)
+	}
+
+	invalid := int64(-1)
+	if _, err := buildFaultInjector(&FaultInjectionModel{LatencyMs: &invalid}); err == nil {
+		t.Error("expected error for negative latency_ms")
+	}
+
+	minMs, maxMs := int64(50), int64(10)
+	_, err = buildFaultInjector(&FaultInjectionModel{HostLatency: map[string]HostLatencyModel{
+		"api.example.com": {MinMs: &minMs, MaxMs: &maxMs},
+	}})
+	if err == nil {
+		t.Error("expected error for host_latency max_ms below min_ms")
+	}
+	if _, err := buildFaultInjector(&FaultInjectionModel{HostLatency: map[string]HostLatencyModel{
+		"api.example.com": {MinMs: &minMs},
+	}}); err != nil {
+		t.Errorf("max_ms should default to min_ms, got error = %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resourceRetryBlocks are the resource's retry blocks
+var resourceRetryBlocks = []path.Path{
+	path.Root("retry"),
+	path.Root("on_destroy").AtName("retry"),
+}
+
+// dataSourceRetryBlocks are the data source's retry blocks
+var dataSourceRetryBlocks = []path.Path{
+	path.Root("retry"),
+}
+
+// jitterModes are the values accepted in jitter_mode
+var jitterModes = []string{jitterModeNone, jitterModeFull, jitterModeEqual, jitterModePercentage}
+
+// retryErrorClasses are the values accepted in retry_on_errors
+var retryErrorClasses = []string{errorClassAny, errorClassDNS, errorClassConnect, errorClassTimeout, errorClassTLS, errorClassReset, errorClassOther}
+
+// validateRetryBlocks checks the retry blocks at blocks, so a typo fails the plan instead of
+// silently changing which failures are retried
+func validateRetryBlocks(ctx context.Context, config tfsdk.Config, blocks []path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, blockPath := range blocks {
+		var retry *RetryModel
+		if getDiags := config.GetAttribute(ctx, blockPath, &retry); getDiags.HasError() {
+			diags.Append(getDiags...)
+			return diags
+		}
+		if retry == nil {
+			continue
+		}
+
+		if mode := retry.JitterMode.ValueString(); mode != "" && !containsFold(jitterModes, mode) {
+			diags.AddAttributeError(blockPath.AtName("jitter_mode"), "Invalid jitter_mode",
+				fmt.Sprintf("jitter_mode must be one of %s, got %q", strings.Join(jitterModes, ", "), mode))
+		}
+
+		if !retry.RetryOnErrors.IsNull() && !retry.RetryOnErrors.IsUnknown() {
+			for i, element := range retry.RetryOnErrors.Elements() {
+				class, ok := element.(types.String)
+				if !ok || class.IsNull() || class.IsUnknown() {
+					continue
+				}
+				if !containsFold(retryErrorClasses, class.ValueString()) {
+					diags.AddAttributeError(blockPath.AtName("retry_on_errors").AtListIndex(i), "Invalid retry_on_errors",
+						fmt.Sprintf("retry_on_errors entries must be one of %s, got %q", strings.Join(retryErrorClasses, ", "), class.ValueString()))
+				}
+			}
+		}
+
+		if !retry.StatusDelayOverrides.IsNull() && !retry.StatusDelayOverrides.IsUnknown() {
+			for key := range retry.StatusDelayOverrides.Elements() {
+				code, err := strconv.ParseInt(strings.TrimSpace(key), 10, 64)
+				if err != nil || code < 100 || code > 599 {
+					diags.AddAttributeError(blockPath.AtName("status_delay_overrides").AtMapKey(key), "Invalid status_delay_overrides",
+						fmt.Sprintf("status_delay_overrides keys must be HTTP status codes between 100 and 599, got %q", key))
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
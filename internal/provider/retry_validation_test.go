@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRetryBlocks(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	retryType := objectType.AttributeTypes["retry"].(tftypes.Object)
+	onDestroyType := objectType.AttributeTypes["on_destroy"].(tftypes.Object)
+	destroyRetryType := onDestroyType.AttributeTypes["retry"].(tftypes.Object)
+
+	// validate checks a config with retry set in both the root and the on_destroy retry block
+	validate := func(retry map[string]tftypes.Value) []path.Path {
+		config := tfsdk.Config{Schema: schemaResp.Schema, Raw: nullObject(objectType, map[string]tftypes.Value{
+			"retry":      nullObject(retryType, retry),
+			"on_destroy": nullObject(onDestroyType, map[string]tftypes.Value{"retry": nullObject(destroyRetryType, retry)}),
+		})}
+		var paths []path.Path
+		for _, d := range validateRetryBlocks(ctx, config, resourceRetryBlocks) {
+			paths = append(paths, d.(interface{ Path() path.Path }).Path())
+		}
+		return paths
+	}
+	strings := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, 0, len(values))
+		for _, v := range values {
+			elements = append(elements, tftypes.NewValue(tftypes.String, v))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+	}
+
+	assert.Empty(t, validate(nil))
+	assert.Empty(t, validate(map[string]tftypes.Value{"retry_on_errors": strings("DNS", "timeout", "other")}))
+	assert.Equal(t, []path.Path{
+		path.Root("retry").AtName("retry_on_errors").AtListIndex(1),
+		path.Root("on_destroy").AtName("retry").AtName("retry_on_errors").AtListIndex(1),
+	}, validate(map[string]tftypes.Value{"retry_on_errors": strings("dns", "timout")}))
+
+	assert.Empty(t, validate(map[string]tftypes.Value{"jitter_mode": tftypes.NewValue(tftypes.String, "Equal")}))
+	assert.Equal(t, []path.Path{
+		path.Root("retry").AtName("jitter_mode"),
+		path.Root("on_destroy").AtName("retry").AtName("jitter_mode"),
+	}, validate(map[string]tftypes.Value{"jitter_mode": tftypes.NewValue(tftypes.String, "decorrelated")}))
+
+	overrides := func(keys ...string) tftypes.Value {
+		elements := make(map[string]tftypes.Value, len(keys))
+		for _, key := range keys {
+			elements[key] = tftypes.NewValue(tftypes.Number, 30000)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.Number}, elements)
+	}
+	assert.Empty(t, validate(map[string]tftypes.Value{"status_delay_overrides": overrides("423", " 503")}))
+	assert.Equal(t, []path.Path{
+		path.Root("retry").AtName("status_delay_overrides").AtMapKey("5xx"),
+		path.Root("on_destroy").AtName("retry").AtName("status_delay_overrides").AtMapKey("5xx"),
+	}, validate(map[string]tftypes.Value{"status_delay_overrides": overrides("429", "5xx")}))
+
+	// The data source paths resolve against its own schema
+	var dsSchemaResp datasource.SchemaResponse
+	NewHttpxRequestDataSource().Schema(ctx, datasource.SchemaRequest{}, &dsSchemaResp)
+	dsObjectType := dsSchemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	dsRetryType := dsObjectType.AttributeTypes["retry"].(tftypes.Object)
+	dsConfig := tfsdk.Config{Schema: dsSchemaResp.Schema, Raw: nullObject(dsObjectType, map[string]tftypes.Value{
+		"retry": nullObject(dsRetryType, map[string]tftypes.Value{"retry_on_errors": strings("tls-handshake")}),
+	})}
+	assert.Equal(t, 1, validateRetryBlocks(ctx, dsConfig, dataSourceRetryBlocks).ErrorsCount())
+}
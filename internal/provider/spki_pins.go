@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// WithPinnedSPKI returns a copy of the provider config pinned to the resource's
+// pinned_spki_sha256 keys. An unset list keeps the provider pins.
+func (p *ProviderConfig) WithPinnedSPKI(ctx context.Context, pins types.List) (*ProviderConfig, error) {
+	if pins.IsNull() || pins.IsUnknown() {
+		return p, nil
+	}
+	values, err := ConvertTerraformList(ctx, pins, func(v interface{}) (string, error) {
+		if strVal, ok := v.(types.String); ok {
+			return strVal.ValueString(), nil
+		}
+		return "", fmt.Errorf("expected string, got %T", v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := client.NormalizeSPKIPins(values)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := *p
+	cfg.PinnedSPKISha256 = normalized
+	return &cfg, nil
+}
@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderConfig_WithPinnedSPKI(t *testing.T) {
+	providerPin := "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="
+	resourcePin := "sha256//LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="
+	base := &ProviderConfig{PinnedSPKISha256: []string{providerPin}}
+
+	cfg, err := base.WithPinnedSPKI(context.Background(), types.ListNull(types.StringType))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{providerPin}, cfg.PinnedSPKISha256)
+
+	cfg, err = base.WithPinnedSPKI(context.Background(), types.ListValueMust(types.StringType, []attr.Value{types.StringValue(resourcePin)}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="}, cfg.ToConfigProviderConfig().PinnedSPKISha256)
+	assert.Equal(t, []string{providerPin}, base.PinnedSPKISha256, "provider config is not modified")
+
+	_, err = base.WithPinnedSPKI(context.Background(), types.ListValueMust(types.StringType, []attr.Value{types.StringValue("deadbeef")}))
+	assert.ErrorContains(t, err, "base64 SHA-256")
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stateEncryptionKeyEnv is read when state_encryption_key is not set in the provider block
+const stateEncryptionKeyEnv = "HTTPX_STATE_ENCRYPTION_KEY"
+
+// encryptedStatePrefix marks values encrypted with state_encryption_key
+const encryptedStatePrefix = "httpx-enc:v1:"
+
+// HKDF labels of the subkeys derived from state_encryption_key, so the key used to derive nonces
+// is never the AES-GCM key itself
+const (
+	stateKeyLabelAEAD  = "httpx state encryption aead"
+	stateKeyLabelNonce = "httpx state encryption nonce"
+)
+
+// stateCipher encrypts response payloads before they are stored in state
+type stateCipher struct {
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+// newStateCipher parses a base64 AES-128, AES-192 or AES-256 key and derives the AES-GCM key
+// (of the same size) and the nonce key from it with HKDF-SHA256
+func newStateCipher(encodedKey string) (*stateCipher, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("state_encryption_key must be base64: %w", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, fmt.Errorf("state_encryption_key must decode to 16, 24 or 32 bytes, got %d", len(key))
+	}
+	aeadKey, err := hkdf.Key(sha256.New, key, nil, stateKeyLabelAEAD, len(key))
+	if err != nil {
+		return nil, err
+	}
+	nonceKey, err := hkdf.Key(sha256.New, key, nil, stateKeyLabelNonce, sha256.Size)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(aeadKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &stateCipher{aead: aead, nonceKey: nonceKey}, nil
+}
+
+// encrypt seals plaintext with AES-GCM. The nonce is an HMAC of the plaintext so an unchanged
+// response encrypts to an unchanged value and refreshes don't churn the state. The encryption is
+// deterministic: anyone reading the state can tell that two encrypted values are equal, e.g. the
+// same token in two resources, though nothing else about them is revealed.
+func (c *stateCipher) encrypt(plaintext string) string {
+	mac := hmac.New(sha256.New, c.nonceKey)
+	mac.Write([]byte(plaintext))
+	nonce := mac.Sum(nil)[:c.aead.NonceSize()]
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedStatePrefix + base64.StdEncoding.EncodeToString(sealed)
+}
+
+// decrypt opens a value produced by encrypt. Values without the prefix were stored before
+// encryption was enabled and are returned as is.
+func (c *stateCipher) decrypt(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, encryptedStatePrefix)
+	if !ok {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted state value")
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt state value, was state_encryption_key changed? %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// sealString encrypts a known string value, leaving already encrypted values alone
+func (c *stateCipher) sealString(value types.String) types.String {
+	if value.IsNull() || value.IsUnknown() || strings.HasPrefix(value.ValueString(), encryptedStatePrefix) {
+		return value
+	}
+	return types.StringValue(c.encrypt(value.ValueString()))
+}
+
+// responseStateFields points at the state attributes that carry response data
+type responseStateFields struct {
+	Strings      []*types.String // response_body, error_response_body, transcript and the like
+	BodyJSON     *types.Dynamic
+	StringMaps   []*types.Map // outputs, response_headers and response_links
+	OutputsLists *types.Map
+	Cookies      *types.Map
+}
+
+// resourceResponseFields returns the response data attributes of the httpx_request resource
+func resourceResponseFields(model *HttpxRequestResourceModel) responseStateFields {
+	return responseStateFields{
+		Strings:      []*types.String{&model.ResponseBody, &model.ErrorResponseBody, &model.Transcript},
+		BodyJSON:     &model.ResponseBodyJson,
+		StringMaps:   []*types.Map{&model.Outputs, &model.ResponseHeaders, &model.ResponseLinks},
+		OutputsLists: &model.OutputsLists,
+		Cookies:      &model.ResponseCookies,
+	}
+}
+
+// dataSourceResponseFields returns the response data attributes of the httpx_request data source
+func dataSourceResponseFields(model *HttpxRequestDataSourceModel) responseStateFields {
+	return responseStateFields{
+		Strings:      []*types.String{&model.ResponseBody, &model.OutputsJson, &model.Transcript},
+		BodyJSON:     &model.ResponseBodyJson,
+		StringMaps:   []*types.Map{&model.Outputs, &model.ResponseHeaders, &model.ResponseLinks},
+		OutputsLists: &model.OutputsLists,
+		Cookies:      &model.ResponseCookies,
+	}
+}
+
+// sealResponseState encrypts the attributes carrying response data when state_encryption_key
+// is set. response_body_json would expose the body in clear, so it is not stored.
+func sealResponseState(ctx context.Context, config *ProviderConfig, fields responseStateFields) error {
+	if config == nil || config.StateCipher == nil {
+		return nil
+	}
+	c := config.StateCipher
+
+	for _, value := range fields.Strings {
+		*value = c.sealString(*value)
+	}
+	if fields.BodyJSON != nil {
+		*fields.BodyJSON = types.DynamicNull()
+	}
+	for _, m := range fields.StringMaps {
+		sealed, err := c.sealStringMap(ctx, *m)
+		if err != nil {
+			return err
+		}
+		*m = sealed
+	}
+	if fields.OutputsLists != nil {
+		sealed, err := c.sealListMap(ctx, *fields.OutputsLists)
+		if err != nil {
+			return err
+		}
+		*fields.OutputsLists = sealed
+	}
+	if fields.Cookies != nil {
+		sealed, err := c.sealCookies(ctx, *fields.Cookies)
+		if err != nil {
+			return err
+		}
+		*fields.Cookies = sealed
+	}
+	return nil
+}
+
+// sealStringMap encrypts the values of a map of strings
+func (c *stateCipher) sealStringMap(ctx context.Context, m types.Map) (types.Map, error) {
+	if m.IsNull() || m.IsUnknown() {
+		return m, nil
+	}
+	values := make(map[string]types.String)
+	if diags := m.ElementsAs(ctx, &values, false); diags.HasError() {
+		return m, fmt.Errorf("failed to read map for encryption")
+	}
+	sealed := make(map[string]attr.Value, len(values))
+	for key, value := range values {
+		sealed[key] = c.sealString(value)
+	}
+	return types.MapValueMust(types.StringType, sealed), nil
+}
+
+// sealListMap encrypts every element of outputs_lists
+func (c *stateCipher) sealListMap(ctx context.Context, m types.Map) (types.Map, error) {
+	if m.IsNull() || m.IsUnknown() {
+		return m, nil
+	}
+	lists := make(map[string][]types.String)
+	if diags := m.ElementsAs(ctx, &lists, false); diags.HasError() {
+		return m, fmt.Errorf("failed to read outputs_lists for encryption")
+	}
+	for key, list := range lists {
+		for i := range list {
+			list[i] = c.sealString(list[i])
+		}
+		lists[key] = list
+	}
+	sealed, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, lists)
+	if diags.HasError() {
+		return m, fmt.Errorf("failed to encrypt outputs_lists")
+	}
+	return sealed, nil
+}
+
+// sealCookies encrypts the values of response_cookies, leaving their attributes readable
+func (c *stateCipher) sealCookies(ctx context.Context, m types.Map) (types.Map, error) {
+	if m.IsNull() || m.IsUnknown() {
+		return m, nil
+	}
+	cookies := make(map[string]ResponseCookieModel)
+	if diags := m.ElementsAs(ctx, &cookies, false); diags.HasError() {
+		return m, fmt.Errorf("failed to read response_cookies for encryption")
+	}
+	for name, cookie := range cookies {
+		cookie.Value = c.sealString(cookie.Value)
+		cookies[name] = cookie
+	}
+	sealed, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: responseCookieAttrTypes}, cookies)
+	if diags.HasError() {
+		return m, fmt.Errorf("failed to encrypt response_cookies")
+	}
+	return sealed, nil
+}
+
+// openInterpolationContext decrypts the outputs, response body and response headers loaded from
+// state, so ${self.*} expressions see the clear values
+func openInterpolationContext(config *ProviderConfig, interpolCtx *InterpolationContext) error {
+	if config == nil || config.StateCipher == nil {
+		return nil
+	}
+
+	var err error
+	if interpolCtx.ResponseBody, err = config.StateCipher.decrypt(interpolCtx.ResponseBody); err != nil {
+		return fmt.Errorf("response_body: %w", err)
+	}
+	for key, value := range interpolCtx.Outputs {
+		if interpolCtx.Outputs[key], err = config.StateCipher.decrypt(value); err != nil {
+			return fmt.Errorf("outputs.%s: %w", key, err)
+		}
+	}
+	for name, value := range interpolCtx.ResponseHeaders {
+		if interpolCtx.ResponseHeaders[name], err = config.StateCipher.decrypt(value); err != nil {
+			return fmt.Errorf("response_headers.%s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+const testStateEncryptionKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+func TestStateCipher(t *testing.T) {
+	c, err := newStateCipher(testStateEncryptionKey)
+	assert.NoError(t, err)
+
+	sealed := c.encrypt(`{"token":"secret"}`)
+	assert.True(t, strings.HasPrefix(sealed, encryptedStatePrefix))
+	assert.NotContains(t, sealed, "secret")
+	assert.Equal(t, sealed, c.encrypt(`{"token":"secret"}`), "unchanged values encrypt the same")
+	assert.NotEqual(t, sealed, c.encrypt(`{"token":"other"}`))
+
+	opened, err := c.decrypt(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"token":"secret"}`, opened)
+
+	plain, err := c.decrypt("stored before encryption")
+	assert.NoError(t, err)
+	assert.Equal(t, "stored before encryption", plain)
+
+	other, err := newStateCipher("ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA=")
+	assert.NoError(t, err)
+	_, err = other.decrypt(sealed)
+	assert.ErrorContains(t, err, "was state_encryption_key changed")
+
+	_, err = newStateCipher("not base64!")
+	assert.Error(t, err)
+	_, err = newStateCipher("c2hvcnQ=")
+	assert.ErrorContains(t, err, "16, 24 or 32 bytes")
+
+	// Values are sealed with a derived key, not with state_encryption_key itself
+	rawKey, err := base64.StdEncoding.DecodeString(testStateEncryptionKey)
+	assert.NoError(t, err)
+	block, err := aes.NewCipher(rawKey)
+	assert.NoError(t, err)
+	rawAEAD, err := cipher.NewGCM(block)
+	assert.NoError(t, err)
+	_, err = (&stateCipher{aead: rawAEAD}).decrypt(sealed)
+	assert.Error(t, err)
+}
+
+func TestSealResponseState(t *testing.T) {
+	ctx := context.Background()
+	newModel := func() HttpxRequestResourceModel {
+		return HttpxRequestResourceModel{
+			ResponseBody:      types.StringValue(`{"id":"42"}`),
+			ResponseBodyJson:  ResponseBodyJsonValue(ctx, `{"id":"42"}`),
+			ErrorResponseBody: types.StringNull(),
+			Transcript:        types.StringValue("HTTP/1.1 200 OK"),
+			Outputs:           types.MapValueMust(types.StringType, map[string]attr.Value{"id": types.StringValue("42")}),
+			ResponseHeaders:   types.MapValueMust(types.StringType, map[string]attr.Value{"X-Token": types.StringValue("t-1")}),
+			ResponseLinks:     types.MapNull(types.StringType),
+			OutputsLists: types.MapValueMust(types.ListType{ElemType: types.StringType}, map[string]attr.Value{
+				"ids": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("42")}),
+			}),
+			ResponseCookies: types.MapNull(types.ObjectType{AttrTypes: responseCookieAttrTypes}),
+		}
+	}
+
+	model := newModel()
+	assert.NoError(t, sealResponseState(ctx, &ProviderConfig{}, resourceResponseFields(&model)))
+	assert.Equal(t, `{"id":"42"}`, model.ResponseBody.ValueString(), "nothing is encrypted without a key")
+	assert.False(t, model.ResponseBodyJson.IsNull())
+
+	c, err := newStateCipher(testStateEncryptionKey)
+	assert.NoError(t, err)
+	config := &ProviderConfig{StateCipher: c}
+	model = newModel()
+	assert.NoError(t, sealResponseState(ctx, config, resourceResponseFields(&model)))
+	assert.True(t, strings.HasPrefix(model.ResponseBody.ValueString(), encryptedStatePrefix))
+	assert.True(t, strings.HasPrefix(model.Transcript.ValueString(), encryptedStatePrefix))
+	assert.True(t, model.ErrorResponseBody.IsNull())
+	assert.True(t, model.ResponseBodyJson.IsNull())
+	sealedID := model.Outputs.Elements()["id"].(types.String).ValueString()
+	assert.True(t, strings.HasPrefix(sealedID, encryptedStatePrefix))
+	sealedToken := model.ResponseHeaders.Elements()["X-Token"].(types.String).ValueString()
+	assert.True(t, strings.HasPrefix(sealedToken, encryptedStatePrefix))
+	sealedList := model.OutputsLists.Elements()["ids"].(types.List).Elements()[0].(types.String).ValueString()
+	assert.True(t, strings.HasPrefix(sealedList, encryptedStatePrefix))
+
+	// Sealing again, as Read does with a body kept from state, doesn't double-encrypt
+	sealed := model
+	assert.NoError(t, sealResponseState(ctx, config, resourceResponseFields(&model)))
+	assert.Equal(t, sealed.ResponseBody, model.ResponseBody)
+	assert.Equal(t, sealed.OutputsLists, model.OutputsLists)
+
+	interpolCtx := &InterpolationContext{
+		ResponseBody:    model.ResponseBody.ValueString(),
+		Outputs:         map[string]string{"id": sealedID},
+		ResponseHeaders: map[string]string{"X-Token": sealedToken},
+	}
+	assert.NoError(t, openInterpolationContext(config, interpolCtx))
+	assert.Equal(t, `{"id":"42"}`, interpolCtx.ResponseBody)
+	assert.Equal(t, "42", interpolCtx.Outputs["id"])
+	assert.Equal(t, "t-1", interpolCtx.ResponseHeaders["X-Token"])
+}
+
+func TestStateEncryptionLeavesNoCleartext(t *testing.T) {
+	ctx := context.Background()
+	const secret = "s3cr3t-value"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Session-Token", secret)
+		w.Header().Set("Link", `<https://api.example.com/`+secret+`>; rel="next"`)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: secret})
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_, _ = w.Write([]byte(`{"token":"` + secret + `","ids":["` + secret + `"]}`))
+	}))
+	defer server.Close()
+
+	c, err := newStateCipher(testStateEncryptionKey)
+	assert.NoError(t, err)
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 4096, StateCipher: c}
+
+	var schemaResp resource.SchemaResponse
+	NewHttpxRequestResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	extractType := objectType.AttributeTypes["extract"].(tftypes.List)
+	expectType := objectType.AttributeTypes["expect"].(tftypes.Object)
+	create := func(path string) tftypes.Value {
+		extract := func(name, jsonPath string) tftypes.Value {
+			return nullObject(extractType.ElementType.(tftypes.Object), map[string]tftypes.Value{
+				"name":      tftypes.NewValue(tftypes.String, name),
+				"json_path": tftypes.NewValue(tftypes.String, jsonPath),
+			})
+		}
+		plan := nullObject(objectType, map[string]tftypes.Value{
+			"url":                 tftypes.NewValue(tftypes.String, server.URL+path),
+			"store_response_body": tftypes.NewValue(tftypes.Bool, true),
+			"capture_transcript":  tftypes.NewValue(tftypes.Bool, true),
+			"extract":             tftypes.NewValue(extractType, []tftypes.Value{extract("token", "token"), extract("ids", "ids")}),
+			"expect": nullObject(expectType, map[string]tftypes.Value{
+				"status_codes": tftypes.NewValue(expectType.AttributeTypes["status_codes"], []tftypes.Value{tftypes.NewValue(tftypes.Number, 200)}),
+			}),
+		})
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+		(&HttpxRequestResource{config: providerConfig}).Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan}}, &resp)
+		return resp.State.Raw
+	}
+
+	state := create("/token")
+	assert.False(t, state.IsNull())
+	assert.Contains(t, state.String(), encryptedStatePrefix)
+	assert.NotContains(t, state.String(), secret)
+
+	// A failed create is kept in state with error_response_body
+	state = create("/fail")
+	assert.False(t, state.IsNull())
+	assert.NotContains(t, state.String(), secret)
+
+	// httpx_batch response bodies
+	var batchSchema resource.SchemaResponse
+	NewHttpxBatchResource().Schema(ctx, resource.SchemaRequest{}, &batchSchema)
+	batchType := batchSchema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	requestType := batchType.AttributeTypes["request"].(tftypes.List)
+	spec := nullObject(requestType.ElementType.(tftypes.Object), map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "token"),
+		"url":  tftypes.NewValue(tftypes.String, server.URL+"/token"),
+	})
+	batchResp := resource.CreateResponse{State: tfsdk.State{Schema: batchSchema.Schema, Raw: tftypes.NewValue(batchType, nil)}}
+	(&HttpxBatchResource{config: providerConfig}).Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: batchSchema.Schema, Raw: nullObject(batchType, map[string]tftypes.Value{
+		"request": tftypes.NewValue(requestType, []tftypes.Value{spec}),
+	})}}, &batchResp)
+	assert.False(t, batchResp.Diagnostics.HasError(), batchResp.Diagnostics)
+	assert.NotContains(t, batchResp.State.Raw.String(), secret)
+}
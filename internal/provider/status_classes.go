@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// statusRange is an inclusive range of HTTP status codes
+type statusRange struct {
+	low  int64
+	high int64
+}
+
+// String returns the range in the form it was configured
+func (r statusRange) String() string {
+	if r.low == r.high {
+		return strconv.FormatInt(r.low, 10)
+	}
+	if r.low%100 == 0 && r.high == r.low+99 {
+		return fmt.Sprintf("%dxx", r.low/100)
+	}
+	return fmt.Sprintf("%d-%d", r.low, r.high)
+}
+
+// parseStatusClass parses a status class ("2xx"), an inclusive range ("500-599") or a single code ("404")
+func parseStatusClass(class string) (statusRange, error) {
+	value := strings.ToLower(strings.TrimSpace(class))
+
+	if len(value) == 3 && strings.HasSuffix(value, "xx") && value[0] >= '1' && value[0] <= '5' {
+		low := int64(value[0]-'0') * 100
+		return statusRange{low: low, high: low + 99}, nil
+	}
+
+	if lowStr, highStr, found := strings.Cut(value, "-"); found {
+		low, lowErr := strconv.ParseInt(strings.TrimSpace(lowStr), 10, 64)
+		high, highErr := strconv.ParseInt(strings.TrimSpace(highStr), 10, 64)
+		if lowErr != nil || highErr != nil || low > high {
+			return statusRange{}, fmt.Errorf("invalid status range %q, expected e.g. \"500-599\"", class)
+		}
+		return statusRange{low: low, high: high}, nil
+	}
+
+	code, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return statusRange{}, fmt.Errorf("invalid status class %q, expected e.g. \"2xx\", \"500-599\" or \"404\"", class)
+	}
+	return statusRange{low: code, high: code}, nil
+}
+
+// parseStatusClasses parses a list of status classes, returning an error for the first invalid entry
+func parseStatusClasses(classes []string) ([]statusRange, error) {
+	ranges := make([]statusRange, 0, len(classes))
+	for _, class := range classes {
+		r, err := parseStatusClass(class)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// buildStatusRanges converts a status_classes list attribute, skipping invalid entries with a warning
+func buildStatusRanges(ctx context.Context, attribute string, list types.List) []statusRange {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	classes, err := ConvertTerraformList(ctx, list, func(v interface{}) (string, error) {
+		if strVal, ok := v.(types.String); ok {
+			return strVal.ValueString(), nil
+		}
+		return "", fmt.Errorf("expected string, got %T", v)
+	})
+	if err != nil {
+		return nil
+	}
+
+	ranges := make([]statusRange, 0, len(classes))
+	for _, class := range classes {
+		r, err := parseStatusClass(class)
+		if err != nil {
+			tflog.Warn(ctx, "Ignoring invalid status class", map[string]interface{}{
+				"attribute": attribute,
+				"error":     err.Error(),
+			})
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// statusInRanges reports whether a status code falls into any of the ranges
+func statusInRanges(code int64, ranges []statusRange) bool {
+	for _, r := range ranges {
+		if code >= r.low && code <= r.high {
+			return true
+		}
+	}
+	return false
+}
+
+// statusRangesString renders ranges for error messages, e.g. "[2xx 404]"
+func statusRangesString(ranges []statusRange) string {
+	parts := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		parts = append(parts, r.String())
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatusClass(t *testing.T) {
+	tests := []struct {
+		class       string
+		expected    statusRange
+		expectError bool
+	}{
+		{class: "2xx", expected: statusRange{low: 200, high: 299}},
+		{class: "5XX", expected: statusRange{low: 500, high: 599}},
+		{class: "500-504", expected: statusRange{low: 500, high: 504}},
+		{class: " 404 ", expected: statusRange{low: 404, high: 404}},
+		{class: "6xx", expectError: true},
+		{class: "504-500", expectError: true},
+		{class: "ok", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.class, func(t *testing.T) {
+			got, err := parseStatusClass(tt.class)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestStatusRangesString(t *testing.T) {
+	ranges, err := parseStatusClasses([]string{"2xx", "500-504", "404"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[2xx 500-504 404]", statusRangesString(ranges))
+}
+
+func statusClassList(classes ...string) types.List {
+	values := make([]attr.Value, 0, len(classes))
+	for _, class := range classes {
+		values = append(values, types.StringValue(class))
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+func TestStatusClasses_Retry(t *testing.T) {
+	ctx := context.Background()
+
+	config := BuildRetryConfig(ctx, &RetryModel{
+		RetryOnStatusClasses: statusClassList("5xx", "bogus"),
+	})
+	assert.True(t, config.ShouldRetry(nil, 503))
+	assert.True(t, config.ShouldRetry(nil, 599))
+	assert.False(t, config.ShouldRetry(nil, 429), "default codes are replaced by classes")
+
+	config = BuildRetryConfig(ctx, &RetryModel{
+		RetryOnStatusCodes:   types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(429)}),
+		RetryOnStatusClasses: statusClassList("500-504"),
+	})
+	assert.True(t, config.ShouldRetry(nil, 429))
+	assert.True(t, config.ShouldRetry(nil, 502))
+	assert.False(t, config.ShouldRetry(nil, 505))
+}
+
+func TestStatusClasses_RetryUntil(t *testing.T) {
+	ctx := context.Background()
+	config := BuildRetryUntilConfig(ctx, &RetryUntilModel{StatusClasses: statusClassList("2xx")})
+
+	satisfied, _ := config.EvaluateRetryUntil(ctx, &ResponseResult{StatusCode: 204})
+	assert.True(t, satisfied)
+
+	satisfied, unsatisfied := config.EvaluateRetryUntil(ctx, &ResponseResult{StatusCode: 404})
+	assert.False(t, satisfied)
+	assert.Equal(t, []string{"status code 404 not in required codes [] or classes [2xx]"}, unsatisfied)
+}
+
+func TestStatusClasses_Expect(t *testing.T) {
+	ctx := context.Background()
+	expect := &ExpectModel{
+		StatusCodes:   types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(404)}),
+		StatusClasses: statusClassList("2xx"),
+	}
+
+	assert.NoError(t, ValidateExpectations(ctx, &ResponseResult{StatusCode: 201}, expect))
+	assert.NoError(t, ValidateExpectations(ctx, &ResponseResult{StatusCode: 404}, expect))
+	assert.ErrorContains(t, ValidateExpectations(ctx, &ResponseResult{StatusCode: 500}, expect),
+		"status code 500 not in expected codes [404] or classes [2xx]")
+	assert.ErrorContains(t, ValidateExpectations(ctx, &ResponseResult{StatusCode: 200}, &ExpectModel{StatusClasses: statusClassList("2yy")}),
+		"invalid status class")
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// templateFunctionCallRegex matches a function call expression such as uuid() or env("HOME")
+var templateFunctionCallRegex = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)\((.*)\)$`)
+
+// templateFunctions holds the helper functions available in ${...} templates
+// Supported functions:
+//   - uuid()                     random version 4 UUID
+//   - timestamp()                current UTC time in RFC 3339 format
+//   - env("VAR")                 value of an environment variable (error if unset)
+//   - base64(value)              standard base64 encoding of value
+//   - jsonpath(json, "a.b")      value at a dot-path in a JSON document
+var templateFunctions = map[string]func(args []string) (string, error){
+	"uuid": func(args []string) (string, error) {
+		if err := expectTemplateArgs("uuid", args, 0); err != nil {
+			return "", err
+		}
+		return generateUUID()
+	},
+	"timestamp": func(args []string) (string, error) {
+		if err := expectTemplateArgs("timestamp", args, 0); err != nil {
+			return "", err
+		}
+		return time.Now().UTC().Format(time.RFC3339), nil
+	},
+	"env": func(args []string) (string, error) {
+		if err := expectTemplateArgs("env", args, 1); err != nil {
+			return "", err
+		}
+		value, ok := os.LookupEnv(args[0])
+		if !ok {
+			return "", fmt.Errorf("environment variable %q is not set", args[0])
+		}
+		return value, nil
+	},
+	"base64": func(args []string) (string, error) {
+		if err := expectTemplateArgs("base64", args, 1); err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString([]byte(args[0])), nil
+	},
+	"jsonpath": func(args []string) (string, error) {
+		if err := expectTemplateArgs("jsonpath", args, 2); err != nil {
+			return "", err
+		}
+		jsonData, err := decodeJSON(args[0])
+		if err != nil {
+			return "", fmt.Errorf("jsonpath(): value is not valid JSON: %w", err)
+		}
+		value, err := evaluateJsonPath(jsonData, args[1])
+		if err != nil {
+			return "", fmt.Errorf("jsonpath(): %w", err)
+		}
+		return formatExtractedValue(value), nil
+	},
+}
+
+// parseTemplateFunctionCall splits a function call expression into its name and raw arguments
+func parseTemplateFunctionCall(expr string) (string, []string, bool) {
+	submatches := templateFunctionCallRegex.FindStringSubmatch(expr)
+	if len(submatches) < 3 {
+		return "", nil, false
+	}
+	return submatches[1], splitTemplateArgs(submatches[2]), true
+}
+
+// splitTemplateArgs splits a comma-separated argument list, ignoring commas
+// inside quoted strings and nested function calls
+func splitTemplateArgs(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	var args []string
+	depth := 0
+	inQuote := false
+	start := 0
+	for i := 0; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			if inQuote {
+				i++ // Skip escaped character
+			}
+		case '"':
+			inQuote = !inQuote
+		case '(':
+			if !inQuote {
+				depth++
+			}
+		case ')':
+			if !inQuote {
+				depth--
+			}
+		case ',':
+			if !inQuote && depth == 0 {
+				args = append(args, strings.TrimSpace(raw[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	args = append(args, strings.TrimSpace(raw[start:]))
+	return args
+}
+
+// callTemplateFunction invokes a template helper function by name
+func callTemplateFunction(name string, args []string) (string, error) {
+	fn, ok := templateFunctions[name]
+	if !ok {
+		return "", fmt.Errorf("unknown template function: %s()", name)
+	}
+	return fn(args)
+}
+
+// expectTemplateArgs validates the number of arguments passed to a template function
+func expectTemplateArgs(name string, args []string, count int) error {
+	if len(args) != count {
+		return fmt.Errorf("%s() expects %d argument(s), got %d", name, count, len(args))
+	}
+	return nil
+}
+
+// generateUUID returns a random RFC 4122 version 4 UUID
+func generateUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate uuid: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // Variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
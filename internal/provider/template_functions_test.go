@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolateString_Functions(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("HTTPX_TEST_TOKEN", "secret-token")
+
+	interpolCtx := &InterpolationContext{
+		ID: "res-1",
+		Outputs: map[string]string{
+			"user": "alice",
+		},
+		ResponseBody: `{"data": {"id": "abc", "count": 3, "big": 12345678901234567890}}`,
+	}
+
+	tests := []struct {
+		name        string
+		text        string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "env",
+			text:     `Bearer ${env("HTTPX_TEST_TOKEN")}`,
+			expected: "Bearer secret-token",
+		},
+		{
+			name:        "env not set",
+			text:        `${env("HTTPX_TEST_UNSET_VARIABLE")}`,
+			expectError: true,
+		},
+		{
+			name:     "base64 of literal",
+			text:     `Basic ${base64("user:pass")}`,
+			expected: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name:     "base64 of self reference",
+			text:     `${base64(self.outputs.user)}`,
+			expected: "YWxpY2U=",
+		},
+		{
+			name:     "nested function calls",
+			text:     `${base64(env("HTTPX_TEST_TOKEN"))}`,
+			expected: "c2VjcmV0LXRva2Vu",
+		},
+		{
+			name:     "jsonpath on response body",
+			text:     `/items/${jsonpath(self.response_body, "data.id")}`,
+			expected: "/items/abc",
+		},
+		{
+			name:     "jsonpath number",
+			text:     `${jsonpath(self.response_body, "data.count")}`,
+			expected: "3",
+		},
+		{
+			name:     "jsonpath large integer",
+			text:     `${jsonpath(self.response_body, "data.big")}`,
+			expected: "12345678901234567890",
+		},
+		{
+			name:        "jsonpath missing path",
+			text:        `${jsonpath(self.response_body, "data.missing")}`,
+			expectError: true,
+		},
+		{
+			name:     "literal containing braces and commas",
+			text:     `${base64("{\"a\":1,\"b\":2}")}`,
+			expected: "eyJhIjoxLCJiIjoyfQ==",
+		},
+		{
+			name:        "unknown function",
+			text:        `${nope()}`,
+			expectError: true,
+		},
+		{
+			name:        "wrong argument count",
+			text:        `${base64()}`,
+			expectError: true,
+		},
+		{
+			name:     "unrecognized expressions are left unchanged",
+			text:     `${var.name}-${self.id}`,
+			expected: "${var.name}-res-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := InterpolateString(ctx, tt.text, interpolCtx)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestInterpolateString_UUIDAndTimestamp(t *testing.T) {
+	ctx := context.Background()
+	interpolCtx := &InterpolationContext{Outputs: map[string]string{}}
+
+	first, err := InterpolateString(ctx, "${uuid()}", interpolCtx)
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), first)
+
+	second, err := InterpolateString(ctx, "${uuid()}", interpolCtx)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	ts, err := InterpolateString(ctx, "${timestamp()}", interpolCtx)
+	assert.NoError(t, err)
+	_, err = time.Parse(time.RFC3339, ts)
+	assert.NoError(t, err)
+}
+
+func TestSplitTemplateArgs(t *testing.T) {
+	assert.Nil(t, splitTemplateArgs(""))
+	assert.Equal(t, []string{"self.response_body", `"a.b"`}, splitTemplateArgs(`self.response_body, "a.b"`))
+	assert.Equal(t, []string{`env("X")`, `"a,b"`}, splitTemplateArgs(`env("X"), "a,b"`))
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/davidshato/terraform-provider-httpx/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// transcriptBodyLimit caps how much of each request and response body a transcript keeps
+const transcriptBodyLimit = 2048
+
+// WithCaptureTranscript returns a copy of the provider config that records a transcript of
+// every attempt when capture_transcript is true
+func (p *ProviderConfig) WithCaptureTranscript(enabled types.Bool) *ProviderConfig {
+	cfg := *p
+	cfg.CaptureTranscript = enabled.ValueBool()
+	return &cfg
+}
+
+// renderAttemptTranscript renders one attempt as "> " request lines followed by "< " response
+// lines. Headers are redacted like request_preview and bodies are truncated and scrubbed.
+func renderAttemptTranscript(req *http.Request, result *ResponseResult, err error, redaction utils.Redaction, attempt int64) string {
+	lines := []string{fmt.Sprintf("* attempt %d", attempt)}
+
+	for _, line := range strings.Split(renderRequestSummary(req, redaction), "\n") {
+		lines = append(lines, "> "+line)
+	}
+	if body := requestBodySnippet(req); body != "" {
+		lines = append(lines, "> "+redaction.Apply(body))
+	}
+
+	switch {
+	case result != nil && result.StatusCode != 0:
+		lines = append(lines, fmt.Sprintf("< %d", result.StatusCode))
+		redactList := append([]string{"Set-Cookie"}, redaction.Headers...)
+		names := make([]string, 0, len(result.Headers))
+		for name := range result.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			lines = append(lines, fmt.Sprintf("< %s: %s", name, utils.RedactHeaderValue(name, result.Headers[name], redactList)))
+		}
+		if result.Body != "" {
+			lines = append(lines, "< "+redaction.Apply(utils.TruncateString(result.Body, transcriptBodyLimit)))
+		}
+	case err != nil:
+		// Errors from ExecuteRequest are already redacted
+		lines = append(lines, "! "+err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// requestBodySnippet returns the start of the request body without consuming it
+func requestBodySnippet(req *http.Request) string {
+	if req.GetBody == nil || req.ContentLength == 0 {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, transcriptBodyLimit+1))
+	if err != nil {
+		return ""
+	}
+	return utils.TruncateString(string(data), transcriptBodyLimit)
+}
+
+// TranscriptValue joins the transcripts of the recorded attempts into the transcript attribute value
+func TranscriptValue(records []AttemptRecord) types.String {
+	parts := make([]string, 0, len(records))
+	for _, record := range records {
+		if record.Transcript != "" {
+			parts = append(parts, record.Transcript)
+		}
+	}
+	if len(parts) == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(strings.Join(parts, "\n\n"))
+}
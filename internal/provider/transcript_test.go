@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureTranscript(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Header().Set("X-Request-Id", "req-1")
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("try again"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"token":"Bearer tok3n123"}`))
+	}))
+	defer server.Close()
+
+	providerConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024, RedactHeaders: []string{"X-Internal-Token"}}
+	captureConfig := providerConfig.WithCaptureTranscript(types.BoolValue(true))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/items", nil)
+	assert.NoError(t, err)
+	req.Header.Set("X-Internal-Token", "secret")
+
+	retryConfig := &RetryConfig{Attempts: 3, MinDelayMs: 1, MaxDelayMs: 1, Backoff: "fixed", RetryOnStatusCodes: []int64{503}}
+	result, err := ExecuteRequestWithRetry(context.Background(), req, captureConfig, retryConfig, nil, nil)
+	assert.NoError(t, err)
+
+	transcript := TranscriptValue(result.AttemptHistory).ValueString()
+	assert.Contains(t, transcript, "* attempt 1\n> GET "+server.URL+"/items\n")
+	assert.Contains(t, transcript, "> X-Internal-Token: [REDACTED]")
+	assert.Contains(t, transcript, "< 503\n")
+	assert.Contains(t, transcript, "< try again")
+	assert.Contains(t, transcript, "* attempt 2\n")
+	assert.Contains(t, transcript, "< Set-Cookie: [REDACTED]")
+	assert.Contains(t, transcript, "< X-Request-Id: req-1")
+	assert.Contains(t, transcript, `< {"token":"Bearer [REDACTED]"}`)
+	assert.NotContains(t, transcript, "secret")
+	assert.NotContains(t, transcript, "tok3n123")
+
+	// Request bodies are included without being consumed
+	req, err = http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/items", strings.NewReader(`{"name":"test"}`))
+	assert.NoError(t, err)
+	result, err = ExecuteRequestWithRetry(context.Background(), req, captureConfig, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, TranscriptValue(result.AttemptHistory).ValueString(), "> Body: 15 bytes\n> {\"name\":\"test\"}\n< 200")
+
+	// Without capture_transcript no transcript is kept
+	req, err = http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/items", nil)
+	assert.NoError(t, err)
+	result, err = ExecuteRequestWithRetry(context.Background(), req, providerConfig, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, TranscriptValue(result.AttemptHistory).IsNull())
+}
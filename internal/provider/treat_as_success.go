@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// credentialHeaders are dropped from follow-up requests to another origin, as net/http does on
+// cross-host redirects. The provider's redact_headers are dropped as well.
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization", "Www-Authenticate", "Cookie", "Cookie2", "X-Api-Key"}
+
+// acceptErrorResponse applies treat_as_success and then on_conflict to the result of the
+// resource's request and its execution error. It returns the result to store, whether an error
+// response was accepted (accepted responses skip expect), and the remaining error.
+func acceptErrorResponse(ctx context.Context, model *HttpxRequestResourceModel, httpReq *http.Request, execConfig *ProviderConfig, retryConfig *RetryConfig, result *ResponseResult, execErr error) (*ResponseResult, bool, error) {
+	result, accepted, err := applyTreatAsSuccess(ctx, model.TreatAsSuccess, httpReq, execConfig, retryConfig, result, execErr)
+	if accepted {
+		return result, true, err
+	}
+	return applyOnConflict(ctx, model.OnConflict, httpReq, execConfig, retryConfig, result, err)
+}
+
+// responseMatches reports whether result has one of statusCodes (or defaultCodes when
+// statusCodes is unset) and meets jsonPathEquals. block names the block in errors.
+func responseMatches(ctx context.Context, block string, statusCodes types.List, defaultCodes []int64, jsonPathEquals types.Map, result *ResponseResult) (bool, error) {
+	if result == nil || result.StatusCode == 0 {
+		return false, nil
+	}
+
+	codes := defaultCodes
+	if !statusCodes.IsNull() && !statusCodes.IsUnknown() {
+		var err error
+		codes, err = ConvertTerraformList(ctx, statusCodes, func(v interface{}) (int64, error) {
+			if intVal, ok := v.(types.Int64); ok {
+				return intVal.ValueInt64(), nil
+			}
+			return 0, fmt.Errorf("expected int64, got %T", v)
+		})
+		if err != nil {
+			return false, fmt.Errorf("invalid %s.status_codes: %w", block, err)
+		}
+	}
+	if len(codes) > 0 {
+		found := false
+		for _, code := range codes {
+			if code == result.StatusCode {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+
+	if !jsonPathEquals.IsNull() && !jsonPathEquals.IsUnknown() {
+		conditions, err := ConvertTerraformMap(ctx, jsonPathEquals)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s.json_path_equals: %w", block, err)
+		}
+		if !checkJsonPathConditions(ctx, result.Body, conditions) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// applyTreatAsSuccess accepts a result of the original request (and its execution error) that
+// matches treat_as_success. The returned result is the follow_up_url response when one is set,
+// since an "already exists" response rarely carries the object the outputs are extracted from.
+// Results that don't match are returned unchanged with accepted = false.
+func applyTreatAsSuccess(ctx context.Context, treat *TreatAsSuccessModel, httpReq *http.Request, execConfig *ProviderConfig, retryConfig *RetryConfig, result *ResponseResult, execErr error) (*ResponseResult, bool, error) {
+	if treat == nil {
+		return result, false, execErr
+	}
+	accepted, err := responseMatches(ctx, "treat_as_success", treat.StatusCodes, nil, treat.JsonPathEquals, result)
+	if err != nil {
+		return result, false, err
+	}
+	if !accepted {
+		return result, false, execErr
+	}
+	if treat.FollowUpUrl.IsNull() || treat.FollowUpUrl.IsUnknown() || treat.FollowUpUrl.ValueString() == "" {
+		return result, true, nil
+	}
+
+	followUp, err := followUpRequest(ctx, httpReq, execConfig, retryConfig, result, http.MethodGet, treat.FollowUpUrl.ValueString(), nil, nil, "")
+	if err != nil {
+		return followUp, true, fmt.Errorf("treat_as_success follow-up %w", err)
+	}
+	return followUp, true, nil
+}
+
+// followUpRequest executes a request made in response to result, such as a GET of an object
+// that already exists. rawURL, header values and body may reference the response with
+// ${self.response_body}, ${self.response_headers.NAME} and template functions; relative URLs
+// resolve against the original request's URL. The original request's headers are sent along with
+// headers, minus those describing the original body, and minus credentials when the URL is on
+// another scheme or host.
+// A non-2xx response is an error. Errors start with the method, e.g. "GET failed: ...".
+func followUpRequest(ctx context.Context, httpReq *http.Request, execConfig *ProviderConfig, retryConfig *RetryConfig, result *ResponseResult, method string, rawURL string, headers map[string]string, query map[string]string, body string) (*ResponseResult, error) {
+	interpolCtx := &InterpolationContext{
+		ResponseBody:    result.Body,
+		StatusCode:      result.StatusCode,
+		ResponseHeaders: result.Headers,
+	}
+	expandedURL, err := InterpolateString(ctx, rawURL, interpolCtx)
+	if err != nil {
+		return result, fmt.Errorf("%s failed: invalid URL: %w", method, err)
+	}
+	target, err := httpReq.URL.Parse(expandedURL)
+	if err != nil {
+		return result, fmt.Errorf("%s failed: invalid URL: %w", method, err)
+	}
+	if len(query) > 0 {
+		values := target.Query()
+		for name, value := range query {
+			values.Set(name, value)
+		}
+		target.RawQuery = values.Encode()
+	}
+	expandedHeaders, err := InterpolateMap(ctx, headers, interpolCtx)
+	if err != nil {
+		return result, fmt.Errorf("%s failed: invalid headers: %w", method, err)
+	}
+	expandedBody, err := InterpolateString(ctx, body, interpolCtx)
+	if err != nil {
+		return result, fmt.Errorf("%s failed: invalid body: %w", method, err)
+	}
+
+	var bodyReader io.Reader
+	if expandedBody != "" {
+		bodyReader = strings.NewReader(expandedBody)
+	}
+	followUpReq, err := http.NewRequestWithContext(ctx, method, target.String(), bodyReader)
+	if err != nil {
+		return result, fmt.Errorf("%s failed: %w", method, err)
+	}
+	followUpReq.Header = httpReq.Header.Clone()
+	for _, name := range []string{"Content-Type", "Content-Length", "Content-Digest", "Idempotency-Key"} {
+		followUpReq.Header.Del(name)
+	}
+	// The URL may come from the response, so credentials only go back to the same origin
+	if !sameOrigin(httpReq.URL, followUpReq.URL) {
+		for _, name := range append(append([]string{}, credentialHeaders...), execConfig.RedactHeaders...) {
+			followUpReq.Header.Del(name)
+		}
+	}
+	for name, value := range expandedHeaders {
+		followUpReq.Header.Set(name, value)
+	}
+
+	followUp, err := ExecuteRequestWithRetry(ctx, followUpReq, execConfig, retryConfig, nil, nil)
+	if err != nil {
+		return followUp, fmt.Errorf("%s failed: %w", method, err)
+	}
+	if followUp.StatusCode < 200 || followUp.StatusCode > 299 {
+		return followUp, fmt.Errorf("%s returned status %d", method, followUp.StatusCode)
+	}
+	followUp.AttemptCount += result.AttemptCount
+	return followUp, nil
+}
+
+// sameOrigin reports whether a and b have the same scheme and host, including the port
+func sameOrigin(a *url.URL, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
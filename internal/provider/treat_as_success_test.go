@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyTreatAsSuccess(t *testing.T) {
+	var followUpAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error": {"code": "ALREADY_EXISTS"}, "existing": {"id": "abc"}}`))
+		case r.URL.Path == "/items/abc":
+			followUpAuth = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"id": "abc", "name": "example"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	execConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024}
+	retryConfig := &RetryConfig{Attempts: 1}
+	create := func() (*http.Request, *ResponseResult) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/items", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("Content-Type", "application/json")
+		result, err := ExecuteRequestWithRetry(ctx, req, execConfig, retryConfig, nil, nil)
+		assert.NoError(t, err)
+		return req, result
+	}
+	treat := &TreatAsSuccessModel{
+		StatusCodes:    types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(409)}),
+		JsonPathEquals: types.MapValueMust(types.StringType, map[string]attr.Value{"error.code": types.StringValue("ALREADY_EXISTS")}),
+		FollowUpUrl:    types.StringNull(),
+	}
+
+	// Without treat_as_success the result and error pass through
+	req, result := create()
+	got, accepted, err := applyTreatAsSuccess(ctx, nil, req, execConfig, retryConfig, result, nil)
+	assert.False(t, accepted)
+	assert.NoError(t, err)
+	assert.Same(t, result, got)
+
+	req, result = create()
+	got, accepted, err = applyTreatAsSuccess(ctx, treat, req, execConfig, retryConfig, result, nil)
+	assert.True(t, accepted)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(409), got.StatusCode)
+
+	// A different error code is not accepted
+	other := *treat
+	other.JsonPathEquals = types.MapValueMust(types.StringType, map[string]attr.Value{"error.code": types.StringValue("LOCKED")})
+	req, result = create()
+	_, accepted, _ = applyTreatAsSuccess(ctx, &other, req, execConfig, retryConfig, result, nil)
+	assert.False(t, accepted)
+
+	// The follow-up GET replaces the result and keeps the request's authentication
+	treat.FollowUpUrl = types.StringValue(`/items/${jsonpath(self.response_body, "existing.id")}`)
+	req, result = create()
+	got, accepted, err = applyTreatAsSuccess(ctx, treat, req, execConfig, retryConfig, result, nil)
+	assert.True(t, accepted)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200), got.StatusCode)
+	assert.Equal(t, `{"id": "abc", "name": "example"}`, got.Body)
+	assert.Equal(t, int64(2), got.AttemptCount)
+	assert.Equal(t, "Bearer token", followUpAuth)
+
+	treat.FollowUpUrl = types.StringValue("/items/missing")
+	req, result = create()
+	_, accepted, err = applyTreatAsSuccess(ctx, treat, req, execConfig, retryConfig, result, nil)
+	assert.True(t, accepted)
+	assert.EqualError(t, err, "treat_as_success follow-up GET returned status 404")
+}
+
+func TestFollowUpRequestCrossOriginCredentials(t *testing.T) {
+	var received http.Header
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"id": "abc"}`))
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		if r.Method == http.MethodPost {
+			w.Header().Set("Location", other.URL+"/items/abc")
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	execConfig := &ProviderConfig{TimeoutMs: 5000, MaxResponseBodyBytes: 1024, RedactHeaders: []string{"X-Session"}}
+	followUp := func(rawURL string) http.Header {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/items", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("Cookie", "session=secret")
+		req.Header.Set("X-Api-Key", "key")
+		req.Header.Set("X-Session", "abc")
+		req.Header.Set("X-Request-Source", "terraform")
+		result, err := ExecuteRequestWithRetry(ctx, req, execConfig, nil, nil, nil)
+		assert.NoError(t, err)
+		_, err = followUpRequest(ctx, req, execConfig, nil, result, http.MethodGet, rawURL, nil, nil, "")
+		assert.NoError(t, err)
+		return received
+	}
+
+	// A URL taken from the response must not send credentials to another host
+	headers := followUp("${self.response_headers.Location}")
+	for _, name := range []string{"Authorization", "Cookie", "X-Api-Key", "X-Session"} {
+		assert.Empty(t, headers.Get(name), name)
+	}
+	assert.Equal(t, "terraform", headers.Get("X-Request-Source"))
+
+	// The same origin keeps them
+	headers = followUp("/items/abc")
+	assert.Equal(t, "Bearer token", headers.Get("Authorization"))
+	assert.Equal(t, "session=secret", headers.Get("Cookie"))
+	assert.Equal(t, "abc", headers.Get("X-Session"))
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -50,16 +52,87 @@ func TruncateString(s string, maxLen int) string {
 	return s[:maxLen] + "... [TRUNCATED]"
 }
 
-// RedactError redacts sensitive information from error messages
-func RedactError(errMsg string, redactList []string) string {
-	// Simple redaction - replace common sensitive patterns
-	result := errMsg
-	for _, pattern := range redactList {
-		// This is a simple implementation; could be enhanced with regex
-		if strings.Contains(strings.ToLower(result), strings.ToLower(pattern)) {
-			result = strings.ReplaceAll(result, pattern, "[REDACTED]")
+// Redaction describes what to scrub from text before it reaches logs, diagnostics or state
+type Redaction struct {
+	// Headers whose values are masked wherever "Name: value" or "Name=value" appears
+	Headers []string
+	// QueryParams whose values are masked in URLs
+	QueryParams []string
+	// Patterns are additional expressions whose matches are masked entirely
+	Patterns []*regexp.Regexp
+}
+
+var (
+	// authSchemePattern matches credentials following a Bearer or Basic auth scheme
+	authSchemePattern = regexp.MustCompile(`(?i)\b(Bearer|Basic)\s+([A-Za-z0-9\-._~+/]+=*)`)
+	// jwtPattern matches JSON Web Tokens anywhere in the text
+	jwtPattern = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+)
+
+// Apply returns s with header values, auth scheme credentials, JWTs, query parameters
+// and configured patterns replaced by [REDACTED]
+func (r Redaction) Apply(s string) string {
+	for _, name := range r.Headers {
+		if name == "" {
+			continue
+		}
+		// Covers "Name: value", "Name=value", JSON "Name":"value" and Go's map[Name:[value]]
+		re := regexp.MustCompile(`(?i)(\b` + regexp.QuoteMeta(name) + `"?\s*[:=]\s*[\["]?)[^\]"\r\n,;&]+`)
+		s = re.ReplaceAllString(s, "${1}[REDACTED]")
+	}
+
+	s = authSchemePattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := authSchemePattern.FindStringSubmatch(match)
+		// Plain words such as "Basic authentication" are not credentials
+		if strings.Trim(parts[2], "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") == "" {
+			return match
+		}
+		return parts[1] + " [REDACTED]"
+	})
+	s = jwtPattern.ReplaceAllString(s, "[REDACTED]")
+	s = RedactQueryParams(s, r.QueryParams)
+
+	for _, re := range r.Patterns {
+		s = re.ReplaceAllString(s, "[REDACTED]")
+	}
+	return s
+}
+
+// CompileRedactPatterns compiles redact_patterns entries
+func CompileRedactPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
 		}
+		compiled = append(compiled, re)
 	}
-	return result
+	return compiled, nil
 }
 
+// RedactError redacts the values of the listed headers and any auth credentials from an error message
+func RedactError(errMsg string, redactList []string) string {
+	return Redaction{Headers: redactList}.Apply(errMsg)
+}
+
+// RedactQueryParams masks the values of the named query parameters wherever they appear in s,
+// which may be a URL or a message embedding one. Names are matched case-insensitively.
+func RedactQueryParams(s string, params []string) string {
+	if len(params) == 0 {
+		return s
+	}
+
+	names := make([]string, 0, len(params))
+	for _, p := range params {
+		if p != "" {
+			names = append(names, regexp.QuoteMeta(p))
+		}
+	}
+	if len(names) == 0 {
+		return s
+	}
+
+	re := regexp.MustCompile(`(?i)([?&](?:` + strings.Join(names, "|") + `)=)[^&#\s"']*`)
+	return re.ReplaceAllString(s, "${1}[REDACTED]")
+}
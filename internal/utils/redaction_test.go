@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"regexp"
 	"testing"
 )
 
@@ -169,8 +170,8 @@ func TestRedactError(t *testing.T) {
 			name:       "redact authorization",
 			errMsg:     "error: Authorization: Bearer secret-token",
 			redactList: []string{"Authorization"},
-			shouldContain: "[REDACTED]",
-			shouldNotContain: "", // RedactError only replaces header names, not values
+			shouldContain: "Authorization: [REDACTED]",
+			shouldNotContain: "secret-token",
 		},
 		{
 			name:       "no redaction",
@@ -179,10 +180,37 @@ func TestRedactError(t *testing.T) {
 			shouldContain: "error: connection failed",
 		},
 		{
-			name:       "case sensitive pattern match",
+			name:       "header name without a value is kept",
 			errMsg:     "error: Authorization header missing",
 			redactList: []string{"Authorization"},
-			shouldContain: "[REDACTED]",
+			shouldContain: "Authorization header missing",
+		},
+		{
+			name:       "case insensitive header value",
+			errMsg:     `request rejected: {"x-internal-token":"abc123"}`,
+			redactList: []string{"X-Internal-Token"},
+			shouldContain: `"x-internal-token":"[REDACTED]"`,
+			shouldNotContain: "abc123",
+		},
+		{
+			name:       "go header map",
+			errMsg:     "headers: map[X-Api-Key:[k-123] Accept:[*/*]]",
+			redactList: []string{"X-Api-Key"},
+			shouldContain: "Accept:[*/*]",
+			shouldNotContain: "k-123",
+		},
+		{
+			name:       "bearer token outside a listed header",
+			errMsg:     "upstream said: invalid token Bearer eyJhbGciOi.J9.abc",
+			redactList: []string{},
+			shouldContain: "Bearer [REDACTED]",
+			shouldNotContain: "eyJhbGciOi",
+		},
+		{
+			name:       "basic as a plain word",
+			errMsg:     "Basic authentication failed",
+			redactList: []string{},
+			shouldContain: "Basic authentication failed",
 		},
 	}
 
@@ -199,6 +227,79 @@ func TestRedactError(t *testing.T) {
 	}
 }
 
+func TestRedactQueryParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		params   []string
+		expected string
+	}{
+		{
+			name:     "no params",
+			input:    "https://api.example.com/v1?api_key=secret",
+			params:   nil,
+			expected: "https://api.example.com/v1?api_key=secret",
+		},
+		{
+			name:     "redact single param",
+			input:    "https://api.example.com/v1?api_key=secret&page=2",
+			params:   []string{"api_key"},
+			expected: "https://api.example.com/v1?api_key=[REDACTED]&page=2",
+		},
+		{
+			name:     "case insensitive and repeated",
+			input:    "https://s3.example.com/obj?X-Amz-Signature=abc123&x-amz-signature=def#top",
+			params:   []string{"x-amz-signature"},
+			expected: "https://s3.example.com/obj?X-Amz-Signature=[REDACTED]&x-amz-signature=[REDACTED]#top",
+		},
+		{
+			name:     "url embedded in error message",
+			input:    `Get "https://api.example.com/v1?token=secret": dial tcp: connection refused`,
+			params:   []string{"token"},
+			expected: `Get "https://api.example.com/v1?token=[REDACTED]": dial tcp: connection refused`,
+		},
+		{
+			name:     "prefix of another param is not matched",
+			input:    "https://api.example.com/v1?token_type=bearer",
+			params:   []string{"token"},
+			expected: "https://api.example.com/v1?token_type=bearer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RedactQueryParams(tt.input, tt.params)
+			if result != tt.expected {
+				t.Errorf("RedactQueryParams() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRedactionApply(t *testing.T) {
+	redaction := Redaction{
+		Headers:     []string{"Authorization"},
+		QueryParams: []string{"sig"},
+		Patterns:    []*regexp.Regexp{regexp.MustCompile(`acct_[0-9]+`)},
+	}
+
+	input := `Get "https://api.example.com/v1?sig=s3cr3t": account acct_42 denied, token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig`
+	expected := `Get "https://api.example.com/v1?sig=[REDACTED]": account [REDACTED] denied, token [REDACTED]`
+	if result := redaction.Apply(input); result != expected {
+		t.Errorf("Apply() = %v, want %v", result, expected)
+	}
+}
+
+func TestCompileRedactPatterns(t *testing.T) {
+	patterns, err := CompileRedactPatterns([]string{`sk_live_[A-Za-z0-9]+`})
+	if err != nil || len(patterns) != 1 {
+		t.Fatalf("CompileRedactPatterns() = %v, %v", patterns, err)
+	}
+	if _, err := CompileRedactPatterns([]string{`(`}); err == nil {
+		t.Error("CompileRedactPatterns() expected error for invalid pattern")
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 || 
 		(len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || 
@@ -29,6 +29,10 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	if flushErr := provider.FlushMetricsSummary(); flushErr != nil {
+		log.Printf("[ERROR] %s", flushErr)
+	}
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}